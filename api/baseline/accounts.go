@@ -0,0 +1,86 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+// ListBPIAccounts retrieves a paginated list of BPI subject accounts scoped to the given API token
+func (s *Service) ListBPIAccounts(params map[string]interface{}) ([]*BPIAccount, error) {
+	status, resp, err := s.Get("bpi_accounts", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list BPI accounts; status: %v", status)
+	}
+
+	accounts, err := api.DecodeList[BPIAccount](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list BPI accounts; status: %v; %s", status, err.Error())
+	}
+
+	return accounts, nil
+}
+
+// ListBPIAccounts is the package-level variant of Service.ListBPIAccounts, using the default service configuration
+func ListBPIAccounts(token string, params map[string]interface{}) ([]*BPIAccount, error) {
+	return InitBaselineService(token).ListBPIAccounts(params)
+}
+
+// CreateBPIAccount creates a new BPI subject account
+func (s *Service) CreateBPIAccount(params map[string]interface{}) (*BPIAccount, error) {
+	status, resp, err := s.Post("bpi_accounts", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create BPI account; status: %v; %s", status, err.Error())
+	}
+
+	if status != 201 {
+		return nil, newError("failed to create BPI account", status, resp)
+	}
+
+	account := &BPIAccount{}
+	accountraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(accountraw, &account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create BPI account; status: %v; %s", status, err.Error())
+	}
+
+	return account, nil
+}
+
+// CreateBPIAccount is the package-level variant of Service.CreateBPIAccount, using the default service configuration
+func CreateBPIAccount(token string, params map[string]interface{}) (*BPIAccount, error) {
+	return InitBaselineService(token).CreateBPIAccount(params)
+}
+
+// GetBPIAccountDetails retrieves details for the given BPI subject account, including
+// its recovery policy and verification methods
+func (s *Service) GetBPIAccountDetails(accountID string, params map[string]interface{}) (*BPIAccount, error) {
+	uri := fmt.Sprintf("bpi_accounts/%s", accountID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch BPI account details; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch BPI account details; status: %v", status)
+	}
+
+	account := &BPIAccount{}
+	accountraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(accountraw, &account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch BPI account details; status: %v; %s", status, err.Error())
+	}
+
+	return account, nil
+}
+
+// GetBPIAccountDetails is the package-level variant of Service.GetBPIAccountDetails, using the default service configuration
+func GetBPIAccountDetails(token, accountID string, params map[string]interface{}) (*BPIAccount, error) {
+	return InitBaselineService(token).GetBPIAccountDetails(accountID, params)
+}
@@ -0,0 +1,99 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// WorkflowAnalytics are statistics describing the instances of a workflow, or of each of the
+// workflows within a workgroup, over the requested period (i.e., using the start and end params)
+type WorkflowAnalytics struct {
+	WorkflowID  *common.ID   `sql:"-" json:"workflow_id,omitempty"`
+	WorkgroupID *common.ID   `sql:"-" json:"workgroup_id,omitempty"`
+	Errors      []*api.Error `sql:"-" json:"errors,omitempty"`
+	Start       *time.Time   `sql:"-" json:"start,omitempty"`
+	End         *time.Time   `sql:"-" json:"end,omitempty"`
+
+	// Instances is the total number of workflow instances
+	Instances uint64 `sql:"-" json:"instances"`
+
+	// InstancesByStatus is the number of workflow instances, keyed on status (i.e., init, running, completed)
+	InstancesByStatus map[string]uint64 `sql:"-" json:"instances_by_status,omitempty"`
+
+	// AverageCycleTime is the mean number of seconds elapsed between the creation and completion
+	// of completed workflow instances
+	AverageCycleTime float64 `sql:"-" json:"average_cycle_time,omitempty"`
+
+	// PendingWorksteps are the worksteps awaiting execution or approval, for each counterparty
+	PendingWorksteps []*CounterpartyPendingWorksteps `sql:"-" json:"pending_worksteps,omitempty"`
+
+	// Workflows are the analytics for each workflow, when analytics are retrieved for a workgroup
+	Workflows []*WorkflowAnalytics `sql:"-" json:"workflows,omitempty"`
+}
+
+// CounterpartyPendingWorksteps is the number of worksteps pending action by a single counterparty
+type CounterpartyPendingWorksteps struct {
+	Address         *string    `sql:"-" json:"address"`
+	Pending         uint64     `sql:"-" json:"pending"`
+	OldestPendingAt *time.Time `sql:"-" json:"oldest_pending_at,omitempty"`
+}
+
+// AverageCycleDuration returns the average cycle time as a duration
+func (a *WorkflowAnalytics) AverageCycleDuration() time.Duration {
+	return time.Duration(a.AverageCycleTime * float64(time.Second))
+}
+
+// PendingFor returns the number of worksteps pending action by the counterparty with the given address
+func (a *WorkflowAnalytics) PendingFor(address string) uint64 {
+	for _, pending := range a.PendingWorksteps {
+		if pending.Address != nil && *pending.Address == address {
+			return pending.Pending
+		}
+	}
+	return 0
+}
+
+// GetWorkflowAnalytics retrieves instance statistics for the given workflow
+func (s *Service) GetWorkflowAnalytics(workflowID string, params map[string]interface{}) (*WorkflowAnalytics, error) {
+	return s.getAnalytics(fmt.Sprintf("workflows/%s/analytics", workflowID), params)
+}
+
+// GetWorkflowAnalytics is the package-level variant of Service.GetWorkflowAnalytics, using the default service configuration
+func GetWorkflowAnalytics(token, workflowID string, params map[string]interface{}) (*WorkflowAnalytics, error) {
+	return InitBaselineService(token).GetWorkflowAnalytics(workflowID, params)
+}
+
+// GetWorkgroupAnalytics retrieves instance statistics for the given workgroup, aggregated across
+// its workflows; the analytics of each workflow are included in Workflows
+func (s *Service) GetWorkgroupAnalytics(workgroupID string, params map[string]interface{}) (*WorkflowAnalytics, error) {
+	return s.getAnalytics(fmt.Sprintf("workgroups/%s/analytics", workgroupID), params)
+}
+
+// GetWorkgroupAnalytics is the package-level variant of Service.GetWorkgroupAnalytics, using the default service configuration
+func GetWorkgroupAnalytics(token, workgroupID string, params map[string]interface{}) (*WorkflowAnalytics, error) {
+	return InitBaselineService(token).GetWorkgroupAnalytics(workgroupID, params)
+}
+
+func (s *Service) getAnalytics(uri string, params map[string]interface{}) (*WorkflowAnalytics, error) {
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow analytics; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, newError("failed to fetch workflow analytics", status, resp)
+	}
+
+	analytics := &WorkflowAnalytics{}
+	analyticsraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(analyticsraw, &analytics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow analytics; status: %v; %s", status, err.Error())
+	}
+
+	return analytics, nil
+}
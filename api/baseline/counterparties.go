@@ -0,0 +1,232 @@
+package baseline
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/provideplatform/provide-go/api"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+// orgRegistryABI is the subset of the baseline OrgRegistry contract used to resolve counterparties
+// see https://github.com/ethereum-oasis/baseline/blob/master/core/contracts/contracts/OrgRegistry.sol
+const orgRegistryABI = `[
+	{"type":"function","name":"getOrg","stateMutability":"view","inputs":[{"name":"_address","type":"address"}],"outputs":[{"name":"","type":"address"},{"name":"","type":"bytes32"},{"name":"","type":"bytes"},{"name":"","type":"bytes"},{"name":"","type":"bytes"},{"name":"","type":"bytes"}]},
+	{"type":"function","name":"getOrgs","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address[]"},{"name":"","type":"bytes32[]"},{"name":"","type":"bytes[]"},{"name":"","type":"bytes[]"},{"name":"","type":"bytes[]"},{"name":"","type":"bytes[]"}]}
+]`
+
+// Counterparty is an organization registered in the OrgRegistry contract of a workgroup
+type Counterparty struct {
+	Participant
+
+	Name         *string `sql:"-" json:"name,omitempty"`
+	WhisperKey   *string `sql:"-" json:"whisper_key,omitempty"`
+	ZKPPublicKey *string `sql:"-" json:"zkp_public_key,omitempty"`
+
+	// Verified is true when the counterparty has been verified against the registry contract
+	Verified bool `sql:"-" json:"-"`
+}
+
+// ListCounterparties retrieves the counterparties registered in the OrgRegistry contract of the
+// workgroup, as indexed by the local baseline stack
+func (s *Service) ListCounterparties(params map[string]interface{}) ([]*Counterparty, error) {
+	status, resp, err := s.Get("counterparties", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list counterparties; status: %v", status)
+	}
+
+	counterparties, err := api.DecodeList[Counterparty](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list counterparties; status: %v; %s", status, err.Error())
+	}
+
+	return counterparties, nil
+}
+
+// ListCounterparties is the package-level variant of Service.ListCounterparties, using the default service configuration
+func ListCounterparties(token string, params map[string]interface{}) ([]*Counterparty, error) {
+	return InitBaselineService(token).ListCounterparties(params)
+}
+
+// ResolveCounterparty retrieves the counterparty registered with the given address
+func (s *Service) ResolveCounterparty(address string) (*Counterparty, error) {
+	uri := fmt.Sprintf("counterparties/%s", address)
+	status, resp, err := s.Get(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve counterparty; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, newError("failed to resolve counterparty", status, resp)
+	}
+
+	counterparty := &Counterparty{}
+	counterpartyraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(counterpartyraw, &counterparty)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve counterparty; status: %v; %s", status, err.Error())
+	}
+
+	return counterparty, nil
+}
+
+// ResolveCounterparty is the package-level variant of Service.ResolveCounterparty, using the default service configuration
+func ResolveCounterparty(token, address string) (*Counterparty, error) {
+	return InitBaselineService(token).ResolveCounterparty(address)
+}
+
+// ListRegistryCounterparties reads the counterparties registered in the OrgRegistry contract at the
+// given address directly, using the given JSON-RPC endpoint
+func ListRegistryCounterparties(rpcClientKey, rpcURL, registry string) ([]*Counterparty, error) {
+	vals, err := callOrgRegistry(rpcClientKey, rpcURL, registry, "getOrgs")
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, _ := vals[0].([]common.Address)
+	names, _ := vals[1].([][32]byte)
+	endpoints, _ := vals[2].([][]byte)
+	whisperKeys, _ := vals[3].([][]byte)
+	zkpPublicKeys, _ := vals[4].([][]byte)
+	metadata, _ := vals[5].([][]byte)
+	if len(names) != len(addrs) || len(endpoints) != len(addrs) || len(whisperKeys) != len(addrs) || len(zkpPublicKeys) != len(addrs) || len(metadata) != len(addrs) {
+		return nil, errors.New("failed to read registry counterparties; malformed getOrgs result")
+	}
+
+	counterparties := make([]*Counterparty, 0)
+	for i := range addrs {
+		counterparties = append(counterparties, newRegistryCounterparty(addrs[i], names[i], endpoints[i], whisperKeys[i], zkpPublicKeys[i], metadata[i]))
+	}
+
+	return counterparties, nil
+}
+
+// ResolveRegistryCounterparty reads the counterparty registered with the given address in the
+// OrgRegistry contract at the given registry address, using the given JSON-RPC endpoint
+func ResolveRegistryCounterparty(rpcClientKey, rpcURL, registry, address string) (*Counterparty, error) {
+	vals, err := callOrgRegistry(rpcClientKey, rpcURL, registry, "getOrg", common.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+
+	addr, _ := vals[0].(common.Address)
+	if addr == (common.Address{}) {
+		return nil, fmt.Errorf("failed to resolve registry counterparty %s; %w", address, ErrNotFound)
+	}
+
+	name, _ := vals[1].([32]byte)
+	endpoint, _ := vals[2].([]byte)
+	whisperKey, _ := vals[3].([]byte)
+	zkpPublicKey, _ := vals[4].([]byte)
+	metadata, _ := vals[5].([]byte)
+	return newRegistryCounterparty(addr, name, endpoint, whisperKey, zkpPublicKey, metadata), nil
+}
+
+// VerifyCounterparty verifies the given counterparty against the OrgRegistry contract at the given
+// registry address; the counterparty is marked Verified when its name, messaging endpoint and keys
+// match those registered on-chain
+func VerifyCounterparty(rpcClientKey, rpcURL, registry string, counterparty *Counterparty) (bool, error) {
+	if counterparty == nil || counterparty.Address == nil {
+		return false, errors.New("failed to verify counterparty; no address provided")
+	}
+
+	registered, err := ResolveRegistryCounterparty(rpcClientKey, rpcURL, registry, *counterparty.Address)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	verified := strings.EqualFold(*counterparty.Address, *registered.Address) &&
+		optionalEqual(counterparty.Name, registered.Name) &&
+		optionalEqual(counterparty.MessagingEndpoint, registered.MessagingEndpoint) &&
+		optionalEqual(counterparty.WhisperKey, registered.WhisperKey) &&
+		optionalEqual(counterparty.ZKPPublicKey, registered.ZKPPublicKey)
+
+	counterparty.Verified = verified
+	return verified, nil
+}
+
+// callOrgRegistry invokes the given read-only OrgRegistry method and returns its unpacked outputs
+func callOrgRegistry(rpcClientKey, rpcURL, registry, method string, args ...interface{}) ([]interface{}, error) {
+	registryABI, err := ethabi.JSON(strings.NewReader(orgRegistryABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OrgRegistry abi; %s", err.Error())
+	}
+
+	data, err := registryABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OrgRegistry %s call; %s", method, err.Error())
+	}
+
+	resp, err := crypto.EVMEthCall(rpcClientKey, rpcURL, []interface{}{
+		map[string]interface{}{
+			"to":   registry,
+			"data": hexutil.Encode(data),
+		},
+		"latest",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke OrgRegistry %s; %s", method, err.Error())
+	}
+
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to invoke OrgRegistry %s; %v", method, resp.Error)
+	}
+
+	result, ok := resp.Result.(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to invoke OrgRegistry %s; unexpected result: %v", method, resp.Result)
+	}
+
+	raw, err := hexutil.Decode(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode OrgRegistry %s result; %s", method, err.Error())
+	}
+
+	vals, err := registryABI.Methods[method].Outputs.UnpackValues(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack OrgRegistry %s result; %s", method, err.Error())
+	}
+
+	return vals, nil
+}
+
+func newRegistryCounterparty(addr common.Address, name [32]byte, endpoint, whisperKey, zkpPublicKey, metadata []byte) *Counterparty {
+	counterparty := &Counterparty{
+		Participant: Participant{
+			Address:           prvdcommon.StringOrNil(addr.Hex()),
+			MessagingEndpoint: prvdcommon.StringOrNil(string(endpoint)),
+		},
+		Name:         prvdcommon.StringOrNil(string(bytes.TrimRight(name[:], "\x00"))),
+		WhisperKey:   prvdcommon.StringOrNil(string(whisperKey)),
+		ZKPPublicKey: prvdcommon.StringOrNil(string(zkpPublicKey)),
+		Verified:     true,
+	}
+
+	if len(metadata) > 0 {
+		json.Unmarshal(metadata, &counterparty.Metadata)
+	}
+
+	return counterparty
+}
+
+// optionalEqual returns true if the given value is unset or equal to the expected value
+func optionalEqual(val, expected *string) bool {
+	if val == nil || *val == "" {
+		return true
+	}
+	return expected != nil && *val == *expected
+}
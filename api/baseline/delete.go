@@ -0,0 +1,78 @@
+package baseline
+
+import (
+	"fmt"
+)
+
+// DeleteWorkgroup deletes the given workgroup from the local baseline stack
+func (s *Service) DeleteWorkgroup(workgroupID string) error {
+	uri := fmt.Sprintf("workgroups/%s", workgroupID)
+	return s.deleteResource(uri, "workgroup")
+}
+
+// DeleteWorkgroup is the package-level variant of Service.DeleteWorkgroup, using the default service configuration
+func DeleteWorkgroup(token, workgroupID string) error {
+	return InitBaselineService(token).DeleteWorkgroup(workgroupID)
+}
+
+// DeleteWorkflow deletes the given workflow from the local baseline stack; when cascade
+// is true, its worksteps are also deleted, otherwise a workflow having worksteps is not deleted
+func (s *Service) DeleteWorkflow(workflowID string, cascade bool) error {
+	uri := fmt.Sprintf("workflows/%s", workflowID)
+	if cascade {
+		uri = fmt.Sprintf("%s?cascade=true", uri)
+	}
+	return s.deleteResource(uri, "workflow")
+}
+
+// DeleteWorkflow is the package-level variant of Service.DeleteWorkflow, using the default service configuration
+func DeleteWorkflow(token, workflowID string, cascade bool) error {
+	return InitBaselineService(token).DeleteWorkflow(workflowID, cascade)
+}
+
+// DeleteWorkstep deletes the given workstep from the local baseline stack
+func (s *Service) DeleteWorkstep(workflowID, workstepID string) error {
+	uri := fmt.Sprintf("workflows/%s/worksteps/%s", workflowID, workstepID)
+	return s.deleteResource(uri, "workstep")
+}
+
+// DeleteWorkstep is the package-level variant of Service.DeleteWorkstep, using the default service configuration
+func DeleteWorkstep(token, workflowID, workstepID string) error {
+	return InitBaselineService(token).DeleteWorkstep(workflowID, workstepID)
+}
+
+// ArchiveWorkflow archives the given workflow; archived workflows are retained but may no longer be executed
+func (s *Service) ArchiveWorkflow(workflowID string) error {
+	uri := fmt.Sprintf("workflows/%s", workflowID)
+	status, resp, err := s.Put(uri, map[string]interface{}{
+		"status": WorkflowStatusArchived,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive workflow; status: %v; %s", status, err.Error())
+	}
+
+	return statusError("failed to archive workflow", status, resp)
+}
+
+// ArchiveWorkflow is the package-level variant of Service.ArchiveWorkflow, using the default service configuration
+func ArchiveWorkflow(token, workflowID string) error {
+	return InitBaselineService(token).ArchiveWorkflow(workflowID)
+}
+
+func (s *Service) deleteResource(uri, resource string) error {
+	status, resp, err := s.Delete(uri)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s; status: %v; %s", resource, status, err.Error())
+	}
+
+	return statusError(fmt.Sprintf("failed to delete %s", resource), status, resp)
+}
+
+// statusError returns nil if the given status indicates success; otherwise an *Error
+// which unwraps to ErrNotFound or ErrConflict, if applicable, is returned
+func statusError(msg string, status int, resp interface{}) error {
+	if status == 204 {
+		return nil
+	}
+	return newError(msg, status, resp)
+}
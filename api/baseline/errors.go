@@ -0,0 +1,83 @@
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+var (
+	// ErrNotFound is returned when the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when the resource cannot be modified in its current state
+	// (i.e., a deployed workflow which has been executed); such resources may be archived
+	ErrConflict = errors.New("conflict")
+
+	// ErrValidation is returned when the request or resource fails validation (i.e., a workflow
+	// which is not valid for deployment); the *Error describes each of the problems
+	ErrValidation = errors.New("validation failed")
+)
+
+// Error is returned when the local baseline stack rejects a request; the error payload
+// returned by the stack is decoded so callers can present why the request was rejected
+type Error struct {
+	Status  int               `json:"-"`
+	Message *string           `json:"message,omitempty"`
+	Errors  []*api.Error      `json:"errors,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"` // field errors, keyed on field name
+
+	msg string
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	details := make([]string, 0)
+	if e.Message != nil {
+		details = append(details, *e.Message)
+	}
+	for _, err := range e.Errors {
+		if err.Message != nil {
+			details = append(details, *err.Message)
+		}
+	}
+	for field, msg := range e.Fields {
+		details = append(details, fmt.Sprintf("%s: %s", field, msg))
+	}
+
+	if len(details) == 0 {
+		return fmt.Sprintf("%s; status: %v", e.msg, e.Status)
+	}
+	return fmt.Sprintf("%s; status: %v; %s", e.msg, e.Status, strings.Join(details, "; "))
+}
+
+// Unwrap returns ErrNotFound, ErrConflict or ErrValidation, when applicable, for use with errors.Is
+func (e *Error) Unwrap() error {
+	switch e.Status {
+	case 400, 422:
+		return ErrValidation
+	case 404:
+		return ErrNotFound
+	case 409:
+		return ErrConflict
+	}
+	return nil
+}
+
+// newError decodes the given error payload into an *Error
+func newError(msg string, status int, resp interface{}) *Error {
+	err := &Error{
+		Status: status,
+		msg:    msg,
+	}
+
+	if resp != nil {
+		raw, _ := json.Marshal(resp)
+		json.Unmarshal(raw, &err)
+	}
+
+	return err
+}
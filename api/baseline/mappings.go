@@ -0,0 +1,141 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+// ListMappings retrieves a paginated list of mappings; mappings may be filtered by workgroup_id
+func (s *Service) ListMappings(params map[string]interface{}) ([]*Mapping, error) {
+	status, resp, err := s.Get("mappings", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list mappings; status: %v", status)
+	}
+
+	mappings, err := api.DecodeList[Mapping](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mappings; status: %v; %s", status, err.Error())
+	}
+
+	return mappings, nil
+}
+
+// ListMappings is the package-level variant of Service.ListMappings, using the default service configuration
+func ListMappings(token string, params map[string]interface{}) ([]*Mapping, error) {
+	return InitBaselineService(token).ListMappings(params)
+}
+
+// CreateMapping creates a new mapping and its models
+func (s *Service) CreateMapping(params map[string]interface{}) (*Mapping, error) {
+	status, resp, err := s.Post("mappings", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mapping; status: %v; %s", status, err.Error())
+	}
+
+	if status != 201 {
+		return nil, newError("failed to create mapping", status, resp)
+	}
+
+	mapping := &Mapping{}
+	mappingraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(mappingraw, &mapping)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mapping; status: %v; %s", status, err.Error())
+	}
+
+	return mapping, nil
+}
+
+// CreateMapping is the package-level variant of Service.CreateMapping, using the default service configuration
+func CreateMapping(token string, params map[string]interface{}) (*Mapping, error) {
+	return InitBaselineService(token).CreateMapping(params)
+}
+
+// UpdateMapping updates the given mapping and its models
+func (s *Service) UpdateMapping(mappingID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("mappings/%s", mappingID)
+	status, resp, err := s.Put(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to update mapping; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to update mapping", status, resp)
+	}
+
+	return nil
+}
+
+// UpdateMapping is the package-level variant of Service.UpdateMapping, using the default service configuration
+func UpdateMapping(token, mappingID string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateMapping(mappingID, params)
+}
+
+// DeleteMapping deletes the given mapping
+func (s *Service) DeleteMapping(mappingID string) error {
+	uri := fmt.Sprintf("mappings/%s", mappingID)
+	return s.deleteResource(uri, "mapping")
+}
+
+// DeleteMapping is the package-level variant of Service.DeleteMapping, using the default service configuration
+func DeleteMapping(token, mappingID string) error {
+	return InitBaselineService(token).DeleteMapping(mappingID)
+}
+
+// ListSchemas retrieves the business object schemas available for mapping within the given workgroup
+func (s *Service) ListSchemas(workgroupID string, params map[string]interface{}) ([]*MappingModel, error) {
+	uri := fmt.Sprintf("workgroups/%s/schemas", workgroupID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list schemas; status: %v", status)
+	}
+
+	schemas, err := api.DecodeList[MappingModel](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas; status: %v; %s", status, err.Error())
+	}
+
+	return schemas, nil
+}
+
+// ListSchemas is the package-level variant of Service.ListSchemas, using the default service configuration
+func ListSchemas(token, workgroupID string, params map[string]interface{}) ([]*MappingModel, error) {
+	return InitBaselineService(token).ListSchemas(workgroupID, params)
+}
+
+// GetSchemaDetails retrieves the fields of the given business object schema within the given workgroup
+func (s *Service) GetSchemaDetails(workgroupID, schemaType string, params map[string]interface{}) (*MappingModel, error) {
+	uri := fmt.Sprintf("workgroups/%s/schemas/%s", workgroupID, schemaType)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema details; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch schema details; status: %v", status)
+	}
+
+	schema := &MappingModel{}
+	schemaraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(schemaraw, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema details; status: %v; %s", status, err.Error())
+	}
+
+	return schema, nil
+}
+
+// GetSchemaDetails is the package-level variant of Service.GetSchemaDetails, using the default service configuration
+func GetSchemaDetails(token, workgroupID, schemaType string, params map[string]interface{}) (*MappingModel, error) {
+	return InitBaselineService(token).GetSchemaDetails(workgroupID, schemaType, params)
+}
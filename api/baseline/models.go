@@ -1,31 +1,68 @@
 package baseline
 
 import (
-	uuid "github.com/kthomas/go.uuid"
+	"time"
+
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/api/privacy"
+	"github.com/provideplatform/provide-go/common"
 )
 
 const ProtocolMessageOpcodeBaseline = "BLINE"
 const ProtocolMessageOpcodeJoin = "JOIN"
 const ProtocolMessageOpcodeSync = "SYNC"
 
+// SystemTypeDynamics365 is the Microsoft Dynamics 365 system of record type
+const SystemTypeDynamics365 = "dynamics365"
+
+// SystemTypeSAP is the SAP system of record type
+const SystemTypeSAP = "sap"
+
+// SystemTypeServiceNow is the ServiceNow system of record type
+const SystemTypeServiceNow = "servicenow"
+
+// WorkflowStatusDraft is the status of a workflow which has not been deployed
+const WorkflowStatusDraft = "draft"
+
+// WorkflowStatusDeployed is the status of a workflow which has been deployed and may be executed
+const WorkflowStatusDeployed = "deployed"
+
+// WorkflowStatusDeprecated is the status of a workflow which has been superseded by a newer version
+const WorkflowStatusDeprecated = "deprecated"
+
+// WorkflowStatusArchived is the status of a workflow which has been archived and may no longer be executed
+const WorkflowStatusArchived = "archived"
+
+// BPIAccount is a BPI subject account, representing a subject of the BPI (i.e., an
+// organization) and the policies governing recovery and verification of its state
+type BPIAccount struct {
+	ID                  *common.ID             `sql:"-" json:"id,omitempty"`
+	CreatedAt           *time.Time             `sql:"-" json:"created_at,omitempty"`
+	Errors              []*api.Error           `sql:"-" json:"errors,omitempty"`
+	Metadata            map[string]interface{} `sql:"-" json:"metadata,omitempty"`
+	Nonce               *uint64                `sql:"-" json:"nonce,omitempty"`
+	Owners              []*string              `sql:"-" json:"owners,omitempty"` // subject identifiers (i.e., DIDs) of the account owners
+	RecoveryPolicy      map[string]interface{} `sql:"-" json:"recovery_policy,omitempty"`
+	SecurityPolicies    map[string]interface{} `sql:"-" json:"security_policies,omitempty"`
+	VerificationMethods []interface{}          `sql:"-" json:"verification_methods,omitempty"`
+}
+
 // BaselineContext represents a collection of BaselineRecord instances in the context of a workflow
 type BaselineContext struct {
-	ID         *uuid.UUID        `sql:"-" json:"id,omitempty"`
-	BaselineID *uuid.UUID        `sql:"-" json:"baseline_id,omitempty"`
+	ID         *common.ID        `sql:"-" json:"id,omitempty"`
+	BaselineID *common.ID        `sql:"-" json:"baseline_id,omitempty"`
 	Records    []*BaselineRecord `sql:"-" json:"records,omitempty"`
 	Workflow   *Workflow         `sql:"-" json:"-"`
-	WorkflowID *uuid.UUID        `sql:"-" json:"workflow_id"`
+	WorkflowID *common.ID        `sql:"-" json:"workflow_id"`
 }
 
 // BaselineRecord represents a link between an object in the internal system of record
 // and the external BaselineContext
 type BaselineRecord struct {
 	ID         *string          `sql:"-" json:"id,omitempty"`
-	BaselineID *uuid.UUID       `sql:"-" json:"baseline_id,omitempty"`
+	BaselineID *common.ID       `sql:"-" json:"baseline_id,omitempty"`
 	Context    *BaselineContext `sql:"-" json:"-"`
-	ContextID  *uuid.UUID       `sql:"-" json:"context_id"`
+	ContextID  *common.ID       `sql:"-" json:"context_id"`
 	Type       *string          `sql:"-" json:"type"`
 }
 
@@ -34,9 +71,9 @@ type Config struct {
 	Counterparties           []*Participant    `sql:"-" json:"counterparties,omitempty"`
 	Env                      map[string]string `sql:"-" json:"env,omitempty"`
 	Errors                   []*api.Error      `sql:"-" json:"errors,omitempty"`
-	NetworkID                *uuid.UUID        `sql:"-" json:"network_id,omitempty"`
+	NetworkID                *common.ID        `sql:"-" json:"network_id,omitempty"`
 	OrganizationAddress      *string           `sql:"-" json:"organization_address,omitempty"`
-	OrganizationID           *uuid.UUID        `sql:"-" json:"organization_id,omitempty"`
+	OrganizationID           *common.ID        `sql:"-" json:"organization_id,omitempty"`
 	OrganizationRefreshToken *string           `sql:"-" json:"organization_refresh_token,omitempty"`
 	RegistryContractAddress  *string           `sql:"-" json:"registry_contract_address,omitempty"`
 }
@@ -44,7 +81,7 @@ type Config struct {
 // IssueVerifiableCredentialRequest represents a request to issue a verifiable credential
 type IssueVerifiableCredentialRequest struct {
 	Address        *string    `json:"address,omitempty"`
-	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	OrganizationID *common.ID `json:"organization_id,omitempty"`
 	PublicKey      *string    `json:"public_key,omitempty"`
 	Signature      *string    `json:"signature"`
 }
@@ -54,10 +91,43 @@ type IssueVerifiableCredentialResponse struct {
 	VC *string `json:"credential"`
 }
 
+// Mapping maps business object models between the systems of record of workgroup participants
+type Mapping struct {
+	ID          *common.ID      `sql:"-" json:"id,omitempty"`
+	Description *string         `sql:"-" json:"description,omitempty"`
+	Errors      []*api.Error    `sql:"-" json:"errors,omitempty"`
+	Models      []*MappingModel `sql:"-" json:"models"`
+	Name        *string         `sql:"-" json:"name"`
+	Type        *string         `sql:"-" json:"type,omitempty"`
+	WorkgroupID *common.ID      `sql:"-" json:"workgroup_id,omitempty"`
+}
+
+// MappingModel is a business object model within a mapping
+type MappingModel struct {
+	ID          *common.ID      `sql:"-" json:"id,omitempty"`
+	Description *string         `sql:"-" json:"description,omitempty"`
+	Fields      []*MappingField `sql:"-" json:"fields"`
+	MappingID   *common.ID      `sql:"-" json:"mapping_id,omitempty"`
+	PrimaryKey  *string         `sql:"-" json:"primary_key,omitempty"`
+	Standard    *string         `sql:"-" json:"standard,omitempty"` // the standard to which the model conforms, if any
+	Type        *string         `sql:"-" json:"type"`
+}
+
+// MappingField is a single field of a mapping model
+type MappingField struct {
+	ID             *common.ID  `sql:"-" json:"id,omitempty"`
+	DefaultValue   interface{} `sql:"-" json:"default_value,omitempty"`
+	Description    *string     `sql:"-" json:"description,omitempty"`
+	IsPrimaryKey   bool        `sql:"-" json:"is_primary_key"`
+	MappingModelID *common.ID  `sql:"-" json:"mapping_model_id,omitempty"`
+	Name           *string     `sql:"-" json:"name"`
+	Type           *string     `sql:"-" json:"type"`
+}
+
 // Message is a proxy-internal wrapper for protocol message handling
 type Message struct {
 	ID              *string          `sql:"-" json:"id,omitempty"`
-	BaselineID      *uuid.UUID       `sql:"-" json:"baseline_id,omitempty"` // optional; when included, can be used to map outbound message just-in-time
+	BaselineID      *common.ID       `sql:"-" json:"baseline_id,omitempty"` // optional; when included, can be used to map outbound message just-in-time
 	Errors          []*api.Error     `sql:"-" json:"errors,omitempty"`
 	MessageID       *string          `sql:"-" json:"message_id,omitempty"`
 	Payload         interface{}      `sql:"-" json:"payload,omitempty"`
@@ -67,23 +137,55 @@ type Message struct {
 	Type            *string          `sql:"-" json:"type,omitempty"`
 }
 
+// ObjectProof is the zero-knowledge proof and associated state commitment for a baselined object
+type ObjectProof struct {
+	BaselineID *common.ID   `sql:"-" json:"baseline_id,omitempty"`
+	CircuitID  *common.ID   `sql:"-" json:"circuit_id,omitempty"`
+	Commitment *string      `sql:"-" json:"commitment,omitempty"` // the state commitment (i.e., note hash) inserted into the shield tree
+	Errors     []*api.Error `sql:"-" json:"errors,omitempty"`
+	Proof      *string      `sql:"-" json:"proof"`
+	Root       *string      `sql:"-" json:"root,omitempty"`   // the shield tree root after the commitment was inserted
+	Shield     *string      `sql:"-" json:"shield,omitempty"` // the address of the shield contract
+	Witness    interface{}  `sql:"-" json:"witness,omitempty"`
+}
+
+// ObjectState is a single baselined state of an object; the state commitment and proof are those
+// produced when the state was baselined, attested to by each of the counterparties
+type ObjectState struct {
+	ObjectProof
+
+	Sequence     uint64         `sql:"-" json:"sequence"` // the position of the state in the history of the object
+	WorkstepID   *common.ID     `sql:"-" json:"workstep_id,omitempty"`
+	CreatedAt    *time.Time     `sql:"-" json:"created_at,omitempty"`
+	Attestations []*Attestation `sql:"-" json:"attestations,omitempty"`
+}
+
+// Attestation is a counterparty signature over a baselined state commitment
+type Attestation struct {
+	Address   *string    `sql:"-" json:"address"`
+	PublicKey *string    `sql:"-" json:"public_key,omitempty"`
+	Signature *string    `sql:"-" json:"signature"`
+	SignedAt  *time.Time `sql:"-" json:"signed_at,omitempty"`
+}
+
 // Participant is a party to a baseline workgroup or workflow context
 type Participant struct {
 	Address           *string                `sql:"-" json:"address"`
 	Metadata          map[string]interface{} `sql:"-" json:"metadata,omitempty"`
 	APIEndpoint       *string                `sql:"-" json:"api_endpoint,omitempty"`
 	MessagingEndpoint *string                `sql:"-" json:"messaging_endpoint,omitempty"`
+	PublicKey         *string                `sql:"-" json:"public_key,omitempty"` // the key used to verify signed messages from the participant
 }
 
 // ProtocolMessage is a baseline protocol message
 // see https://github.com/ethereum-oasis/baseline/blob/master/core/types/src/protocol.ts
 type ProtocolMessage struct {
-	BaselineID *uuid.UUID              `sql:"-" json:"baseline_id,omitempty"`
+	BaselineID *common.ID              `sql:"-" json:"baseline_id,omitempty"`
 	Opcode     *string                 `sql:"-" json:"opcode,omitempty"`
 	Sender     *string                 `sql:"-" json:"sender,omitempty"`
 	Recipient  *string                 `sql:"-" json:"recipient,omitempty"`
 	Shield     *string                 `sql:"-" json:"shield,omitempty"`
-	Identifier *uuid.UUID              `sql:"-" json:"identifier,omitempty"`
+	Identifier *common.ID              `sql:"-" json:"identifier,omitempty"`
 	Signature  *string                 `sql:"-" json:"signature,omitempty"`
 	Type       *string                 `sql:"-" json:"type,omitempty"`
 	Payload    *ProtocolMessagePayload `sql:"-" json:"payload,omitempty"`
@@ -97,9 +199,73 @@ type ProtocolMessagePayload struct {
 	Witness interface{}            `sql:"-" json:"witness,omitempty"`
 }
 
+// WorkstepExecution is the result of executing a workstep; when the workstep requires
+// finality, the execution remains pending until approved by the required participants
+type WorkstepExecution struct {
+	Errors  []*api.Error `sql:"-" json:"errors,omitempty"`
+	Proof   *string      `sql:"-" json:"proof,omitempty"`
+	Status  *string      `sql:"-" json:"status,omitempty"`
+	Witness interface{}  `sql:"-" json:"witness,omitempty"`
+}
+
+// StackStatus is the health of the local baseline stack and its subsystems
+type StackStatus struct {
+	Errors   []*api.Error     `sql:"-" json:"errors,omitempty"`
+	NATS     *SubsystemStatus `sql:"-" json:"nats,omitempty"`
+	Privacy  *SubsystemStatus `sql:"-" json:"privacy,omitempty"`
+	Registry *SubsystemStatus `sql:"-" json:"registry,omitempty"` // connectivity to the registry contract
+	Vault    *SubsystemStatus `sql:"-" json:"vault,omitempty"`
+}
+
+// Healthy returns true if each reported subsystem is healthy
+func (s *StackStatus) Healthy() bool {
+	for _, subsystem := range []*SubsystemStatus{s.NATS, s.Privacy, s.Registry, s.Vault} {
+		if subsystem != nil && !subsystem.Healthy {
+			return false
+		}
+	}
+	return len(s.Errors) == 0
+}
+
+// SubsystemStatus is the health of a single subsystem of the local baseline stack
+type SubsystemStatus struct {
+	Healthy bool    `sql:"-" json:"healthy"`
+	Message *string `sql:"-" json:"message,omitempty"`
+}
+
+// System is a system of record (i.e., SAP, Dynamics, ServiceNow) connected to a workgroup
+type System struct {
+	ID          *common.ID             `sql:"-" json:"id,omitempty"`
+	Auth        map[string]interface{} `sql:"-" json:"auth,omitempty"`
+	Description *string                `sql:"-" json:"description,omitempty"`
+	Endpoint    *string                `sql:"-" json:"endpoint_url,omitempty"`
+	Errors      []*api.Error           `sql:"-" json:"errors,omitempty"`
+	Middleware  map[string]interface{} `sql:"-" json:"middleware,omitempty"`
+	Name        *string                `sql:"-" json:"name,omitempty"`
+	Type        *string                `sql:"-" json:"type,omitempty"`
+	WorkgroupID *common.ID             `sql:"-" json:"workgroup_id,omitempty"`
+}
+
+// SystemSchema describes the fields of a business object type exposed by a system of record
+type SystemSchema struct {
+	Description *string                `sql:"-" json:"description,omitempty"`
+	Fields      []*SystemSchemaField   `sql:"-" json:"fields,omitempty"`
+	Metadata    map[string]interface{} `sql:"-" json:"metadata,omitempty"`
+	Name        *string                `sql:"-" json:"name,omitempty"`
+	Type        *string                `sql:"-" json:"type"`
+}
+
+// SystemSchemaField is a single field of a SystemSchema
+type SystemSchemaField struct {
+	Description *string `sql:"-" json:"description,omitempty"`
+	Name        *string `sql:"-" json:"name"`
+	Required    bool    `sql:"-" json:"required"`
+	Type        *string `sql:"-" json:"type,omitempty"`
+}
+
 // Workgroup is a baseline workgroup context
 type Workgroup struct {
-	ID           *uuid.UUID     `sql:"-" json:"id,omitempty"`
+	ID           *common.ID     `sql:"-" json:"id,omitempty"`
 	Errors       []*api.Error   `sql:"-" json:"errors,omitempty"`
 	Participants []*Participant `sql:"-" json:"participants"`
 	Workflows    []*Workflow    `json:"workflows,omitempty"`
@@ -111,20 +277,29 @@ type Workgroup struct {
 
 // Workflow is a baseline workflow context
 type Workflow struct {
-	ID           *uuid.UUID     `sql:"-" json:"id,omitempty"`
+	ID           *common.ID     `sql:"-" json:"id,omitempty"`
+	Name         *string        `sql:"-" json:"name,omitempty"`
+	Description  *string        `sql:"-" json:"description,omitempty"`
+	DeployedAt   *time.Time     `sql:"-" json:"deployed_at,omitempty"`
 	Errors       []*api.Error   `sql:"-" json:"errors,omitempty"`
 	Participants []*Participant `sql:"-" json:"participants"`
 	Shield       *string        `sql:"-" json:"shield,omitempty"`
+	Status       *string        `sql:"-" json:"status,omitempty"`
+	Version      *string        `sql:"-" json:"version,omitempty"`
+	WorkflowID   *common.ID     `sql:"-" json:"workflow_id,omitempty"` // the prototype workflow from which this version was created, if any
+	WorkgroupID  *common.ID     `sql:"-" json:"workgroup_id,omitempty"`
 	Worksteps    []*Workstep    `sql:"-" json:"worksteps,omitempty"`
 }
 
 // Workstep is a baseline workflow context
 type Workstep struct {
-	ID              *uuid.UUID       `sql:"-" json:"id,omitempty"`
+	ID              *common.ID       `sql:"-" json:"id,omitempty"`
 	Circuit         *privacy.Circuit `sql:"-" json:"circuit,omitempty"`
-	CircuitID       *uuid.UUID       `sql:"-" json:"circuit_id"`
+	CircuitID       *common.ID       `sql:"-" json:"circuit_id"`
 	Errors          []*api.Error     `sql:"-" json:"errors,omitempty"`
 	Participants    []*Participant   `sql:"-" json:"participants"`
 	RequireFinality bool             `sql:"-" json:"require_finality"`
-	WorkflowID      *uuid.UUID       `sql:"-" json:"workflow_id,omitempty"`
+	WorkflowID      *common.ID       `sql:"-" json:"workflow_id,omitempty"`
+
+	Constraints *WorkstepConstraints `sql:"-" json:"constraints,omitempty"`
 }
@@ -0,0 +1,160 @@
+package baseline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// CreateObjectsBatchSize is the maximum number of objects sent to the local baseline stack in a
+// single request by CreateObjects; larger loads are sent in consecutive batches
+var CreateObjectsBatchSize = 100
+
+// ObjectResult is the outcome of baselining a single object using CreateObjects
+type ObjectResult struct {
+	Index      int          `sql:"-" json:"index"` // the index of the object in the objects given to CreateObjects
+	ID         *string      `sql:"-" json:"id,omitempty"`
+	BaselineID *common.ID   `sql:"-" json:"baseline_id,omitempty"`
+	Status     *string      `sql:"-" json:"status,omitempty"`
+	Errors     []*api.Error `sql:"-" json:"errors,omitempty"`
+
+	// Err is set when the object was rejected, either locally upon validation or by the stack
+	Err error `sql:"-" json:"-"`
+}
+
+// CreateObjects baselines many business objects, i.e., for an initial load from a system of record;
+// each object is validated and the valid objects are sent in batches of CreateObjectsBatchSize. A
+// result is returned for every object, in the order given; an error is returned only when a batch
+// request fails outright, in which case the error is also set on the results of the unsent objects
+func (s *Service) CreateObjects(objects []*ObjectParams) ([]*ObjectResult, error) {
+	results := make([]*ObjectResult, len(objects))
+	pending := make([]int, 0, len(objects))
+
+	for i, object := range objects {
+		results[i] = &ObjectResult{Index: i}
+		if object == nil {
+			results[i].Err = fmt.Errorf("failed to create object; nil object params at index %d", i)
+			continue
+		}
+		results[i].ID = object.ID
+
+		err := object.Validate()
+		if err != nil {
+			results[i].Err = fmt.Errorf("failed to create object; %s", err.Error())
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	batchSize := CreateObjectsBatchSize
+	if batchSize <= 0 {
+		batchSize = len(pending)
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		end := start + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		err := s.createObjectsBatch(objects, pending[start:end], results)
+		if err != nil {
+			for _, i := range pending[start:] {
+				results[i].Err = err
+			}
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// CreateObjects is the package-level variant of Service.CreateObjects, using the default service configuration
+func CreateObjects(token string, objects []*ObjectParams) ([]*ObjectResult, error) {
+	return InitBaselineService(token).CreateObjects(objects)
+}
+
+// createObjectsBatch sends the objects at the given indices and applies the per-item results
+func (s *Service) createObjectsBatch(objects []*ObjectParams, indices []int, results []*ObjectResult) error {
+	batch := make([]map[string]interface{}, 0, len(indices))
+	for _, i := range indices {
+		batch = append(batch, objects[i].Params())
+	}
+
+	status, resp, err := s.Post("objects/bulk", map[string]interface{}{
+		"objects": batch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create baseline objects; status: %v; %s", status, err.Error())
+	}
+
+	if status != 202 && status != 207 {
+		return newError("failed to create baseline objects", status, resp)
+	}
+
+	items, ok := resp.([]interface{})
+	if !ok {
+		return fmt.Errorf("failed to create baseline objects; unexpected response; status: %v", status)
+	}
+
+	received := map[int]bool{}
+	for _, item := range items {
+		result := &ObjectResult{}
+		resultraw, _ := json.Marshal(item)
+		err := json.Unmarshal(resultraw, &result)
+		if err != nil || result.Index < 0 || result.Index >= len(indices) {
+			continue
+		}
+
+		i := indices[result.Index]
+		result.Index = i
+		if result.ID == nil {
+			result.ID = results[i].ID
+		}
+		if len(result.Errors) > 0 {
+			result.Err = newError("failed to create baseline object", status, map[string]interface{}{
+				"errors": result.Errors,
+			})
+		}
+		results[i] = result
+		received[i] = true
+	}
+
+	for _, i := range indices {
+		if !received[i] {
+			results[i].Err = fmt.Errorf("failed to create baseline object; no result returned for object at index %d", i)
+		}
+	}
+
+	return nil
+}
+
+// CreateObjectOperation baselines the given object and returns an Operation which completes
+// once the proof and state commitment of the object are available; see api.Operation. Network
+// errors, rate limiting and server errors are retried, while any other error fails the operation
+func (s *Service) CreateObjectOperation(params map[string]interface{}) (*api.Operation[ObjectProof], error) {
+	resp, err := s.CreateObject(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewOperation(resp, func(ctx context.Context, id string) (*ObjectProof, bool, error) {
+		status, proof, err := s.getObjectProof(id)
+		if err != nil {
+			if !api.TransientStatus(status) {
+				return nil, false, err
+			}
+			common.Log.Debugf("failed to resolve proof for baselined object %s; %s", id, err.Error())
+			return nil, false, nil
+		}
+		return proof, proof.Proof != nil, nil
+	})
+}
+
+// CreateObjectOperation is the package-level variant of Service.CreateObjectOperation, using the default service configuration
+func CreateObjectOperation(token string, params map[string]interface{}) (*api.Operation[ObjectProof], error) {
+	return InitBaselineService(token).CreateObjectOperation(params)
+}
@@ -0,0 +1,60 @@
+package baseline
+
+import (
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+var (
+	defaultOptions      []Option
+	defaultOptionsMutex = &sync.RWMutex{}
+)
+
+// Option configures a baseline Service
+type Option func(*Service)
+
+// Configure sets the default options applied to each Service initialized by the package-level
+// helpers; operators of multiple stacks may instead initialize a differently-configured Service
+// per stack using InitBaselineService
+func Configure(opts ...Option) {
+	defaultOptionsMutex.Lock()
+	defer defaultOptionsMutex.Unlock()
+	defaultOptions = opts
+}
+
+// WithHost sets the host of the baseline stack API
+func WithHost(host string) Option {
+	return func(s *Service) {
+		s.Host = host
+	}
+}
+
+// WithPath sets the base path of the baseline stack API
+func WithPath(path string) Option {
+	return func(s *Service) {
+		s.Path = path
+	}
+}
+
+// WithScheme sets the scheme of the baseline stack API
+func WithScheme(scheme string) Option {
+	return func(s *Service) {
+		s.Scheme = scheme
+	}
+}
+
+// WithUserAgent sets the User-Agent prepended to the default provide-go User-Agent of each request
+func WithUserAgent(userAgent string) Option {
+	return func(s *Service) {
+		s.UserAgent = common.StringOrNil(userAgent)
+	}
+}
+
+// WithTimeout sets the timeout of each request
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		s.Timeout = timeout
+	}
+}
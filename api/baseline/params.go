@@ -0,0 +1,242 @@
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// WorkgroupParams are the typed parameters used to create or update a workgroup
+type WorkgroupParams struct {
+	Name         *string        `json:"name,omitempty"`
+	Description  *string        `json:"description,omitempty"`
+	NetworkID    *common.ID     `json:"network_id,omitempty"`
+	Participants []*Participant `json:"participants,omitempty"`
+
+	// Token is the signed invitation used to join a previously-initialized workgroup
+	Token *string `json:"token,omitempty"`
+
+	PrivacyPolicy      interface{} `json:"privacy_policy,omitempty"`
+	SecurityPolicy     interface{} `json:"security_policy,omitempty"`
+	TokenizationPolicy interface{} `json:"tokenization_policy,omitempty"`
+
+	// Raw parameters are merged into the request, overriding typed parameters of the same name
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Validate the workgroup params
+func (p *WorkgroupParams) Validate() error {
+	if p == nil {
+		return errors.New("workgroup params required")
+	}
+	if p.Name == nil && p.Token == nil {
+		return errors.New("workgroup name or invitation token required")
+	}
+	return nil
+}
+
+// ValidateUpdate validates the workgroup params for a partial update; only the given params
+// are validated
+func (p *WorkgroupParams) ValidateUpdate() error {
+	if p == nil {
+		return errors.New("workgroup params required")
+	}
+	if p.Name != nil && *p.Name == "" {
+		return errors.New("workgroup name must not be empty")
+	}
+	return nil
+}
+
+// Params returns the workgroup params as a raw params map
+func (p *WorkgroupParams) Params() map[string]interface{} {
+	return toParams(p, p.Raw)
+}
+
+// WorkflowParams are the typed parameters used to create or update a workflow
+type WorkflowParams struct {
+	Name         *string        `json:"name,omitempty"`
+	Description  *string        `json:"description,omitempty"`
+	WorkgroupID  *common.ID     `json:"workgroup_id,omitempty"`
+	WorkflowID   *common.ID     `json:"workflow_id,omitempty"` // the prototype workflow, if any
+	Participants []*Participant `json:"participants,omitempty"`
+	Shield       *string        `json:"shield,omitempty"`
+	Version      *string        `json:"version,omitempty"`
+
+	// Raw parameters are merged into the request, overriding typed parameters of the same name
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Validate the workflow params
+func (p *WorkflowParams) Validate() error {
+	if p == nil {
+		return errors.New("workflow params required")
+	}
+	if p.Name == nil {
+		return errors.New("workflow name required")
+	}
+	if p.WorkgroupID == nil {
+		return errors.New("workflow workgroup_id required")
+	}
+	return nil
+}
+
+// ValidateUpdate validates the workflow params for a partial update; only the given params
+// are validated
+func (p *WorkflowParams) ValidateUpdate() error {
+	if p == nil {
+		return errors.New("workflow params required")
+	}
+	if p.Name != nil && *p.Name == "" {
+		return errors.New("workflow name must not be empty")
+	}
+	if p.WorkgroupID != nil && p.WorkgroupID.IsZero() {
+		return errors.New("workflow workgroup_id must not be empty")
+	}
+	return nil
+}
+
+// Params returns the workflow params as a raw params map
+func (p *WorkflowParams) Params() map[string]interface{} {
+	return toParams(p, p.Raw)
+}
+
+// WorkstepParams are the typed parameters used to create or update a workstep
+type WorkstepParams struct {
+	Name            *string        `json:"name,omitempty"`
+	Description     *string        `json:"description,omitempty"`
+	WorkflowID      *common.ID     `json:"workflow_id,omitempty"`
+	CircuitID       *common.ID     `json:"circuit_id,omitempty"`
+	Cardinality     *int           `json:"cardinality,omitempty"`
+	Participants    []*Participant `json:"participants,omitempty"`
+	RequireFinality *bool          `json:"require_finality,omitempty"`
+
+	// Constraints govern approval and finality of the workstep and selection of its circuit
+	Constraints *WorkstepConstraints `json:"constraints,omitempty"`
+
+	// Raw parameters are merged into the request, overriding typed parameters of the same name
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Validate the workstep params
+func (p *WorkstepParams) Validate() error {
+	if p == nil {
+		return errors.New("workstep params required")
+	}
+	if p.Name == nil {
+		return errors.New("workstep name required")
+	}
+	if p.WorkflowID == nil {
+		return errors.New("workstep workflow_id required")
+	}
+	return p.ValidateUpdate()
+}
+
+// ValidateUpdate validates the workstep params for a partial update; only the given params
+// are validated, so the name and workflow_id required upon creation may be omitted
+func (p *WorkstepParams) ValidateUpdate() error {
+	if p == nil {
+		return errors.New("workstep params required")
+	}
+	if p.Name != nil && *p.Name == "" {
+		return errors.New("workstep name must not be empty")
+	}
+	if p.WorkflowID != nil && p.WorkflowID.IsZero() {
+		return errors.New("workstep workflow_id must not be empty")
+	}
+	if p.Cardinality != nil && *p.Cardinality < 0 {
+		return errors.New("workstep cardinality must not be negative")
+	}
+	if p.Constraints != nil {
+		if p.CircuitID != nil && p.Constraints.Circuit != nil {
+			return errors.New("workstep circuit_id and circuit constraint are mutually exclusive")
+		}
+		return p.Constraints.Validate()
+	}
+	return nil
+}
+
+// Params returns the workstep params as a raw params map
+func (p *WorkstepParams) Params() map[string]interface{} {
+	return toParams(p, p.Raw)
+}
+
+// WorkstepConstraints govern who must approve a workstep execution before it is final and
+// how the circuit used to prove the workstep is selected
+type WorkstepConstraints struct {
+	// RequiredApprovers are the addresses of the participants which must approve each execution
+	RequiredApprovers []string `json:"required_approvers,omitempty"`
+
+	// FinalityThreshold is the number of approvals after which an execution is final; when unset,
+	// approval by all of the required approvers is required
+	FinalityThreshold *int `json:"finality_threshold,omitempty"`
+
+	// Circuit selects the circuit for the workstep when no circuit id is given
+	Circuit *CircuitSelection `json:"circuit,omitempty"`
+}
+
+// CircuitSelection describes the circuit to be selected or provisioned for a workstep
+type CircuitSelection struct {
+	Identifier    *string `json:"identifier,omitempty"` // i.e., purchase_order
+	Provider      *string `json:"provider,omitempty"`   // i.e., gnark
+	ProvingScheme *string `json:"proving_scheme,omitempty"`
+	Curve         *string `json:"curve,omitempty"`
+}
+
+// Validate the workstep constraints
+func (c *WorkstepConstraints) Validate() error {
+	if c.FinalityThreshold != nil {
+		if *c.FinalityThreshold < 1 {
+			return errors.New("workstep finality threshold must be positive")
+		}
+		if len(c.RequiredApprovers) > 0 && *c.FinalityThreshold > len(c.RequiredApprovers) {
+			return errors.New("workstep finality threshold exceeds the number of required approvers")
+		}
+	}
+	if c.Circuit != nil && c.Circuit.Identifier == nil {
+		return errors.New("workstep circuit constraint identifier required")
+	}
+	return nil
+}
+
+// ObjectParams are the typed parameters used to create or update a baselined object
+type ObjectParams struct {
+	ID         *string                `json:"id,omitempty"` // the id of the object in the internal system of record
+	BaselineID *common.ID             `json:"baseline_id,omitempty"`
+	Type       *string                `json:"type,omitempty"`
+	Payload    map[string]interface{} `json:"payload,omitempty"`
+
+	// Raw parameters are merged into the request, overriding typed parameters of the same name
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Validate the object params
+func (p *ObjectParams) Validate() error {
+	if p == nil {
+		return errors.New("object params required")
+	}
+	if p.Type == nil {
+		return errors.New("object type required")
+	}
+	if p.Payload == nil {
+		return errors.New("object payload required")
+	}
+	return nil
+}
+
+// Params returns the object params as a raw params map
+func (p *ObjectParams) Params() map[string]interface{} {
+	return toParams(p, p.Raw)
+}
+
+func toParams(typed interface{}, raw map[string]interface{}) map[string]interface{} {
+	params := map[string]interface{}{}
+	paramsraw, _ := json.Marshal(typed)
+	json.Unmarshal(paramsraw, &params)
+
+	for key, val := range raw {
+		params[key] = val
+	}
+
+	return params
+}
@@ -0,0 +1,188 @@
+package baseline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/api/privacy"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+const shieldGetRootFunctionSelector = "getRoot()"
+const shieldRootsFunctionSelector = "roots(bytes32)"
+
+// GetObjectProof retrieves the proof and state commitment for the given baselined object
+func (s *Service) GetObjectProof(id string) (*ObjectProof, error) {
+	_, proof, err := s.getObjectProof(id)
+	return proof, err
+}
+
+// getObjectProof retrieves the proof for the given baselined object along with the response status
+func (s *Service) getObjectProof(id string) (int, *ObjectProof, error) {
+	uri := fmt.Sprintf("objects/%s/proof", id)
+	status, resp, err := s.Get(uri, map[string]interface{}{})
+	if err != nil {
+		return status, nil, fmt.Errorf("failed to fetch object proof; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return status, nil, fmt.Errorf("failed to fetch object proof; status: %v", status)
+	}
+
+	proof := &ObjectProof{}
+	proofraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(proofraw, &proof)
+	if err != nil {
+		return status, nil, fmt.Errorf("failed to fetch object proof; status: %v; %s", status, err.Error())
+	}
+
+	return status, proof, nil
+}
+
+// GetObjectProof is the package-level variant of Service.GetObjectProof, using the default service configuration
+func GetObjectProof(token, id string) (*ObjectProof, error) {
+	return InitBaselineService(token).GetObjectProof(id)
+}
+
+// GetObjectStateHistory retrieves each of the baselined states of the given object, ordered from
+// the earliest to the latest state, including the proof and counterparty attestations of each state
+func (s *Service) GetObjectStateHistory(id string) ([]*ObjectState, error) {
+	uri := fmt.Sprintf("objects/%s/history", id)
+	status, resp, err := s.Get(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch object state history; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, newError("failed to fetch object state history", status, resp)
+	}
+
+	states, err := api.DecodeList[ObjectState](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch object state history; status: %v; %s", status, err.Error())
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i].Sequence < states[j].Sequence
+	})
+
+	return states, nil
+}
+
+// GetObjectStateHistory is the package-level variant of Service.GetObjectStateHistory, using the default service configuration
+func GetObjectStateHistory(token, id string) ([]*ObjectState, error) {
+	return InitBaselineService(token).GetObjectStateHistory(id)
+}
+
+// VerifyObjectProof verifies the given object proof using the workstep circuit and, when the
+// proof references a shield contract, verifies that the committed root is the current root or
+// one of the historical roots of the shield contract using the given JSON-RPC endpoint; the
+// root of a proof is superseded as soon as subsequent commitments are inserted into the tree.
+// The proof carries no merkle path, so the inclusion of the commitment under the root is not
+// checked. An error is returned if the proof references neither a circuit nor a shield root,
+// as there is nothing to verify.
+func VerifyObjectProof(token string, proof *ObjectProof, rpcClientKey, rpcURL string) (bool, error) {
+	if proof == nil || proof.Proof == nil {
+		return false, errors.New("failed to verify object proof; no proof provided")
+	}
+
+	if proof.CircuitID == nil && (proof.Shield == nil || proof.Root == nil) {
+		return false, errors.New("failed to verify object proof; proof references neither a circuit nor a shield root")
+	}
+
+	if proof.CircuitID != nil {
+		resp, err := privacy.Verify(token, proof.CircuitID.String(), map[string]interface{}{
+			"proof":   *proof.Proof,
+			"witness": proof.Witness,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to verify object proof; %s", err.Error())
+		}
+
+		if !resp.Result {
+			return false, nil
+		}
+	}
+
+	if proof.Shield != nil && proof.Root != nil {
+		root, err := shieldRoot(rpcClientKey, rpcURL, *proof.Shield)
+		if err != nil {
+			return false, err
+		}
+
+		if normalizeHex(root) != normalizeHex(*proof.Root) {
+			historical, err := shieldHasRoot(rpcClientKey, rpcURL, *proof.Shield, *proof.Root)
+			if err != nil {
+				return false, err
+			}
+
+			if !historical {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// shieldRoot reads the current root of the shield contract at the given address
+func shieldRoot(rpcClientKey, rpcURL, shield string) (string, error) {
+	root, err := shieldCall(rpcClientKey, rpcURL, shield, crypto.EVMHashFunctionSelector(shieldGetRootFunctionSelector))
+	if err != nil {
+		return "", fmt.Errorf("failed to read shield contract root; %s", err.Error())
+	}
+
+	return root, nil
+}
+
+// shieldHasRoot returns true if the given root is recorded in the root history of the shield
+// contract at the given address
+func shieldHasRoot(rpcClientKey, rpcURL, shield, root string) (bool, error) {
+	arg := strings.TrimPrefix(strings.ToLower(root), "0x")
+	if len(arg) > 64 {
+		return false, fmt.Errorf("failed to read shield contract root history; invalid root: %s", root)
+	}
+	arg = strings.Repeat("0", 64-len(arg)) + arg
+
+	val, err := shieldCall(rpcClientKey, rpcURL, shield, crypto.EVMHashFunctionSelector(shieldRootsFunctionSelector)+arg)
+	if err != nil {
+		return false, fmt.Errorf("failed to read shield contract root history; %s", err.Error())
+	}
+
+	return normalizeHex(val) != "" && normalizeHex(val) == normalizeHex(root), nil
+}
+
+// shieldCall invokes eth_call on the shield contract at the given address using the given
+// hex-encoded calldata, returning the hex-encoded result
+func shieldCall(rpcClientKey, rpcURL, shield, data string) (string, error) {
+	resp, err := crypto.EVMEthCall(rpcClientKey, rpcURL, []interface{}{
+		map[string]interface{}{
+			"to":   shield,
+			"data": fmt.Sprintf("0x%s", data),
+		},
+		"latest",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if resp.Error != nil {
+		return "", fmt.Errorf("%v", resp.Error)
+	}
+
+	val, ok := resp.Result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected result: %v", resp.Result)
+	}
+
+	return val, nil
+}
+
+func normalizeHex(val string) string {
+	val = strings.TrimPrefix(strings.ToLower(val), "0x")
+	return strings.TrimLeft(val, "0")
+}
@@ -0,0 +1,75 @@
+package baseline
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/provideplatform/provide-go/common"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+func TestVerifyObjectProofHistoricalRoot(t *testing.T) {
+	const currentRoot = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	const historicalRoot = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	const unknownRoot = "0x3333333333333333333333333333333333333333333333333333333333333333"
+
+	getRoot := "0x" + crypto.EVMHashFunctionSelector(shieldGetRootFunctionSelector)
+	roots := "0x" + crypto.EVMHashFunctionSelector(shieldRootsFunctionSelector)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage          `json:"id"`
+			Params []map[string]interface{} `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		data, _ := req.Params[0]["data"].(string)
+		result := "0x" + strings.Repeat("0", 64)
+		switch {
+		case data == getRoot:
+			result = currentRoot
+		case data == roots+strings.TrimPrefix(historicalRoot, "0x"):
+			result = historicalRoot
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		})
+	}))
+	defer srv.Close()
+
+	for root, expected := range map[string]bool{
+		currentRoot:    true,
+		historicalRoot: true,
+		unknownRoot:    false,
+	} {
+		verified, err := VerifyObjectProof("token", &ObjectProof{
+			Proof:  common.StringOrNil("0x"),
+			Root:   common.StringOrNil(root),
+			Shield: common.StringOrNil("0x0000000000000000000000000000000000000001"),
+		}, "baseline-proof-test", srv.URL)
+		if err != nil {
+			t.Fatalf("failed to verify object proof; %s", err.Error())
+		}
+
+		if verified != expected {
+			t.Errorf("expected verification of root %s to be %v", root, expected)
+		}
+	}
+}
+
+func TestVerifyObjectProofNothingToVerify(t *testing.T) {
+	verified, err := VerifyObjectProof("token", &ObjectProof{
+		Proof:  common.StringOrNil("0x"),
+		Shield: common.StringOrNil("0x0000000000000000000000000000000000000001"),
+	}, "baseline-proof-test", "http://127.0.0.1:0")
+	if err == nil || verified {
+		t.Error("expected proof without a circuit or shield root to fail verification")
+	}
+}
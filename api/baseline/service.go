@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
@@ -18,16 +19,18 @@ type Service struct {
 	api.Client
 }
 
-// InitBaselineService convenience method to initialize a `baseline.Service` instance
-func InitBaselineService(token string) *Service {
+// InitBaselineService convenience method to initialize a `baseline.Service` instance; the
+// service is configured using the environment and any default options (see Configure),
+// followed by the given options
+func InitBaselineService(token string, opts ...Option) *Service {
 	host := defaultBaselineHost
 	if os.Getenv("BASELINE_API_HOST") != "" {
 		host = os.Getenv("BASELINE_API_HOST")
 	}
 
 	path := defaultBaselinePath
-	if os.Getenv("BASELIEN_API_PATH") != "" {
-		host = os.Getenv("BASELIEN_API_PATH")
+	if os.Getenv("BASELINE_API_PATH") != "" {
+		path = os.Getenv("BASELINE_API_PATH")
 	}
 
 	scheme := defaultBaselineScheme
@@ -35,7 +38,7 @@ func InitBaselineService(token string) *Service {
 		scheme = os.Getenv("BASELINE_API_SCHEME")
 	}
 
-	return &Service{
+	service := &Service{
 		api.Client{
 			Host:   host,
 			Path:   path,
@@ -43,25 +46,42 @@ func InitBaselineService(token string) *Service {
 			Token:  common.StringOrNil(token),
 		},
 	}
+
+	defaultOptionsMutex.RLock()
+	for _, opt := range defaultOptions {
+		opt(service)
+	}
+	defaultOptionsMutex.RUnlock()
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
 }
 
 // ConfigureStack updates the global configuration on the local baseline stack
-func ConfigureStack(token string, params map[string]interface{}) error {
-	status, _, err := InitBaselineService(token).Put("config", params)
+func (s *Service) ConfigureStack(params map[string]interface{}) error {
+	status, resp, err := s.Put("config", params)
 	if err != nil {
 		return fmt.Errorf("failed to configure baseline stack; status: %v; %s", status, err.Error())
 	}
 
 	if status != 204 {
-		return fmt.Errorf("failed to configure baseline stack; status: %v", status)
+		return newError("failed to configure baseline stack", status, resp)
 	}
 
 	return nil
 }
 
+// ConfigureStack is the package-level variant of Service.ConfigureStack, using the default service configuration
+func ConfigureStack(token string, params map[string]interface{}) error {
+	return InitBaselineService(token).ConfigureStack(params)
+}
+
 // ListWorkgroups retrieves a paginated list of baseline workgroups scoped to the given API token
-func ListWorkgroups(token, applicationID string, params map[string]interface{}) ([]*Workgroup, error) {
-	status, resp, err := InitBaselineService(token).Get("workgroups", params)
+func (s *Service) ListWorkgroups(applicationID string, params map[string]interface{}) ([]*Workgroup, error) {
+	status, resp, err := s.Get("workgroups", params)
 	if err != nil {
 		return nil, err
 	}
@@ -70,26 +90,28 @@ func ListWorkgroups(token, applicationID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to list baseline workgroups; status: %v", status)
 	}
 
-	workgroups := make([]*Workgroup, 0)
-	for _, item := range resp.([]interface{}) {
-		workgroup := &Workgroup{}
-		workgroupraw, _ := json.Marshal(item)
-		json.Unmarshal(workgroupraw, &workgroup)
-		workgroups = append(workgroups, workgroup)
+	workgroups, err := api.DecodeList[Workgroup](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baseline workgroups; status: %v; %s", status, err.Error())
 	}
 
 	return workgroups, nil
 }
 
+// ListWorkgroups is the package-level variant of Service.ListWorkgroups, using the default service configuration
+func ListWorkgroups(token, applicationID string, params map[string]interface{}) ([]*Workgroup, error) {
+	return InitBaselineService(token).ListWorkgroups(applicationID, params)
+}
+
 // CreateWorkgroup initializes a new or previously-joined workgroup on the local baseline stack
-func CreateWorkgroup(token string, params map[string]interface{}) (*Workgroup, error) {
-	status, resp, err := InitBaselineService(token).Post("workgroups", params)
+func (s *Service) CreateWorkgroup(params map[string]interface{}) (*Workgroup, error) {
+	status, resp, err := s.Post("workgroups", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workgroup; status: %v; %s", status, err.Error())
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to create workgroup; status: %v", status)
+		return nil, newError("failed to create workgroup", status, resp)
 	}
 
 	workgroup := &Workgroup{}
@@ -99,24 +121,123 @@ func CreateWorkgroup(token string, params map[string]interface{}) (*Workgroup, e
 	return workgroup, nil
 }
 
+// CreateWorkgroup is the package-level variant of Service.CreateWorkgroup, using the default service configuration
+func CreateWorkgroup(token string, params map[string]interface{}) (*Workgroup, error) {
+	return InitBaselineService(token).CreateWorkgroup(params)
+}
+
 // UpdateWorkgroup updates a previously-initialized workgroup on the local baseline stack
-func UpdateWorkgroup(id, token string, params map[string]interface{}) error {
+func (s *Service) UpdateWorkgroup(id string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("workgroups/%s", id)
-	status, _, err := InitBaselineService(token).Post(uri, params)
+	status, resp, err := s.Post(uri, params)
 	if err != nil {
 		return fmt.Errorf("failed to update workgroup; status: %v; %s", status, err.Error())
 	}
 
 	if status != 204 {
-		return fmt.Errorf("failed to update workgroup; status: %v", status)
+		return newError("failed to update workgroup", status, resp)
 	}
 
 	return nil
 }
 
+// UpdateWorkgroup is the package-level variant of Service.UpdateWorkgroup, using the default service configuration
+func UpdateWorkgroup(id, token string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateWorkgroup(id, params)
+}
+
+// ListWorkgroupParticipants retrieves a paginated list of participants in the given workgroup
+func (s *Service) ListWorkgroupParticipants(workgroupID string, params map[string]interface{}) ([]*Participant, error) {
+	uri := fmt.Sprintf("workgroups/%s/participants", workgroupID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list workgroup participants; status: %v", status)
+	}
+
+	participants, err := api.DecodeList[Participant](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workgroup participants; status: %v; %s", status, err.Error())
+	}
+
+	return participants, nil
+}
+
+// ListWorkgroupParticipants is the package-level variant of Service.ListWorkgroupParticipants, using the default service configuration
+func ListWorkgroupParticipants(token, workgroupID string, params map[string]interface{}) ([]*Participant, error) {
+	return InitBaselineService(token).ListWorkgroupParticipants(workgroupID, params)
+}
+
+// InviteWorkgroupParticipant dispatches an invitation to join the given workgroup
+func (s *Service) InviteWorkgroupParticipant(workgroupID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("workgroups/%s/invitations", workgroupID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to invite workgroup participant; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to invite workgroup participant", status, resp)
+	}
+
+	return nil
+}
+
+// InviteWorkgroupParticipant is the package-level variant of Service.InviteWorkgroupParticipant, using the default service configuration
+func InviteWorkgroupParticipant(token, workgroupID string, params map[string]interface{}) error {
+	return InitBaselineService(token).InviteWorkgroupParticipant(workgroupID, params)
+}
+
+// CreateWorkgroupParticipant adds a counterparty, including its messaging endpoint
+// and verifying key, as a participant in the given workgroup
+func (s *Service) CreateWorkgroupParticipant(workgroupID string, params map[string]interface{}) (*Participant, error) {
+	uri := fmt.Sprintf("workgroups/%s/participants", workgroupID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workgroup participant; status: %v; %s", status, err.Error())
+	}
+
+	if status != 201 {
+		return nil, newError("failed to create workgroup participant", status, resp)
+	}
+
+	participant := &Participant{}
+	participantraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(participantraw, &participant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workgroup participant; status: %v; %s", status, err.Error())
+	}
+
+	return participant, nil
+}
+
+// CreateWorkgroupParticipant is the package-level variant of Service.CreateWorkgroupParticipant, using the default service configuration
+func CreateWorkgroupParticipant(token, workgroupID string, params map[string]interface{}) (*Participant, error) {
+	return InitBaselineService(token).CreateWorkgroupParticipant(workgroupID, params)
+}
+
+// DeleteWorkgroupParticipant removes the participant with the given address from the given workgroup
+func (s *Service) DeleteWorkgroupParticipant(workgroupID, address string) error {
+	uri := fmt.Sprintf("workgroups/%s/participants/%s", workgroupID, address)
+	status, resp, err := s.Delete(uri)
+	if err != nil {
+		return fmt.Errorf("failed to delete workgroup participant; status: %v; %s", status, err.Error())
+	}
+
+	return statusError("failed to delete workgroup participant", status, resp)
+}
+
+// DeleteWorkgroupParticipant is the package-level variant of Service.DeleteWorkgroupParticipant, using the default service configuration
+func DeleteWorkgroupParticipant(token, workgroupID, address string) error {
+	return InitBaselineService(token).DeleteWorkgroupParticipant(workgroupID, address)
+}
+
 // ListWorkflows retrieves a paginated list of baseline workflows scoped to the given API token
-func ListWorkflows(token, applicationID string, params map[string]interface{}) ([]*Workflow, error) {
-	status, resp, err := InitBaselineService(token).Get("workflows", params)
+func (s *Service) ListWorkflows(applicationID string, params map[string]interface{}) ([]*Workflow, error) {
+	status, resp, err := s.Get("workflows", params)
 	if err != nil {
 		return nil, err
 	}
@@ -125,26 +246,28 @@ func ListWorkflows(token, applicationID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list baseline workflows; status: %v", status)
 	}
 
-	workflows := make([]*Workflow, 0)
-	for _, item := range resp.([]interface{}) {
-		workflow := &Workflow{}
-		workflowraw, _ := json.Marshal(item)
-		json.Unmarshal(workflowraw, &workflow)
-		workflows = append(workflows, workflow)
+	workflows, err := api.DecodeList[Workflow](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baseline workflows; status: %v; %s", status, err.Error())
 	}
 
 	return workflows, nil
 }
 
+// ListWorkflows is the package-level variant of Service.ListWorkflows, using the default service configuration
+func ListWorkflows(token, applicationID string, params map[string]interface{}) ([]*Workflow, error) {
+	return InitBaselineService(token).ListWorkflows(applicationID, params)
+}
+
 // CreateWorkflow initializes a new workflow on the local baseline stack
-func CreateWorkflow(token string, params map[string]interface{}) (*Workflow, error) {
-	status, resp, err := InitBaselineService(token).Post("workflows", params)
+func (s *Service) CreateWorkflow(params map[string]interface{}) (*Workflow, error) {
+	status, resp, err := s.Post("workflows", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workflow; status: %v; %s", status, err.Error())
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to create workflow; status: %v", status)
+		return nil, newError("failed to create workflow", status, resp)
 	}
 
 	workflow := &Workflow{}
@@ -154,9 +277,34 @@ func CreateWorkflow(token string, params map[string]interface{}) (*Workflow, err
 	return workflow, nil
 }
 
+// CreateWorkflow is the package-level variant of Service.CreateWorkflow, using the default service configuration
+func CreateWorkflow(token string, params map[string]interface{}) (*Workflow, error) {
+	return InitBaselineService(token).CreateWorkflow(params)
+}
+
+// UpdateWorkflow updates the given workflow on the local baseline stack
+func (s *Service) UpdateWorkflow(id string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("workflows/%s", id)
+	status, resp, err := s.Put(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to update workflow; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to update workflow", status, resp)
+	}
+
+	return nil
+}
+
+// UpdateWorkflow is the package-level variant of Service.UpdateWorkflow, using the default service configuration
+func UpdateWorkflow(token, id string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateWorkflow(id, params)
+}
+
 // ListWorksteps retrieves a paginated list of baseline worksteps scoped to the given API token
-func ListWorksteps(token, applicationID string, params map[string]interface{}) ([]*Workstep, error) {
-	status, resp, err := InitBaselineService(token).Get("worksteps", params)
+func (s *Service) ListWorksteps(applicationID string, params map[string]interface{}) ([]*Workstep, error) {
+	status, resp, err := s.Get("worksteps", params)
 	if err != nil {
 		return nil, err
 	}
@@ -165,26 +313,28 @@ func ListWorksteps(token, applicationID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list baseline worksteps; status: %v", status)
 	}
 
-	worksteps := make([]*Workstep, 0)
-	for _, item := range resp.([]interface{}) {
-		workstep := &Workstep{}
-		workstepraw, _ := json.Marshal(item)
-		json.Unmarshal(workstepraw, &workstep)
-		worksteps = append(worksteps, workstep)
+	worksteps, err := api.DecodeList[Workstep](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baseline worksteps; status: %v; %s", status, err.Error())
 	}
 
 	return worksteps, nil
 }
 
+// ListWorksteps is the package-level variant of Service.ListWorksteps, using the default service configuration
+func ListWorksteps(token, applicationID string, params map[string]interface{}) ([]*Workstep, error) {
+	return InitBaselineService(token).ListWorksteps(applicationID, params)
+}
+
 // CreateWorkstep initializes a new workstep on the local baseline stack
-func CreateWorkstep(token string, params map[string]interface{}) (*Workstep, error) {
-	status, resp, err := InitBaselineService(token).Post("worksteps", params)
+func (s *Service) CreateWorkstep(params map[string]interface{}) (*Workstep, error) {
+	status, resp, err := s.Post("worksteps", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create workstep; status: %v; %s", status, err.Error())
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to create workstep; status: %v", status)
+		return nil, newError("failed to create workstep", status, resp)
 	}
 
 	workstep := &Workstep{}
@@ -194,31 +344,347 @@ func CreateWorkstep(token string, params map[string]interface{}) (*Workstep, err
 	return workstep, nil
 }
 
+// CreateWorkstep is the package-level variant of Service.CreateWorkstep, using the default service configuration
+func CreateWorkstep(token string, params map[string]interface{}) (*Workstep, error) {
+	return InitBaselineService(token).CreateWorkstep(params)
+}
+
+// UpdateWorkstep updates the given workstep on the local baseline stack
+func (s *Service) UpdateWorkstep(workflowID, workstepID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("workflows/%s/worksteps/%s", workflowID, workstepID)
+	status, resp, err := s.Put(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to update workstep; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to update workstep", status, resp)
+	}
+
+	return nil
+}
+
+// UpdateWorkstep is the package-level variant of Service.UpdateWorkstep, using the default service configuration
+func UpdateWorkstep(token, workflowID, workstepID string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateWorkstep(workflowID, workstepID, params)
+}
+
+// ExecuteWorkstep executes the given workstep using the given params, advancing the workflow
+// instance; the witness, when provided, is used to generate the proof for the workstep circuit
+func (s *Service) ExecuteWorkstep(workflowID, workstepID string, params map[string]interface{}) (*WorkstepExecution, error) {
+	uri := fmt.Sprintf("workflows/%s/worksteps/%s/execute", workflowID, workstepID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute workstep; status: %v; %s", status, err.Error())
+	}
+
+	if status != 201 && status != 202 {
+		return nil, newError("failed to execute workstep", status, resp)
+	}
+
+	execution := &WorkstepExecution{}
+	executionraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(executionraw, &execution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute workstep; status: %v; %s", status, err.Error())
+	}
+
+	return execution, nil
+}
+
+// ExecuteWorkstep is the package-level variant of Service.ExecuteWorkstep, using the default service configuration
+func ExecuteWorkstep(token, workflowID, workstepID string, params map[string]interface{}) (*WorkstepExecution, error) {
+	return InitBaselineService(token).ExecuteWorkstep(workflowID, workstepID, params)
+}
+
+// ListWorkstepParticipants retrieves a paginated list of participants in the given workstep
+func (s *Service) ListWorkstepParticipants(workflowID, workstepID string, params map[string]interface{}) ([]*Participant, error) {
+	uri := fmt.Sprintf("workflows/%s/worksteps/%s/participants", workflowID, workstepID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list workstep participants; status: %v", status)
+	}
+
+	participants, err := api.DecodeList[Participant](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workstep participants; status: %v; %s", status, err.Error())
+	}
+
+	return participants, nil
+}
+
+// ListWorkstepParticipants is the package-level variant of Service.ListWorkstepParticipants, using the default service configuration
+func ListWorkstepParticipants(token, workflowID, workstepID string, params map[string]interface{}) ([]*Participant, error) {
+	return InitBaselineService(token).ListWorkstepParticipants(workflowID, workstepID, params)
+}
+
+// ApproveWorkstep approves a pending execution of the given workstep on behalf of the
+// participant authorized by the given token
+func (s *Service) ApproveWorkstep(workflowID, workstepID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("workflows/%s/worksteps/%s/approve", workflowID, workstepID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to approve workstep; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to approve workstep", status, resp)
+	}
+
+	return nil
+}
+
+// ApproveWorkstep is the package-level variant of Service.ApproveWorkstep, using the default service configuration
+func ApproveWorkstep(token, workflowID, workstepID string, params map[string]interface{}) error {
+	return InitBaselineService(token).ApproveWorkstep(workflowID, workstepID, params)
+}
+
+// FinalizeWorkstep finalizes the given workstep; worksteps which require finality
+// cannot be finalized until approved by all participants
+func (s *Service) FinalizeWorkstep(workflowID, workstepID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("workflows/%s/worksteps/%s/finalize", workflowID, workstepID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to finalize workstep; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to finalize workstep", status, resp)
+	}
+
+	return nil
+}
+
+// FinalizeWorkstep is the package-level variant of Service.FinalizeWorkstep, using the default service configuration
+func FinalizeWorkstep(token, workflowID, workstepID string, params map[string]interface{}) error {
+	return InitBaselineService(token).FinalizeWorkstep(workflowID, workstepID, params)
+}
+
 // CreateObject is a generic way to baseline a business object
-func CreateObject(token string, params map[string]interface{}) (interface{}, error) {
-	status, resp, err := InitBaselineService(token).Post("objects", params)
+func (s *Service) CreateObject(params map[string]interface{}) (interface{}, error) {
+	status, resp, err := s.Post("objects", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create baseline object; status: %v; %s", status, err.Error())
 	}
 
 	if status != 202 {
-		return nil, fmt.Errorf("failed to create baseline object; status: %v", status)
+		return nil, newError("failed to create baseline object", status, resp)
 	}
 
 	return resp, nil
 }
 
+// CreateObject is the package-level variant of Service.CreateObject, using the default service configuration
+func CreateObject(token string, params map[string]interface{}) (interface{}, error) {
+	return InitBaselineService(token).CreateObject(params)
+}
+
 // UpdateObject updates a business object
-func UpdateObject(token, id string, params map[string]interface{}) error {
+func (s *Service) UpdateObject(id string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("objects/%s", id)
-	status, _, err := InitBaselineService(token).Put(uri, params)
+	status, resp, err := s.Put(uri, params)
 	if err != nil {
 		return fmt.Errorf("failed to update baseline state; status: %v; %s", status, err.Error())
 	}
 
 	if status != 202 {
-		return fmt.Errorf("failed to update baseline state; status: %v", status)
+		return newError("failed to update baseline state", status, resp)
 	}
 
 	return nil
 }
+
+// UpdateObject is the package-level variant of Service.UpdateObject, using the default service configuration
+func UpdateObject(token, id string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateObject(id, params)
+}
+
+// CreateWorkgroupWithParams validates the given typed params and initializes a new or
+// previously-joined workgroup on the local baseline stack
+func (s *Service) CreateWorkgroupWithParams(params *WorkgroupParams) (*Workgroup, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workgroup; %s", err.Error())
+	}
+	return s.CreateWorkgroup(params.Params())
+}
+
+// CreateWorkgroupWithParams is the package-level variant of Service.CreateWorkgroupWithParams, using the default service configuration
+func CreateWorkgroupWithParams(token string, params *WorkgroupParams) (*Workgroup, error) {
+	return InitBaselineService(token).CreateWorkgroupWithParams(params)
+}
+
+// UpdateWorkgroupWithParams validates the given typed params and updates the workgroup on the local baseline stack
+func (s *Service) UpdateWorkgroupWithParams(id string, params *WorkgroupParams) error {
+	err := params.ValidateUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to update workgroup; %s", err.Error())
+	}
+	return s.UpdateWorkgroup(id, params.Params())
+}
+
+// UpdateWorkgroupWithParams is the package-level variant of Service.UpdateWorkgroupWithParams, using the default service configuration
+func UpdateWorkgroupWithParams(token, id string, params *WorkgroupParams) error {
+	return InitBaselineService(token).UpdateWorkgroupWithParams(id, params)
+}
+
+// CreateWorkflowWithParams validates the given typed params and initializes a new workflow on the local baseline stack
+func (s *Service) CreateWorkflowWithParams(params *WorkflowParams) (*Workflow, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow; %s", err.Error())
+	}
+	return s.CreateWorkflow(params.Params())
+}
+
+// CreateWorkflowWithParams is the package-level variant of Service.CreateWorkflowWithParams, using the default service configuration
+func CreateWorkflowWithParams(token string, params *WorkflowParams) (*Workflow, error) {
+	return InitBaselineService(token).CreateWorkflowWithParams(params)
+}
+
+// UpdateWorkflowWithParams validates the given typed params and updates the workflow on the local baseline stack
+func (s *Service) UpdateWorkflowWithParams(id string, params *WorkflowParams) error {
+	err := params.ValidateUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to update workflow; %s", err.Error())
+	}
+	return s.UpdateWorkflow(id, params.Params())
+}
+
+// UpdateWorkflowWithParams is the package-level variant of Service.UpdateWorkflowWithParams, using the default service configuration
+func UpdateWorkflowWithParams(token, id string, params *WorkflowParams) error {
+	return InitBaselineService(token).UpdateWorkflowWithParams(id, params)
+}
+
+// CreateWorkstepWithParams validates the given typed params and initializes a new workstep on the local baseline stack
+func (s *Service) CreateWorkstepWithParams(params *WorkstepParams) (*Workstep, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workstep; %s", err.Error())
+	}
+	return s.CreateWorkstep(params.Params())
+}
+
+// CreateWorkstepWithParams is the package-level variant of Service.CreateWorkstepWithParams, using the default service configuration
+func CreateWorkstepWithParams(token string, params *WorkstepParams) (*Workstep, error) {
+	return InitBaselineService(token).CreateWorkstepWithParams(params)
+}
+
+// UpdateWorkstepWithParams validates the given typed params and updates the workstep on the local
+// baseline stack; only the given params are updated, i.e., to configure the workstep constraints
+func (s *Service) UpdateWorkstepWithParams(workflowID, workstepID string, params *WorkstepParams) error {
+	err := params.ValidateUpdate()
+	if err != nil {
+		return fmt.Errorf("failed to update workstep; %s", err.Error())
+	}
+	return s.UpdateWorkstep(workflowID, workstepID, params.Params())
+}
+
+// UpdateWorkstepWithParams is the package-level variant of Service.UpdateWorkstepWithParams, using the default service configuration
+func UpdateWorkstepWithParams(token, workflowID, workstepID string, params *WorkstepParams) error {
+	return InitBaselineService(token).UpdateWorkstepWithParams(workflowID, workstepID, params)
+}
+
+// CreateObjectWithParams validates the given typed params and baselines the object
+func (s *Service) CreateObjectWithParams(params *ObjectParams) (interface{}, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object; %s", err.Error())
+	}
+	return s.CreateObject(params.Params())
+}
+
+// CreateObjectWithParams is the package-level variant of Service.CreateObjectWithParams, using the default service configuration
+func CreateObjectWithParams(token string, params *ObjectParams) (interface{}, error) {
+	return InitBaselineService(token).CreateObjectWithParams(params)
+}
+
+// UpdateObjectWithParams validates the given typed params and updates the baselined object
+func (s *Service) UpdateObjectWithParams(id string, params *ObjectParams) error {
+	err := params.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to update object; %s", err.Error())
+	}
+	return s.UpdateObject(id, params.Params())
+}
+
+// UpdateObjectWithParams is the package-level variant of Service.UpdateObjectWithParams, using the default service configuration
+func UpdateObjectWithParams(token, id string, params *ObjectParams) error {
+	return InitBaselineService(token).UpdateObjectWithParams(id, params)
+}
+
+// SendProtocolMessage sends the given protocol message to its recipient through the local
+// baseline stack; the returned message contains the identifiers used to correlate the
+// round trip (i.e., the message id and baseline id)
+func (s *Service) SendProtocolMessage(msg *ProtocolMessage) (*Message, error) {
+	if msg == nil || msg.Recipient == nil {
+		return nil, fmt.Errorf("failed to send protocol message; recipient required")
+	}
+
+	params := map[string]interface{}{}
+	msgraw, _ := json.Marshal(msg)
+	json.Unmarshal(msgraw, &params)
+
+	status, resp, err := s.Post("protocol_messages", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send protocol message; status: %v; %s", status, err.Error())
+	}
+
+	if status != 202 {
+		return nil, newError("failed to send protocol message", status, resp)
+	}
+
+	message := &Message{}
+	messageraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(messageraw, &message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send protocol message; status: %v; %s", status, err.Error())
+	}
+
+	if message.ProtocolMessage == nil {
+		message.ProtocolMessage = msg
+	}
+
+	return message, nil
+}
+
+// SendProtocolMessage is the package-level variant of Service.SendProtocolMessage, using the default service configuration
+func SendProtocolMessage(token string, msg *ProtocolMessage) (*Message, error) {
+	return InitBaselineService(token).SendProtocolMessage(msg)
+}
+
+// GetStackStatus retrieves the health of the local baseline stack and its subsystems;
+// the stack is ready when the returned status is Healthy. The status endpoint is served
+// outside of the versioned API, so it is resolved relative to the configured path with the
+// api/v1 suffix removed, i.e., a path of stack/api/v1 resolves stack/status
+func (s *Service) GetStackStatus() (*StackStatus, error) {
+	service := *s
+	service.Path = strings.Trim(strings.TrimSuffix(strings.Trim(s.Path, "/"), defaultBaselinePath), "/")
+
+	status, resp, err := service.Get("status", map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch baseline stack status; %s", err.Error())
+	}
+
+	if status != 200 && status != 503 {
+		return nil, fmt.Errorf("failed to fetch baseline stack status; status: %v", status)
+	}
+
+	stackStatus := &StackStatus{}
+	statusraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(statusraw, &stackStatus)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch baseline stack status; status: %v; %s", status, err.Error())
+	}
+
+	return stackStatus, nil
+}
+
+// GetStackStatus is the package-level variant of Service.GetStackStatus, using the default service configuration
+func GetStackStatus(token string) (*StackStatus, error) {
+	return InitBaselineService(token).GetStackStatus()
+}
@@ -0,0 +1,154 @@
+package baseline
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/provideplatform/provide-go/version"
+)
+
+func TestServiceOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stack/workgroups" {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+
+		if r.Header.Get("User-Agent") != "stack-a "+version.UserAgent() {
+			t.Errorf("expected configured user agent followed by the provide-go user agent; got %s", r.Header.Get("User-Agent"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id":"0b1bcf4d-5c3e-4fd4-8b3a-1f7e0a7f5a2e"}]`))
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	service := InitBaselineService("token",
+		WithHost(srvURL.Host),
+		WithScheme(srvURL.Scheme),
+		WithPath("stack"),
+		WithUserAgent("stack-a"),
+	)
+
+	workgroups, err := service.ListWorkgroups("", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to list workgroups; %s", err.Error())
+	}
+
+	if len(workgroups) != 1 || workgroups[0].ID == nil || workgroups[0].ID.String() != "0b1bcf4d-5c3e-4fd4-8b3a-1f7e0a7f5a2e" {
+		t.Errorf("unexpected workgroups")
+	}
+}
+
+func TestGetStackStatusPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"api/v1":        "/status",
+		"stack/api/v1/": "/stack/status",
+		"stack":         "/stack/status",
+	} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != expected {
+				t.Errorf("expected status path %s for %s; got %s", expected, path, r.URL.Path)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{}`))
+		}))
+
+		srvURL, _ := url.Parse(srv.URL)
+		_, err := InitBaselineService("token", WithHost(srvURL.Host), WithScheme(srvURL.Scheme), WithPath(path)).GetStackStatus()
+		srv.Close()
+
+		if err != nil {
+			t.Errorf("failed to fetch stack status for path %s; %s", path, err.Error())
+		}
+	}
+}
+
+func TestWithParamsNilParams(t *testing.T) {
+	service := InitBaselineService("token", WithHost("127.0.0.1:0"))
+
+	if _, err := service.CreateWorkgroupWithParams(nil); err == nil {
+		t.Error("expected error creating workgroup without params")
+	}
+	if err := service.UpdateWorkgroupWithParams("id", nil); err == nil {
+		t.Error("expected error updating workgroup without params")
+	}
+	if _, err := service.CreateWorkflowWithParams(nil); err == nil {
+		t.Error("expected error creating workflow without params")
+	}
+	if err := service.UpdateWorkflowWithParams("id", nil); err == nil {
+		t.Error("expected error updating workflow without params")
+	}
+	if _, err := service.CreateWorkstepWithParams(nil); err == nil {
+		t.Error("expected error creating workstep without params")
+	}
+	if err := service.UpdateWorkstepWithParams("workflow", "id", nil); err == nil {
+		t.Error("expected error updating workstep without params")
+	}
+	if _, err := service.CreateObjectWithParams(nil); err == nil {
+		t.Error("expected error creating object without params")
+	}
+	if err := service.UpdateObjectWithParams("id", nil); err == nil {
+		t.Error("expected error updating object without params")
+	}
+}
+
+func TestUpdateWorkstepWithParamsPartial(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v1/workflows/workflow/worksteps/workstep" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	service := InitBaselineService("token", WithHost(srvURL.Host), WithScheme(srvURL.Scheme))
+
+	threshold := 1
+	err := service.UpdateWorkstepWithParams("workflow", "workstep", &WorkstepParams{
+		Constraints: &WorkstepConstraints{
+			RequiredApprovers: []string{"0x01", "0x02"},
+			FinalityThreshold: &threshold,
+		},
+	})
+	if err != nil {
+		t.Errorf("failed to update workstep constraints; %s", err.Error())
+	}
+
+	threshold = 3
+	err = service.UpdateWorkstepWithParams("workflow", "workstep", &WorkstepParams{
+		Constraints: &WorkstepConstraints{
+			RequiredApprovers: []string{"0x01", "0x02"},
+			FinalityThreshold: &threshold,
+		},
+	})
+	if err == nil {
+		t.Error("expected error updating workstep with invalid constraints")
+	}
+}
+
+func TestDeployWorkflowValidationError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(422)
+		w.Write([]byte(`{"errors":[{"message":"workstep circuit required"}]}`))
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	service := InitBaselineService("token", WithHost(srvURL.Host), WithScheme(srvURL.Scheme))
+
+	_, err := service.DeployWorkflow("workflow")
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected validation error; got %v", err)
+	}
+
+	var baselineErr *Error
+	if !errors.As(err, &baselineErr) || baselineErr.Status != 422 || len(baselineErr.Errors) != 1 {
+		t.Errorf("expected *Error describing the validation failure; got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package baseline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// EventTypeProtocolMessage is the type of event delivered upon receipt of an inbound protocol message
+const EventTypeProtocolMessage = "protocol_message"
+
+// EventTypeWorkflowStatus is the type of event delivered upon a change in workflow status
+const EventTypeWorkflowStatus = "workflow_status"
+
+const subscriptionBufferSize = 64
+
+// Event is a real-time event delivered by the local baseline stack; events which are
+// neither acked nor nacked are redelivered by the stack
+type Event struct {
+	ID              *string          `json:"id"`
+	Type            *string          `json:"type"`
+	ProtocolMessage *ProtocolMessage `json:"protocol_message,omitempty"`
+	Workflow        *Workflow        `json:"workflow,omitempty"`
+
+	service *Service
+}
+
+// Ack acknowledges successful processing of the event
+func (e *Event) Ack() error {
+	return e.settle("ack")
+}
+
+// Nack negatively acknowledges the event, signaling the stack to redeliver it
+func (e *Event) Nack() error {
+	return e.settle("nack")
+}
+
+func (e *Event) settle(op string) error {
+	if e.ID == nil {
+		return fmt.Errorf("failed to %s event; no event id", op)
+	}
+
+	uri := fmt.Sprintf("events/%s/%s", *e.ID, op)
+	status, resp, err := e.service.Post(uri, map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to %s event; status: %v; %s", op, status, err.Error())
+	}
+
+	if status != 204 {
+		return newError(fmt.Sprintf("failed to %s event", op), status, resp)
+	}
+
+	return nil
+}
+
+// Subscription delivers inbound protocol messages and workflow status changes as typed events
+type Subscription struct {
+	// Events is closed when the subscription is closed or terminated by an unrecoverable error
+	Events <-chan *Event
+
+	cancel context.CancelFunc
+	err    error
+	mutex  *sync.Mutex
+	stream *api.Stream
+}
+
+// Close the subscription
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+// Err returns the error which terminated the subscription, if any
+func (s *Subscription) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	return s.stream.Err()
+}
+
+// Subscribe to real-time events from the local baseline stack; params may be used to
+// filter events by type or workflow_id. The subscription is automatically resumed if the
+// connection to the stack is interrupted.
+func (s *Service) Subscribe(ctx context.Context, params map[string]interface{}) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := s.Stream(ctx, "events", params)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to subscribe to baseline events; %s", err.Error())
+	}
+
+	events := make(chan *Event, subscriptionBufferSize)
+	sub := &Subscription{
+		Events: events,
+		cancel: cancel,
+		mutex:  &sync.Mutex{},
+		stream: stream,
+	}
+
+	go func() {
+		defer close(events)
+
+		for evt := range stream.Events {
+			event := &Event{}
+			err := json.Unmarshal(evt.Data, &event)
+			if err != nil {
+				common.Log.Warningf("failed to unmarshal baseline event; %s", err.Error())
+				continue
+			}
+
+			if event.ID == nil {
+				event.ID = evt.ID
+			}
+			if event.Type == nil {
+				event.Type = evt.Event
+			}
+			event.service = s
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if stream.Err() == nil && ctx.Err() == nil {
+			sub.mutex.Lock()
+			sub.err = errors.New("baseline event stream terminated")
+			sub.mutex.Unlock()
+		}
+	}()
+
+	return sub, nil
+}
+
+// Subscribe is the package-level variant of Service.Subscribe, using the default service configuration
+func Subscribe(ctx context.Context, token string, params map[string]interface{}) (*Subscription, error) {
+	return InitBaselineService(token).Subscribe(ctx, params)
+}
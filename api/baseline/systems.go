@@ -0,0 +1,163 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+// ListSystems retrieves a paginated list of systems of record connected to the given workgroup
+func (s *Service) ListSystems(workgroupID string, params map[string]interface{}) ([]*System, error) {
+	uri := fmt.Sprintf("workgroups/%s/systems", workgroupID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list systems; status: %v", status)
+	}
+
+	systems, err := api.DecodeList[System](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list systems; status: %v; %s", status, err.Error())
+	}
+
+	return systems, nil
+}
+
+// ListSystems is the package-level variant of Service.ListSystems, using the default service configuration
+func ListSystems(token, workgroupID string, params map[string]interface{}) ([]*System, error) {
+	return InitBaselineService(token).ListSystems(workgroupID, params)
+}
+
+// CreateSystem registers a system of record with the given workgroup
+func (s *Service) CreateSystem(workgroupID string, params map[string]interface{}) (*System, error) {
+	uri := fmt.Sprintf("workgroups/%s/systems", workgroupID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create system; status: %v; %s", status, err.Error())
+	}
+
+	if status != 201 {
+		return nil, newError("failed to create system", status, resp)
+	}
+
+	system := &System{}
+	systemraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(systemraw, &system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create system; status: %v; %s", status, err.Error())
+	}
+
+	return system, nil
+}
+
+// CreateSystem is the package-level variant of Service.CreateSystem, using the default service configuration
+func CreateSystem(token, workgroupID string, params map[string]interface{}) (*System, error) {
+	return InitBaselineService(token).CreateSystem(workgroupID, params)
+}
+
+// GetSystemDetails retrieves details for the given system of record
+func (s *Service) GetSystemDetails(workgroupID, systemID string, params map[string]interface{}) (*System, error) {
+	uri := fmt.Sprintf("workgroups/%s/systems/%s", workgroupID, systemID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch system details; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch system details; status: %v", status)
+	}
+
+	system := &System{}
+	systemraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(systemraw, &system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch system details; status: %v; %s", status, err.Error())
+	}
+
+	return system, nil
+}
+
+// GetSystemDetails is the package-level variant of Service.GetSystemDetails, using the default service configuration
+func GetSystemDetails(token, workgroupID, systemID string, params map[string]interface{}) (*System, error) {
+	return InitBaselineService(token).GetSystemDetails(workgroupID, systemID, params)
+}
+
+// UpdateSystem updates the configuration of the given system of record
+func (s *Service) UpdateSystem(workgroupID, systemID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("workgroups/%s/systems/%s", workgroupID, systemID)
+	status, resp, err := s.Put(uri, params)
+	if err != nil {
+		return fmt.Errorf("failed to update system; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("failed to update system", status, resp)
+	}
+
+	return nil
+}
+
+// UpdateSystem is the package-level variant of Service.UpdateSystem, using the default service configuration
+func UpdateSystem(token, workgroupID, systemID string, params map[string]interface{}) error {
+	return InitBaselineService(token).UpdateSystem(workgroupID, systemID, params)
+}
+
+// DeleteSystem disconnects the given system of record from the workgroup
+func (s *Service) DeleteSystem(workgroupID, systemID string) error {
+	uri := fmt.Sprintf("workgroups/%s/systems/%s", workgroupID, systemID)
+	return s.deleteResource(uri, "system")
+}
+
+// DeleteSystem is the package-level variant of Service.DeleteSystem, using the default service configuration
+func DeleteSystem(token, workgroupID, systemID string) error {
+	return InitBaselineService(token).DeleteSystem(workgroupID, systemID)
+}
+
+// TestSystemReachability verifies the local stack is able to connect and authenticate
+// to a system of record using the given configuration, without registering the system
+func (s *Service) TestSystemReachability(params map[string]interface{}) error {
+	status, resp, err := s.Post("systems/reachability", params)
+	if err != nil {
+		return fmt.Errorf("failed to test system reachability; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return newError("system unreachable", status, resp)
+	}
+
+	return nil
+}
+
+// TestSystemReachability is the package-level variant of Service.TestSystemReachability, using the default service configuration
+func TestSystemReachability(token string, params map[string]interface{}) error {
+	return InitBaselineService(token).TestSystemReachability(params)
+}
+
+// ListSystemSchemas introspects the business object schemas exposed by the given system of record
+func (s *Service) ListSystemSchemas(workgroupID, systemID string, params map[string]interface{}) ([]*SystemSchema, error) {
+	uri := fmt.Sprintf("workgroups/%s/systems/%s/schemas", workgroupID, systemID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list system schemas; status: %v", status)
+	}
+
+	schemas, err := api.DecodeList[SystemSchema](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list system schemas; status: %v; %s", status, err.Error())
+	}
+
+	return schemas, nil
+}
+
+// ListSystemSchemas is the package-level variant of Service.ListSystemSchemas, using the default service configuration
+func ListSystemSchemas(token, workgroupID, systemID string, params map[string]interface{}) ([]*SystemSchema, error) {
+	return InitBaselineService(token).ListSystemSchemas(workgroupID, systemID, params)
+}
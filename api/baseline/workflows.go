@@ -0,0 +1,95 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetWorkflowDetails retrieves details for the given workflow, including its worksteps
+func (s *Service) GetWorkflowDetails(workflowID string, params map[string]interface{}) (*Workflow, error) {
+	uri := fmt.Sprintf("workflows/%s", workflowID)
+	status, resp, err := s.Get(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow details; status: %v; %s", status, err.Error())
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch workflow details; status: %v", status)
+	}
+
+	workflow := &Workflow{}
+	workflowraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(workflowraw, &workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow details; status: %v; %s", status, err.Error())
+	}
+
+	return workflow, nil
+}
+
+// GetWorkflowDetails is the package-level variant of Service.GetWorkflowDetails, using the default service configuration
+func GetWorkflowDetails(token, workflowID string, params map[string]interface{}) (*Workflow, error) {
+	return InitBaselineService(token).GetWorkflowDetails(workflowID, params)
+}
+
+// CreateWorkflowVersion creates a new draft version of the given workflow; the version
+// inherits the worksteps of the given workflow and is deployed independently
+func (s *Service) CreateWorkflowVersion(workflowID, version string, params map[string]interface{}) (*Workflow, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	params["version"] = version
+
+	uri := fmt.Sprintf("workflows/%s/versions", workflowID)
+	status, resp, err := s.Post(uri, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow version; status: %v; %s", status, err.Error())
+	}
+
+	if status != 201 {
+		return nil, newError("failed to create workflow version", status, resp)
+	}
+
+	workflow := &Workflow{}
+	workflowraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(workflowraw, &workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow version; status: %v; %s", status, err.Error())
+	}
+
+	return workflow, nil
+}
+
+// CreateWorkflowVersion is the package-level variant of Service.CreateWorkflowVersion, using the default service configuration
+func CreateWorkflowVersion(token, workflowID, version string, params map[string]interface{}) (*Workflow, error) {
+	return InitBaselineService(token).CreateWorkflowVersion(workflowID, version, params)
+}
+
+// DeployWorkflow validates and deploys the given draft workflow; when the workflow is not valid
+// for deployment, the returned *Error describes each problem with the workflow or its worksteps
+// and unwraps to ErrValidation
+func (s *Service) DeployWorkflow(workflowID string) (*Workflow, error) {
+	uri := fmt.Sprintf("workflows/%s/deploy", workflowID)
+	status, resp, err := s.Post(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy workflow; status: %v; %s", status, err.Error())
+	}
+
+	if status != 202 {
+		return nil, newError("failed to deploy workflow", status, resp)
+	}
+
+	workflow := &Workflow{}
+	workflowraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(workflowraw, &workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy workflow; status: %v; %s", status, err.Error())
+	}
+
+	return workflow, nil
+}
+
+// DeployWorkflow is the package-level variant of Service.DeployWorkflow, using the default service configuration
+func DeployWorkflow(token, workflowID string) (*Workflow, error) {
+	return InitBaselineService(token).DeployWorkflow(workflowID)
+}
@@ -0,0 +1,186 @@
+package c2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	uuid "github.com/kthomas/go.uuid"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+// ContainerStatusRunning is the status of a running container
+const ContainerStatusRunning = "running"
+
+// ContainerStatusTerminated is the status of a terminated container
+const ContainerStatusTerminated = "terminated"
+
+// Container instances represent a container deployed to cloud-agnostic infrastructure
+type Container struct {
+	api.Model
+
+	NodeID         *uuid.UUID `json:"node_id,omitempty"`
+	ApplicationID  *uuid.UUID `json:"application_id,omitempty"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+
+	Name     *string `json:"name,omitempty"`
+	Image    *string `json:"image,omitempty"`
+	Provider *string `json:"provider,omitempty"` // i.e., aws, azure, docker
+	Region   *string `json:"region,omitempty"`
+	Status   *string `json:"status,omitempty"`
+
+	ExternalID *string                `json:"external_id,omitempty"` // provider-specific task or container group id
+	Interface  *NetworkInterface      `json:"interface,omitempty"`
+	Config     map[string]interface{} `json:"config,omitempty"`
+}
+
+// Params returns the container params as params suitable for deploying a container
+func (p *ContainerParams) Params() map[string]interface{} {
+	params := map[string]interface{}{
+		"region": p.Region,
+	}
+
+	if p.ResourceGroupName != "" {
+		params["resource_group_name"] = p.ResourceGroupName
+	}
+	if p.Image != nil {
+		params["image"] = *p.Image
+	}
+	if p.VirtualNetworkID != nil {
+		params["virtual_network_id"] = *p.VirtualNetworkID
+	}
+	if p.ContainerGroupName != nil {
+		params["container_group_name"] = *p.ContainerGroupName
+	}
+	if p.ContainerName != nil {
+		params["container_name"] = *p.ContainerName
+	}
+	if p.CPU != nil {
+		params["cpu"] = *p.CPU
+	}
+	if p.Memory != nil {
+		params["memory"] = *p.Memory
+	}
+	if len(p.Entrypoint) > 0 {
+		params["entrypoint"] = p.Entrypoint
+	}
+	if len(p.SecurityGroupIds) > 0 {
+		params["security_group_ids"] = p.SecurityGroupIds
+	}
+	if len(p.SubnetIds) > 0 {
+		params["subnet_ids"] = p.SubnetIds
+	}
+	if p.Environment != nil {
+		params["environment"] = p.Environment
+	}
+	if p.Security != nil {
+		params["security"] = p.Security
+	}
+
+	return params
+}
+
+// DeployContainer deploys a new container for the given authorization scope
+func DeployContainer(token string, params map[string]interface{}) (*Container, error) {
+	status, resp, err := InitC2Service(token).Post("containers", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 && status != 202 {
+		return nil, fmt.Errorf("failed to deploy container; status: %v", status)
+	}
+
+	container := &Container{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy container; status: %v; %s", status, err.Error())
+	}
+
+	return container, nil
+}
+
+// DeployContainerWithParams deploys a new container using the given typed container params
+func DeployContainerWithParams(token string, containerParams *ContainerParams) (*Container, error) {
+	return DeployContainer(token, containerParams.Params())
+}
+
+// ListContainers lists containers for the given authorization scope
+func ListContainers(token string, params map[string]interface{}) ([]*Container, error) {
+	status, resp, err := InitC2Service(token).Get("containers", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list containers; status: %v", status)
+	}
+
+	containers, err := api.DecodeList[Container](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers; status: %v; %s", status, err.Error())
+	}
+
+	return containers, nil
+}
+
+// GetContainerDetails fetches details for the given container
+func GetContainerDetails(token, containerID string, params map[string]interface{}) (*Container, error) {
+	uri := fmt.Sprintf("containers/%s", containerID)
+	status, resp, err := InitC2Service(token).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch container details; status: %v", status)
+	}
+
+	container := &Container{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &container)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch container details; status: %v; %s", status, err.Error())
+	}
+
+	return container, nil
+}
+
+// GetContainerLogs fetches the logs for the given container
+func GetContainerLogs(token, containerID string, params map[string]interface{}) (*NodeLogsResponse, error) {
+	uri := fmt.Sprintf("containers/%s/logs", containerID)
+	status, resp, err := InitC2Service(token).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch container logs; status: %v", status)
+	}
+
+	logsResponse := &NodeLogsResponse{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &logsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch container logs; status: %v; %s", status, err.Error())
+	}
+
+	return logsResponse, nil
+}
+
+// TerminateContainer terminates the given container
+func TerminateContainer(token, containerID string) error {
+	uri := fmt.Sprintf("containers/%s", containerID)
+	status, _, err := InitC2Service(token).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 202 && status != 204 {
+		return fmt.Errorf("failed to terminate container; status: %v", status)
+	}
+
+	return nil
+}
@@ -27,7 +27,7 @@ func InitC2Service(token string) *Service {
 
 	path := defaultC2Path
 	if os.Getenv("C2_API_PATH") != "" {
-		host = os.Getenv("C2_API_PATH")
+		path = os.Getenv("C2_API_PATH")
 	}
 
 	scheme := defaultC2Scheme
@@ -57,12 +57,9 @@ func ListNodes(token string, params map[string]interface{}) ([]*Node, error) {
 		return nil, fmt.Errorf("failed to list nodes; status: %v", status)
 	}
 
-	nodes := make([]*Node, 0)
-	for _, item := range resp.([]interface{}) {
-		node := &Node{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &node)
-		nodes = append(nodes, node)
+	nodes, err := api.DecodeList[Node](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes; status: %v; %s", status, err.Error())
 	}
 
 	return nodes, nil
@@ -193,12 +190,9 @@ func ListLoadBalancers(token string, params map[string]interface{}) ([]*LoadBala
 		return nil, fmt.Errorf("failed to list load balancers; status: %v", status)
 	}
 
-	balancers := make([]*LoadBalancer, 0)
-	for _, item := range resp.([]interface{}) {
-		balancer := &LoadBalancer{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &balancer)
-		balancers = append(balancers, balancer)
+	balancers, err := api.DecodeList[LoadBalancer](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers; status: %v; %s", status, err.Error())
 	}
 
 	return balancers, nil
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultResponseCacheMaxEntries = 256
+
+// CachedResponse is a GET response retained by a ResponseCache so it can be revalidated using
+// a conditional request; the body is retained as received, i.e., compressed
+type CachedResponse struct {
+	Status       int
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified string
+}
+
+// ResponseCache stores GET responses for conditional requests; implementations must be safe
+// for concurrent use
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// memoryResponseCache is an in-memory, least-recently-used ResponseCache
+type memoryResponseCache struct {
+	entries    map[string]*list.Element
+	lru        *list.List
+	maxEntries int
+	mutex      sync.Mutex
+}
+
+type memoryResponseCacheEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryResponseCache returns an in-memory ResponseCache which retains at most the given
+// number of responses, evicting the least recently used; a default is used when maxEntries <= 0
+func NewMemoryResponseCache(maxEntries int) ResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultResponseCacheMaxEntries
+	}
+	return &memoryResponseCache{
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		maxEntries: maxEntries,
+	}
+}
+
+func (m *memoryResponseCache) Get(key string) (*CachedResponse, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.lru.MoveToFront(elem)
+	return elem.Value.(*memoryResponseCacheEntry).resp, true
+}
+
+func (m *memoryResponseCache) Set(key string, resp *CachedResponse) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryResponseCacheEntry).resp = resp
+		m.lru.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.lru.PushFront(&memoryResponseCacheEntry{key: key, resp: resp})
+	for m.lru.Len() > m.maxEntries {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryResponseCacheEntry).key)
+	}
+}
+
+// responseCacheKey returns the cache key for the given request; credentials are hashed into
+// the key so responses are never shared across tokens
+func responseCacheKey(req *http.Request) string {
+	digest := sha256.New()
+	digest.Write([]byte(strings.Join(req.Header["Authorization"], ",")))
+	digest.Write([]byte{0})
+	digest.Write([]byte(strings.Join(req.Header["Cookie"], ",")))
+	return req.URL.String() + "#" + hex.EncodeToString(digest.Sum(nil))
+}
+
+// doCached sends the given GET request conditionally using the ETag or Last-Modified of the
+// cached response, if any; a 304 response is replaced with the cached response
+func (c *Client) doCached(client *http.Client, req *http.Request) (*http.Response, error) {
+	key := responseCacheKey(req)
+	cached, hit := c.Cache.Get(key)
+	if hit {
+		if cached.ETag != "" {
+			req.Header["If-None-Match"] = []string{cached.ETag}
+		}
+		if cached.LastModified != "" {
+			req.Header["If-Modified-Since"] = []string{cached.LastModified}
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hit {
+		resp.Body.Close()
+		common.Log.Tracef("HTTP GET %s not modified; using cached response", req.URL.String())
+		return cached.response(req), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	maxResponseSize := c.maxResponseSize()
+	if maxResponseSize > 0 && resp.ContentLength > maxResponseSize {
+		return resp, nil
+	}
+
+	var reader io.Reader = resp.Body
+	if maxResponseSize > 0 {
+		reader = io.LimitReader(resp.Body, maxResponseSize+1)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	cached = &CachedResponse{
+		Status:       resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		ETag:         etag,
+		LastModified: lastModified,
+	}
+	if maxResponseSize <= 0 || int64(len(body)) <= maxResponseSize {
+		c.Cache.Set(key, cached)
+	}
+
+	return cached.response(req), nil
+}
+
+// response returns an http.Response replaying the cached response for the given request
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.Status),
+		StatusCode:    r.Status,
+		Header:        r.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -1,14 +1,18 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
+	"compress/flate"
 	"compress/gzip"
+	"compress/zlib"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -17,15 +21,27 @@ import (
 	"strings"
 	"time"
 
+	uuid "github.com/kthomas/go.uuid"
 	"github.com/provideplatform/provide-go/common"
+	"github.com/provideplatform/provide-go/version"
 	"github.com/vincent-petithory/dataurl"
 )
 
 const defaultContentType = "application/json"
 const defaultRequestTimeout = time.Second * 10
 
+// ContentEncodingDeflate is the deflate content-coding
+const ContentEncodingDeflate = "deflate"
+
+// ContentEncodingGzip is the gzip content-coding
+const ContentEncodingGzip = "gzip"
+
 var customRequestTimeout *time.Duration
 
+// RequestIDGenerator, when set, generates the X-Request-ID header of requests sent by clients
+// which do not configure their own generator; see NewRequestID
+var RequestIDGenerator func() string
+
 // Client is a generic base class for calling a REST API; when a token is configured on an
 // Client instance it will be provided as a bearer authorization header; when a username and
 // password are configured on an Client instance, they will be used for HTTP basic authorization
@@ -43,6 +59,67 @@ type Client struct {
 
 	Username *string
 	Password *string
+
+	// ContentEncoding, when set to gzip or deflate, compresses request bodies using the given encoding
+	ContentEncoding *string
+
+	// DisableCompression, when true, omits the Accept-Encoding header so responses are not compressed
+	DisableCompression bool
+
+	// Debug, when true, logs the full request and response, including headers and bodies;
+	// authorization headers, tokens and other sensitive values are redacted
+	Debug bool
+
+	// RedactedFields are additional JSON fields and query parameters whose values are redacted when Debug is enabled
+	RedactedFields []string
+
+	// Timeout, when set, overrides the default request timeout for requests sent by this Client
+	Timeout time.Duration
+
+	// MaxResponseSize, when set, overrides the default maximum size of a (decompressed) response
+	// body, in bytes; a negative value disables the limit. See common.MaxResponseSize
+	MaxResponseSize int64
+
+	// UserAgent, when set, is prepended to the default provide-go User-Agent, i.e., myapp/1.0
+	UserAgent *string
+
+	// RequestIDGenerator, when set, generates the X-Request-ID header of each request sent by
+	// this Client, overriding the package-level RequestIDGenerator
+	RequestIDGenerator func() string
+
+	// Cache, when set, retains GET responses which have an ETag or Last-Modified header so
+	// subsequent requests are sent conditionally; a 304 response is served from the cache.
+	// See NewMemoryResponseCache
+	Cache ResponseCache
+
+	// UseNumber decodes numbers in responses received by this Client as json.Number rather than
+	// float64, regardless of DefaultDecodeOptions; responses are decoded into generic values, so
+	// the remaining DecodeOptions only apply to typed decoding via DecodeModel
+	UseNumber bool
+}
+
+// NewRequestID returns a random (v4) uuid for use as an X-Request-ID; it is suitable for use
+// as a RequestIDGenerator
+func NewRequestID() string {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+func (c *Client) maxResponseSize() int64 {
+	if c.MaxResponseSize != 0 {
+		return c.MaxResponseSize
+	}
+	return common.MaxResponseSize()
+}
+
+func (c *Client) requestTimeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return requestTimeout()
 }
 
 func requestTimeout() time.Duration {
@@ -82,52 +159,61 @@ func (c *Client) parseResponse(resp *http.Response) (status int, response interf
 		return 0, nil, err
 	}
 
-	var reader io.ReadCloser
-	switch resp.Header.Get("Content-Encoding") {
-	case "gzip":
-		reader, err = gzip.NewReader(resp.Body)
-	default:
-		reader = resp.Body
+	maxResponseSize := c.maxResponseSize()
+	if maxResponseSize > 0 && resp.ContentLength > maxResponseSize {
+		common.Log.Warningf("rejected %d-byte HTTP %s response from %s; maximum response size is %d bytes", resp.ContentLength, resp.Request.Method, resp.Request.URL.String(), maxResponseSize)
+		return resp.StatusCode, nil, common.ErrResponseTooLarge
 	}
 
-	buf := new(bytes.Buffer)
-	if reader != nil {
-		defer reader.Close()
-
-		for {
-			buffer := make([]byte, 256)
-			n, err := reader.Read(buffer)
-			if n > 0 {
-				common.Log.Tracef("read %d bytes from HTTP response stream", n)
-				i, err := buf.Write(buffer[0:n])
-				if err != nil {
-					common.Log.Warningf("failed to write HTTP response to internal client buffer; %s", err.Error())
-					return resp.StatusCode, nil, err
-				} else {
-					common.Log.Tracef("wrote %d bytes from HTTP response to internal client buffer", i)
-				}
-			} else if err != nil {
-				if err != io.EOF {
-					common.Log.Warningf("failed to read HTTP response stream; %s", err.Error())
-					return resp.StatusCode, nil, err
-				}
-				break
-			}
+	reader, err := decompressingReader(resp)
+	if err != nil {
+		common.Log.Warningf("failed to initialize %s reader for HTTP %s response from %s; %s", resp.Header.Get("Content-Encoding"), resp.Request.Method, resp.Request.URL.String(), err.Error())
+		return resp.StatusCode, nil, err
+	}
+	defer reader.Close()
+
+	if maxResponseSize > 0 {
+		// the limit applies to the decompressed stream to guard against decompression bombs
+		reader = &limitedReadCloser{
+			Reader: common.LimitReader(reader, maxResponseSize),
+			Closer: reader,
 		}
 	}
 
-	if buf.Len() > 0 {
-		contentTypeParts := strings.Split(resp.Header.Get("Content-Type"), ";")
-		switch strings.ToLower(contentTypeParts[0]) {
-		case "application/json":
-			err = json.Unmarshal(buf.Bytes(), &response)
-			if err != nil {
-				err = fmt.Errorf("failed to unmarshal %v-byte HTTP %s response from %s; %s", len(buf.Bytes()), resp.Request.Method, resp.Request.URL.String(), err.Error())
-				return resp.StatusCode, nil, err
-			}
-		default:
-			// no-op
+	if c.Debug {
+		reader, err = c.debugResponse(resp, reader)
+		if err != nil {
+			common.Log.Warningf("failed to read HTTP response stream; %s", err.Error())
+			return resp.StatusCode, nil, err
+		}
+	}
+
+	contentTypeParts := strings.Split(resp.Header.Get("Content-Type"), ";")
+	switch strings.ToLower(contentTypeParts[0]) {
+	case "application/json":
+		// decode directly from the (possibly decompressing) response stream
+		// so large list responses are never fully buffered in memory twice
+		decoder := json.NewDecoder(reader)
+		if c.UseNumber || DefaultDecodeOptions.UseNumber {
+			decoder.UseNumber()
 		}
+		err = decoder.Decode(&response)
+		if err == io.EOF {
+			return resp.StatusCode, nil, nil
+		} else if errors.Is(err, common.ErrResponseTooLarge) {
+			common.Log.Warningf("HTTP %s response from %s exceeds maximum response size of %d bytes", resp.Request.Method, resp.Request.URL.String(), maxResponseSize)
+			return resp.StatusCode, nil, err
+		} else if err != nil {
+			err = fmt.Errorf("failed to unmarshal HTTP %s response from %s; %s", resp.Request.Method, resp.Request.URL.String(), err.Error())
+			return resp.StatusCode, nil, err
+		}
+	default:
+		n, err := io.Copy(ioutil.Discard, reader)
+		if err != nil {
+			common.Log.Warningf("failed to read HTTP response stream; %s", err.Error())
+			return resp.StatusCode, nil, err
+		}
+		common.Log.Tracef("discarded %d bytes from HTTP response stream", n)
 	}
 
 	return resp.StatusCode, response, nil
@@ -138,11 +224,13 @@ func (c *Client) sendRequest(
 	urlString,
 	contentType string,
 	params map[string]interface{},
+	opts ...RequestOption,
 ) (resp *http.Response, err error) {
 	return c.sendRequestWithTLSClientConfig(method, urlString, contentType, params,
 		&tls.Config{
 			InsecureSkipVerify: false,
 		},
+		opts...,
 	)
 }
 
@@ -152,13 +240,16 @@ func (c *Client) sendRequestWithTLSClientConfig(
 	contentType string,
 	params map[string]interface{},
 	tlsClientConfig *tls.Config,
+	opts ...RequestOption,
 ) (resp *http.Response, err error) {
+	options := newRequestOptions(opts)
+
 	client := &http.Client{
 		Transport: &http.Transport{
 			DisableKeepAlives: true,
 			TLSClientConfig:   tlsClientConfig,
 		},
-		Timeout: requestTimeout(),
+		Timeout: c.requestTimeout(),
 	}
 
 	mthd := strings.ToUpper(method)
@@ -177,33 +268,14 @@ func (c *Client) sendRequestWithTLSClientConfig(
 		}
 		reqURL.RawQuery = q.Encode()
 	}
+	options.applyQuery(reqURL)
 
-	headers := map[string][]string{
-		"Accept-Encoding": {"gzip, deflate"},
-		"Accept-Language": {"en-us"},
-		"Accept":          {"application/json"},
-	}
-
-	if c.Token != nil {
-		headers["Authorization"] = []string{fmt.Sprintf("bearer %s", *c.Token)}
-	} else if c.Username != nil && c.Password != nil {
-		headers["Authorization"] = []string{buildBasicAuthorizationHeader(*c.Username, *c.Password)}
-	}
-
-	if c.Cookie != nil {
-		headers["Cookie"] = []string{*c.Cookie}
-	}
-
-	if c.Headers != nil {
-		for name, val := range c.Headers {
-			headers[name] = val
-		}
-	}
+	headers := c.requestHeaders()
 
 	var req *http.Request
+	var payload []byte
 
 	if mthd == "POST" || mthd == "PUT" || mthd == "PATCH" {
-		var payload []byte
 		switch contentType {
 		case "application/json":
 			payload, err = json.Marshal(params)
@@ -253,7 +325,17 @@ func (c *Client) sendRequestWithTLSClientConfig(
 			common.Log.Warningf("attempted HTTP %s request with unsupported content type: %s; unable to marshal request body", mthd, contentType)
 		}
 
-		req, _ = http.NewRequest(method, urlString, bytes.NewReader(payload))
+		body := payload
+		if c.ContentEncoding != nil && len(payload) > 0 {
+			body, err = compress(*c.ContentEncoding, payload)
+			if err != nil {
+				common.Log.Warningf("failed to compress payload for HTTP %s request: %s; %s", method, urlString, err.Error())
+				return nil, err
+			}
+			headers["Content-Encoding"] = []string{*c.ContentEncoding}
+		}
+
+		req, _ = http.NewRequest(method, reqURL.String(), bytes.NewReader(body))
 		headers["Content-Type"] = []string{contentType}
 	} else {
 		req = &http.Request{
@@ -262,21 +344,78 @@ func (c *Client) sendRequestWithTLSClientConfig(
 		}
 	}
 
+	options.applyHeaders(headers)
 	req.Header = headers
+
+	if c.Debug {
+		c.debugRequest(req, payload)
+	}
+
+	if mthd == "GET" && c.Cache != nil {
+		return c.doCached(client, req)
+	}
+
 	return client.Do(req)
 }
 
+// requestHeaders returns the default headers for requests sent by the client,
+// including authorization, cookie and any custom headers configured on the client
+func (c *Client) requestHeaders() map[string][]string {
+	headers := map[string][]string{
+		"Accept-Language":  {"en-us"},
+		"Accept":           {"application/json"},
+		"User-Agent":       {version.UserAgent()},
+		"X-Client-Version": {version.String()},
+	}
+
+	if c.UserAgent != nil && *c.UserAgent != "" {
+		headers["User-Agent"] = []string{fmt.Sprintf("%s %s", *c.UserAgent, version.UserAgent())}
+	}
+
+	generator := c.RequestIDGenerator
+	if generator == nil {
+		generator = RequestIDGenerator
+	}
+	if generator != nil {
+		if requestID := generator(); requestID != "" {
+			headers["X-Request-ID"] = []string{requestID}
+		}
+	}
+
+	if !c.DisableCompression {
+		headers["Accept-Encoding"] = []string{fmt.Sprintf("%s, %s", ContentEncodingGzip, ContentEncodingDeflate)}
+	}
+
+	if c.Token != nil {
+		headers["Authorization"] = []string{fmt.Sprintf("bearer %s", *c.Token)}
+	} else if c.Username != nil && c.Password != nil {
+		headers["Authorization"] = []string{buildBasicAuthorizationHeader(*c.Username, *c.Password)}
+	}
+
+	if c.Cookie != nil {
+		headers["Cookie"] = []string{*c.Cookie}
+	}
+
+	if c.Headers != nil {
+		for name, val := range c.Headers {
+			headers[name] = val
+		}
+	}
+
+	return headers
+}
+
 // Get constructs and synchronously sends an API GET request
-func (c *Client) Get(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+func (c *Client) Get(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("GET", url, defaultContentType, params)
+	resp, err := c.sendRequest("GET", url, defaultContentType, params, opts...)
 	return c.parseResponse(resp)
 }
 
 // Head constructs and synchronously sends an API HEAD request; returns the headers
-func (c *Client) Head(uri string, params map[string]interface{}) (status int, response map[string][]string, err error) {
+func (c *Client) Head(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response map[string][]string, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("HEAD", url, defaultContentType, params)
+	resp, err := c.sendRequest("HEAD", url, defaultContentType, params, opts...)
 	if err != nil {
 		return resp.StatusCode, nil, err
 	}
@@ -284,93 +423,93 @@ func (c *Client) Head(uri string, params map[string]interface{}) (status int, re
 }
 
 // GetWithTLSClientConfig constructs and synchronously sends an API GET request
-func (c *Client) GetWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) GetWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("GET", url, defaultContentType, params, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("GET", url, defaultContentType, params, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
 // Patch constructs and synchronously sends an API PATCH request
-func (c *Client) Patch(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+func (c *Client) Patch(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("PATCH", url, defaultContentType, params)
+	resp, err := c.sendRequest("PATCH", url, defaultContentType, params, opts...)
 	return c.parseResponse(resp)
 }
 
 // PatchWithTLSClientConfig constructs and synchronously sends an API PATCH request
-func (c *Client) PatchWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) PatchWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("PATCH", url, defaultContentType, params, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("PATCH", url, defaultContentType, params, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
 // Post constructs and synchronously sends an API POST request
-func (c *Client) Post(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+func (c *Client) Post(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("POST", url, defaultContentType, params)
+	resp, err := c.sendRequest("POST", url, defaultContentType, params, opts...)
 	return c.parseResponse(resp)
 }
 
 // PostWithTLSClientConfig constructs and synchronously sends an API POST request
-func (c *Client) PostWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) PostWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("POST", url, defaultContentType, params, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("POST", url, defaultContentType, params, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
 // PostWWWFormURLEncoded constructs and synchronously sends an API POST request using application/x-www-form-urlencoded as the content-type
-func (c *Client) PostWWWFormURLEncoded(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+func (c *Client) PostWWWFormURLEncoded(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("POST", url, "application/x-www-form-urlencoded", params)
+	resp, err := c.sendRequest("POST", url, "application/x-www-form-urlencoded", params, opts...)
 	return c.parseResponse(resp)
 }
 
 // PostWWWFormURLEncodedWithTLSClientConfig constructs and synchronously sends an API POST request using application/x-www-form-urlencoded as the content-type
-func (c *Client) PostWWWFormURLEncodedWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) PostWWWFormURLEncodedWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("POST", url, "application/x-www-form-urlencoded", params, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("POST", url, "application/x-www-form-urlencoded", params, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
 // PostMultipartFormData constructs and synchronously sends an API POST request using multipart/form-data as the content-type
-func (c *Client) PostMultipartFormData(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+func (c *Client) PostMultipartFormData(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("POST", url, "multipart/form-data", params)
+	resp, err := c.sendRequest("POST", url, "multipart/form-data", params, opts...)
 	return c.parseResponse(resp)
 }
 
 // PostMultipartFormDataWithTLSClientConfig constructs and synchronously sends an API POST request using multipart/form-data as the content-type
-func (c *Client) PostMultipartFormDataWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) PostMultipartFormDataWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("POST", url, "multipart/form-data", params, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("POST", url, "multipart/form-data", params, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
 // Put constructs and synchronously sends an API PUT request
-func (c *Client) Put(uri string, params map[string]interface{}) (status int, response interface{}, err error) {
+func (c *Client) Put(uri string, params map[string]interface{}, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("PUT", url, defaultContentType, params)
+	resp, err := c.sendRequest("PUT", url, defaultContentType, params, opts...)
 	return c.parseResponse(resp)
 }
 
 // PutWithTLSClientConfig constructs and synchronously sends an API PUT request
-func (c *Client) PutWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) PutWithTLSClientConfig(uri string, params map[string]interface{}, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("PUT", url, defaultContentType, params, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("PUT", url, defaultContentType, params, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
 // Delete constructs and synchronously sends an API DELETE request
-func (c *Client) Delete(uri string) (status int, response interface{}, err error) {
+func (c *Client) Delete(uri string, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequest("DELETE", url, defaultContentType, nil)
+	resp, err := c.sendRequest("DELETE", url, defaultContentType, nil, opts...)
 	return c.parseResponse(resp)
 }
 
 // DeleteWithTLSClientConfig constructs and synchronously sends an API DELETE request
-func (c *Client) DeleteWithTLSClientConfig(uri string, tlsClientConfig *tls.Config) (status int, response interface{}, err error) {
+func (c *Client) DeleteWithTLSClientConfig(uri string, tlsClientConfig *tls.Config, opts ...RequestOption) (status int, response interface{}, err error) {
 	url := c.buildURL(uri)
-	resp, err := c.sendRequestWithTLSClientConfig("DELETE", url, defaultContentType, nil, tlsClientConfig)
+	resp, err := c.sendRequestWithTLSClientConfig("DELETE", url, defaultContentType, nil, tlsClientConfig, opts...)
 	return c.parseResponse(resp)
 }
 
@@ -388,3 +527,73 @@ func buildBasicAuthorizationHeader(username, password string) string {
 	auth := fmt.Sprintf("%s:%s", username, password)
 	return fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(auth)))
 }
+
+// compress the given payload using the named content-coding
+func compress(encoding string, payload []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	var writer io.WriteCloser
+	var err error
+
+	switch strings.ToLower(encoding) {
+	case ContentEncodingGzip:
+		writer = gzip.NewWriter(buf)
+	case ContentEncodingDeflate:
+		writer = zlib.NewWriter(buf)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding: %s", encoding)
+	}
+
+	_, err = writer.Write(payload)
+	if err != nil {
+		writer.Close()
+		return nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	common.Log.Tracef("compressed %d-byte payload to %d bytes using %s content encoding", len(payload), buf.Len(), encoding)
+	return buf.Bytes(), nil
+}
+
+// limitedReadCloser closes the underlying reader of a size-limited reader
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// decompressingReader returns a reader which transparently decompresses
+// the response body in accordance with its Content-Encoding header
+func decompressingReader(resp *http.Response) (io.ReadCloser, error) {
+	if resp.Body == nil {
+		return ioutil.NopCloser(bytes.NewReader([]byte{})), nil
+	}
+
+	switch strings.ToLower(resp.Header.Get("Content-Encoding")) {
+	case ContentEncodingGzip:
+		reader, err := gzip.NewReader(resp.Body)
+		if err == io.EOF {
+			return ioutil.NopCloser(bytes.NewReader([]byte{})), nil
+		}
+		return reader, err
+	case ContentEncodingDeflate:
+		// RFC 7230 deflate is zlib-wrapped, but many servers send raw deflate;
+		// sniff the zlib header and fall back to raw deflate when it is absent
+		buffered := bufio.NewReader(resp.Body)
+		hdr, err := buffered.Peek(2)
+		if err == io.EOF || len(hdr) < 2 {
+			return ioutil.NopCloser(bytes.NewReader([]byte{})), nil
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr[0]&0x0f == 0x08 && (uint16(hdr[0])<<8|uint16(hdr[1]))%31 == 0 {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	default:
+		return ioutil.NopCloser(resp.Body), nil
+	}
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/provideplatform/provide-go/common"
+	"github.com/provideplatform/provide-go/version"
+)
+
+func TestCompressedRequestResponseRoundTrip(t *testing.T) {
+	for _, encoding := range []string{ContentEncodingGzip, ContentEncodingDeflate} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Encoding") != encoding {
+				t.Errorf("expected %s request content encoding; got %s", encoding, r.Header.Get("Content-Encoding"))
+			}
+
+			reader, err := decompressingReader(&http.Response{Header: r.Header, Body: r.Body})
+			if err != nil {
+				t.Errorf("failed to decompress %s request body; %s", encoding, err.Error())
+				return
+			}
+			body, _ := ioutil.ReadAll(reader)
+
+			payload, _ := compress(encoding, body)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Encoding", encoding)
+			w.WriteHeader(201)
+			w.Write(payload)
+		}))
+
+		srvURL, _ := url.Parse(srv.URL)
+		client := &Client{
+			Host:            srvURL.Host,
+			Path:            "api/v1",
+			Scheme:          srvURL.Scheme,
+			ContentEncoding: &encoding,
+		}
+
+		status, resp, err := client.Post("echo", map[string]interface{}{
+			"hello": "world",
+		})
+		srv.Close()
+
+		if err != nil {
+			t.Errorf("failed to post %s-encoded request; %s", encoding, err.Error())
+			continue
+		}
+
+		if status != 201 {
+			t.Errorf("expected 201 status; got %d", status)
+		}
+
+		if echo, ok := resp.(map[string]interface{}); !ok || echo["hello"] != "world" {
+			t.Errorf("failed to round-trip %s-encoded payload; got %v", encoding, resp)
+		}
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	encoding := ContentEncodingGzip
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a highly compressible payload which is small on the wire but large once decompressed
+		payload, _ := compress(encoding, []byte(`{"data":"`+strings.Repeat("a", 1024*1024)+`"}`))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", encoding)
+		w.Write(payload)
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	client := &Client{
+		Host:            srvURL.Host,
+		Scheme:          srvURL.Scheme,
+		MaxResponseSize: 64 * 1024,
+	}
+
+	_, _, err := client.Get("large", map[string]interface{}{})
+	if !errors.Is(err, common.ErrResponseTooLarge) {
+		t.Errorf("expected response too large error; got %v", err)
+	}
+
+	client.MaxResponseSize = -1
+	_, resp, err := client.Get("large", map[string]interface{}{})
+	if err != nil || resp == nil {
+		t.Errorf("failed to read response with response size limit disabled; %v", err)
+	}
+}
+
+func TestClientVersionHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("User-Agent"), "myapp/1.0 provide-go/") {
+			t.Errorf("expected user agent to include application and provide-go; got %s", r.Header.Get("User-Agent"))
+		}
+
+		if r.Header.Get("X-Client-Version") != version.String() {
+			t.Errorf("expected client version %s; got %s", version.String(), r.Header.Get("X-Client-Version"))
+		}
+
+		if r.Header.Get("X-Request-ID") != "req-1" {
+			t.Errorf("expected request id req-1; got %s", r.Header.Get("X-Request-ID"))
+		}
+
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	client := &Client{
+		Host:               srvURL.Host,
+		Scheme:             srvURL.Scheme,
+		UserAgent:          common.StringOrNil("myapp/1.0"),
+		RequestIDGenerator: func() string { return "req-1" },
+	}
+
+	_, _, err := client.Get("ping", nil)
+	if err != nil {
+		t.Errorf("failed to send request; %s", err.Error())
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant-ID") != "tenant" {
+			t.Errorf("expected tenant header; got %s", r.Header.Get("X-Tenant-ID"))
+		}
+
+		if r.Header.Get("Accept") != "application/vnd.preview+json" {
+			t.Errorf("expected accept header to be overridden; got %s", r.Header.Get("Accept"))
+		}
+
+		if r.URL.Query().Get("preview") != "true" {
+			t.Errorf("expected preview query parameter; got %s", r.URL.RawQuery)
+		}
+
+		if r.Header.Get("Cookie") != "session=abc; flag=on" {
+			t.Errorf("expected client and request cookies; got %s", r.Header.Get("Cookie"))
+		}
+
+		w.WriteHeader(204)
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	client := &Client{
+		Host:   srvURL.Host,
+		Scheme: srvURL.Scheme,
+		Cookie: common.StringOrNil("session=abc"),
+	}
+
+	_, _, err := client.Post("resources", map[string]interface{}{},
+		WithHeader("X-Tenant-ID", "tenant"),
+		WithHeader("Accept", "application/vnd.preview+json"),
+		WithQuery("preview", "true"),
+		WithCookie("flag", "on"),
+	)
+	if err != nil {
+		t.Errorf("failed to send request; %s", err.Error())
+	}
+}
+
+func TestResponseCacheConditionalRequests(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(304)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(200)
+		w.Write([]byte(`[{"id":"1"}]`))
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	cache := NewMemoryResponseCache(0)
+	client := &Client{
+		Host:   srvURL.Host,
+		Scheme: srvURL.Scheme,
+		Token:  common.StringOrNil("token"),
+		Cache:  cache,
+	}
+
+	for i := 0; i < 2; i++ {
+		status, resp, err := client.Get("resources", map[string]interface{}{})
+		if err != nil {
+			t.Fatalf("failed to send request %d; %s", i, err.Error())
+		}
+
+		if status != 200 {
+			t.Errorf("expected 200 status for request %d; got %d", i, status)
+		}
+
+		if items, ok := resp.([]interface{}); !ok || len(items) != 1 {
+			t.Errorf("expected cached list response for request %d; got %v", i, resp)
+		}
+	}
+
+	other := &Client{
+		Host:   srvURL.Host,
+		Scheme: srvURL.Scheme,
+		Token:  common.StringOrNil("other"),
+		Cache:  cache,
+	}
+	other.Get("resources", map[string]interface{}{})
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests; got %d", requests)
+	}
+
+	cached, _ := cache.Get(responseCacheKey(&http.Request{
+		URL:    &url.URL{Scheme: srvURL.Scheme, Host: srvURL.Host, Path: "/resources"},
+		Header: http.Header{"Authorization": {"bearer other"}},
+	}))
+	if cached == nil || cached.ETag != `"v1"` {
+		t.Errorf("expected response to be cached per token")
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	client := &Client{RedactedFields: []string{"Signature"}}
+	uri, _ := url.Parse("https://ident.provide.services/api/v1/users?access_token=abc&signature=def&page=2")
+
+	redacted := client.redactURL(uri)
+	for _, secret := range []string{"abc", "def"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("expected %s to be redacted from %s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, "page=2") {
+		t.Errorf("expected page param to be preserved in %s", redacted)
+	}
+	if uri.RawQuery != "access_token=abc&signature=def&page=2" {
+		t.Errorf("expected original URL to be unmodified; got %s", uri.String())
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedHeaders are never logged in the clear when Debug is enabled
+var defaultRedactedHeaders = map[string]bool{
+	"authorization":       true,
+	"cookie":              true,
+	"proxy-authorization": true,
+	"set-cookie":          true,
+	"x-api-key":           true,
+}
+
+// defaultRedactedFields are JSON fields and query parameters whose values are never logged in the clear when Debug is enabled
+var defaultRedactedFields = []string{
+	"access_token",
+	"client_secret",
+	"mnemonic",
+	"password",
+	"private_key",
+	"refresh_token",
+	"secret",
+	"seed",
+	"token",
+}
+
+func (c *Client) debugRequest(req *http.Request, payload []byte) {
+	common.Log.Debugf("HTTP %s request: %s\nheaders: %s\nbody: %s",
+		req.Method,
+		c.redactURL(req.URL),
+		redactHeaders(req.Header),
+		c.redactBody(payload),
+	)
+}
+
+// debugResponse logs the given response, returning a reader over the buffered body
+func (c *Client) debugResponse(resp *http.Response, reader io.ReadCloser) (io.ReadCloser, error) {
+	body, err := ioutil.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	uri := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		uri = c.redactURL(resp.Request.URL)
+	}
+
+	common.Log.Debugf("HTTP response: %s; status: %d\nheaders: %s\nbody: %s",
+		uri,
+		resp.StatusCode,
+		redactHeaders(resp.Header),
+		c.redactBody(body),
+	)
+
+	return ioutil.NopCloser(bytes.NewReader(body)), nil
+}
+
+func redactHeaders(header http.Header) string {
+	redacted := map[string][]string{}
+	for name, vals := range header {
+		if defaultRedactedHeaders[strings.ToLower(name)] {
+			redacted[name] = []string{redactedValue}
+			continue
+		}
+		redacted[name] = vals
+	}
+
+	raw, _ := json.Marshal(redacted)
+	return string(raw)
+}
+
+// redactBody returns the given body with sensitive JSON fields redacted; bodies which
+// are not JSON are omitted entirely, as their contents cannot be safely redacted
+func (c *Client) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var val interface{}
+	err := json.Unmarshal(body, &val)
+	if err != nil {
+		return "[non-JSON body omitted]"
+	}
+
+	raw, _ := json.Marshal(redactValue(val, c.redactedFields()))
+	return string(raw)
+}
+
+// redactURL returns the given URL with the values of sensitive query parameters redacted
+func (c *Client) redactURL(uri *url.URL) string {
+	if uri.RawQuery == "" {
+		return uri.String()
+	}
+
+	fields := c.redactedFields()
+	query := uri.Query()
+	for key := range query {
+		if fields[strings.ToLower(key)] {
+			query[key] = []string{redactedValue}
+		}
+	}
+
+	redacted := *uri
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+func (c *Client) redactedFields() map[string]bool {
+	fields := map[string]bool{}
+	for _, field := range defaultRedactedFields {
+		fields[field] = true
+	}
+	for _, field := range c.RedactedFields {
+		fields[strings.ToLower(field)] = true
+	}
+	return fields
+}
+
+func redactValue(val interface{}, fields map[string]bool) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if fields[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(child, fields)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i], fields)
+		}
+		return v
+	}
+	return val
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeOptions control how JSON responses are decoded
+type DecodeOptions struct {
+	// UseNumber decodes numbers as json.Number rather than float64, so big integers
+	// (i.e., wei values and block numbers) do not lose precision
+	UseNumber bool
+
+	// DisallowUnknownFields causes DecodeModel to return an error when a response contains
+	// attributes which are not modeled, i.e., to detect API drift early
+	DisallowUnknownFields bool
+}
+
+// DefaultDecodeOptions apply to typed decoding via DecodeModel, Decode and DecodeList; UseNumber
+// additionally applies to all responses received by any Client (see Client.UseNumber)
+var DefaultDecodeOptions = DecodeOptions{}
+
+// newDecoder returns a json decoder for the given input configured using the given options
+func (o *DecodeOptions) newDecoder(raw []byte) *json.Decoder {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if o.UseNumber {
+		decoder.UseNumber()
+	}
+	if o.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	return decoder
+}
+
+// rawModel is implemented by models which embed Model
+type rawModel interface {
+	Raw() map[string]interface{}
+	SetRaw(map[string]interface{})
+}
+
+// DecodeModel decodes the given API response into the given model; when the model embeds
+// Model, the raw response attributes are retained so they can be preserved by UpdateParams.
+// The response is decoded using DefaultDecodeOptions
+func DecodeModel(resp interface{}, v interface{}) error {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	opts := DefaultDecodeOptions
+	err = opts.newDecoder(raw).Decode(v)
+	if err != nil {
+		return err
+	}
+
+	if m, ok := v.(rawModel); ok {
+		attrs := map[string]interface{}{}
+		opts.DisallowUnknownFields = false
+		if opts.newDecoder(raw).Decode(&attrs) == nil {
+			m.SetRaw(attrs)
+		}
+	}
+
+	return nil
+}
+
+// Decode decodes the given API response into a new T; see DecodeModel
+func Decode[T any](resp interface{}) (*T, error) {
+	v := new(T)
+	err := DecodeModel(resp, v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecodeList decodes the given API list response into a slice of new T; an error is returned
+// if the response is not a list or any item fails to decode
+func DecodeList[T any](resp interface{}) ([]*T, error) {
+	if resp == nil {
+		return make([]*T, 0), nil
+	}
+
+	items, ok := resp.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to decode list; unexpected response type: %T", resp)
+	}
+
+	list := make([]*T, 0, len(items))
+	for i, item := range items {
+		v, err := Decode[T](item)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode list item at index %d; %s", i, err.Error())
+		}
+		list = append(list, v)
+	}
+
+	return list, nil
+}
+
+// UpdateParams returns params for updating the given model; modeled attributes are
+// overlaid onto the raw attributes returned by the API, so server-side attributes
+// which are not modeled survive the round trip
+func UpdateParams(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	modeled := map[string]interface{}{}
+	err = json.Unmarshal(raw, &modeled)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]interface{}{}
+	if m, ok := v.(rawModel); ok {
+		for key, val := range m.Raw() {
+			params[key] = val
+		}
+	}
+	for key, val := range modeled {
+		params[key] = val
+	}
+
+	delete(params, "errors")
+	return params, nil
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+type testModel struct {
+	Model
+	Name *string `json:"name"`
+}
+
+func TestDecodeModelPreservesUnknownAttributes(t *testing.T) {
+	resp := map[string]interface{}{
+		"id":         "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"name":       "original",
+		"updated_at": "2021-01-02T00:00:00Z",
+		"server_attr": map[string]interface{}{
+			"nested": true,
+		},
+	}
+
+	m := &testModel{}
+	err := DecodeModel(resp, m)
+	if err != nil {
+		t.Fatalf("failed to decode model; %s", err.Error())
+	}
+
+	if m.Raw()["server_attr"] == nil {
+		t.Errorf("expected raw attributes to be retained")
+	}
+	if m.LastModifiedAt() != *m.UpdatedAt {
+		t.Errorf("expected last modified at to be updated at")
+	}
+	if m.Deleted() {
+		t.Errorf("expected model not to be deleted")
+	}
+
+	name := "updated"
+	m.Name = &name
+	params, err := UpdateParams(m)
+	if err != nil {
+		t.Fatalf("failed to build update params; %s", err.Error())
+	}
+
+	if params["name"] != "updated" {
+		t.Errorf("expected modeled attribute to be updated; got %v", params["name"])
+	}
+	if params["server_attr"] == nil {
+		t.Errorf("expected unknown attribute to survive round trip")
+	}
+	if _, ok := params["errors"]; ok {
+		t.Errorf("expected errors to be omitted from update params")
+	}
+}
+
+func TestErrorsMessages(t *testing.T) {
+	msg := "not found"
+	errs := Errors{{Message: &msg}, nil, {}}
+	if errs.Error() != "not found" {
+		t.Errorf("unexpected errors message: %s", errs.Error())
+	}
+}
+
+func TestDecodeList(t *testing.T) {
+	resp := []interface{}{
+		map[string]interface{}{"id": "6ba7b810-9dad-11d1-80b4-00c04fd430c8", "name": "first"},
+		map[string]interface{}{"name": "second", "extra": 1},
+	}
+
+	list, err := DecodeList[testModel](resp)
+	if err != nil {
+		t.Fatalf("failed to decode list; %s", err.Error())
+	}
+
+	if len(list) != 2 || *list[0].Name != "first" || *list[1].Name != "second" {
+		t.Errorf("unexpected decoded list")
+	}
+	if list[1].Raw()["extra"] == nil {
+		t.Errorf("expected raw attributes to be retained for list items")
+	}
+
+	_, err = DecodeList[testModel]([]interface{}{map[string]interface{}{"name": 1}})
+	if err == nil {
+		t.Errorf("expected error decoding invalid list item")
+	}
+
+	_, err = DecodeList[testModel](map[string]interface{}{})
+	if err == nil {
+		t.Errorf("expected error decoding non-list response")
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	defer func(opts DecodeOptions) { DefaultDecodeOptions = opts }(DefaultDecodeOptions)
+
+	type balance struct {
+		Model
+		Wei *big.Int `json:"wei"`
+	}
+
+	var resp interface{}
+	opts := &DecodeOptions{UseNumber: true}
+	err := opts.newDecoder([]byte(`{"wei":123456789012345678901234567890,"extra":true}`)).Decode(&resp)
+	if err != nil {
+		t.Fatalf("failed to decode response; %s", err.Error())
+	}
+
+	b := &balance{}
+	err = DecodeModel(resp, b)
+	if err != nil {
+		t.Fatalf("failed to decode model; %s", err.Error())
+	}
+	if b.Wei.String() != "123456789012345678901234567890" {
+		t.Errorf("expected big integer precision to be preserved; got %s", b.Wei.String())
+	}
+
+	DefaultDecodeOptions.DisallowUnknownFields = true
+	err = DecodeModel(resp, &balance{})
+	if err == nil {
+		t.Errorf("expected error decoding unknown field in strict mode")
+	}
+}
+
+func TestModelOmitsZeroID(t *testing.T) {
+	raw, err := json.Marshal(&Model{})
+	if err != nil {
+		t.Fatalf("failed to marshal model; %s", err.Error())
+	}
+
+	attrs := map[string]interface{}{}
+	json.Unmarshal(raw, &attrs)
+	if _, ok := attrs["id"]; ok {
+		t.Errorf("expected zero id to be omitted; got %s", string(raw))
+	}
+}
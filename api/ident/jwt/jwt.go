@@ -0,0 +1,282 @@
+// Package jwt verifies ident-issued JWTs locally using the keys published by ident,
+// allowing resource servers to authorize requests without calling ident on every request
+package jwt
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultApplicationClaimsKey = "prvd"
+const defaultKeysCacheTTL = time.Hour
+const defaultLeeway = time.Second * 30
+const minimumKeysRefreshInterval = time.Minute
+
+// Claims are the verified claims of an ident-issued JWT
+type Claims struct {
+	Audience    []string
+	ExpiresAt   *time.Time
+	ID          *string
+	IssuedAt    *time.Time
+	Issuer      *string
+	NotBefore   *time.Time
+	Permissions ident.Permission
+	Subject     *string
+
+	// Raw contains all claims, including application-specific claims
+	Raw map[string]interface{}
+}
+
+// Capabilities returns the names of the permissions granted by the claims
+func (c *Claims) Capabilities() []string {
+	return c.Permissions.Names()
+}
+
+// Verifier verifies JWT signatures using ident's published JWKs, which are fetched
+// on demand and cached; registered claims are validated against the configuration.
+// The zero Verifier is usable; a Verifier must not be copied after first use.
+type Verifier struct {
+	// Audience, when set, must be present in the aud claim
+	Audience *string
+
+	// Issuer, when set, must match the iss claim
+	Issuer *string
+
+	// Leeway is the allowed clock skew when validating exp and nbf; NewVerifier defaults it to 30s
+	Leeway time.Duration
+
+	// CacheTTL is the duration after which cached keys are refetched; defaults to one hour when unset
+	CacheTTL time.Duration
+
+	// FetchKeys resolves the published JWKs; defaults to ident.GetJWKs
+	FetchKeys func() ([]*ident.JSONWebKey, error)
+
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	mutex     sync.Mutex
+}
+
+// NewVerifier initializes a Verifier for the given audience and issuer; either may be nil
+func NewVerifier(audience, issuer *string) *Verifier {
+	return &Verifier{
+		Audience:  audience,
+		Issuer:    issuer,
+		Leeway:    defaultLeeway,
+		CacheTTL:  defaultKeysCacheTTL,
+		FetchKeys: ident.GetJWKs,
+		keys:      map[string]*rsa.PublicKey{},
+	}
+}
+
+// Verify the signature and registered claims of the given JWT, returning the verified claims
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	mapClaims := jwtgo.MapClaims{}
+	parser := &jwtgo.Parser{SkipClaimsValidation: true}
+	_, err := parser.ParseWithClaims(token, mapClaims, v.keyfunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify JWT; %s", err.Error())
+	}
+
+	claims := parseClaims(mapClaims)
+	err = v.validate(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) keyfunc(token *jwtgo.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwtgo.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected JWT signing alg: %s", token.Method.Alg())
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	return v.resolveKey(kid)
+}
+
+func (v *Verifier) resolveKey(kid string) (*rsa.PublicKey, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	ttl := v.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultKeysCacheTTL
+	}
+
+	stale := time.Since(v.fetchedAt) > ttl
+	key := v.lookupKey(kid)
+	if key == nil || stale {
+		// unknown kids trigger a refetch to support key rotation, but no more than once per interval
+		if stale || time.Since(v.fetchedAt) > minimumKeysRefreshInterval {
+			err := v.refreshKeys()
+			if err != nil && key == nil {
+				return nil, err
+			}
+			if err == nil {
+				key = v.lookupKey(kid)
+			}
+		}
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("failed to resolve JWT verification key; kid: %s", kid)
+	}
+
+	return key, nil
+}
+
+func (v *Verifier) lookupKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key
+		}
+	}
+	return v.keys[kid]
+}
+
+func (v *Verifier) refreshKeys() error {
+	fetchKeys := v.FetchKeys
+	if fetchKeys == nil {
+		fetchKeys = ident.GetJWKs
+	}
+
+	jwks, err := fetchKeys()
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKs; %s", err.Error())
+	}
+
+	keys := map[string]*rsa.PublicKey{}
+	for _, jwk := range jwks {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			common.Log.Debugf("skipping unsupported JWK %s; %s", jwk.Kid, err.Error())
+			continue
+		}
+		keys[jwk.Kid] = key
+		if jwk.Fingerprint != "" {
+			keys[jwk.Fingerprint] = key
+		}
+	}
+
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	common.Log.Debugf("cached %d JWKs", len(jwks))
+	return nil
+}
+
+func (v *Verifier) validate(claims *Claims) error {
+	now := time.Now()
+
+	if claims.ExpiresAt != nil && now.After(claims.ExpiresAt.Add(v.Leeway)) {
+		return errors.New("failed to verify JWT; token is expired")
+	}
+
+	if claims.NotBefore != nil && now.Add(v.Leeway).Before(*claims.NotBefore) {
+		return errors.New("failed to verify JWT; token is not yet valid")
+	}
+
+	if v.Issuer != nil && (claims.Issuer == nil || *claims.Issuer != *v.Issuer) {
+		return fmt.Errorf("failed to verify JWT; invalid issuer")
+	}
+
+	if v.Audience != nil {
+		valid := false
+		for _, aud := range claims.Audience {
+			if aud == *v.Audience {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("failed to verify JWT; invalid audience")
+		}
+	}
+
+	return nil
+}
+
+func parseClaims(mapClaims jwtgo.MapClaims) *Claims {
+	claims := &Claims{
+		Audience: make([]string, 0),
+		Raw:      mapClaims,
+	}
+
+	switch aud := mapClaims["aud"].(type) {
+	case string:
+		claims.Audience = append(claims.Audience, aud)
+	case []interface{}:
+		for _, item := range aud {
+			if str, ok := item.(string); ok {
+				claims.Audience = append(claims.Audience, str)
+			}
+		}
+	}
+
+	claims.ExpiresAt = timeClaim(mapClaims, "exp")
+	claims.IssuedAt = timeClaim(mapClaims, "iat")
+	claims.NotBefore = timeClaim(mapClaims, "nbf")
+
+	if jti, ok := mapClaims["jti"].(string); ok {
+		claims.ID = common.StringOrNil(jti)
+	}
+
+	if iss, ok := mapClaims["iss"].(string); ok {
+		claims.Issuer = common.StringOrNil(iss)
+	}
+
+	if sub, ok := mapClaims["sub"].(string); ok {
+		claims.Subject = common.StringOrNil(sub)
+	}
+
+	if prvd, ok := mapClaims[defaultApplicationClaimsKey].(map[string]interface{}); ok {
+		if permissions, ok := prvd["permissions"].(float64); ok {
+			claims.Permissions = ident.Permission(uint32(permissions))
+		}
+	}
+
+	return claims
+}
+
+func timeClaim(mapClaims jwtgo.MapClaims, name string) *time.Time {
+	if val, ok := mapClaims[name].(float64); ok {
+		t := time.Unix(int64(val), 0)
+		return &t
+	}
+	return nil
+}
+
+func parseJWK(jwk *ident.JSONWebKey) (*rsa.PublicKey, error) {
+	if jwk.PublicKey != "" && strings.Contains(jwk.PublicKey, "BEGIN") {
+		return jwtgo.ParseRSAPublicKeyFromPEM([]byte(jwk.PublicKey))
+	}
+
+	if jwk.N == "" || jwk.E == "" {
+		return nil, errors.New("no RSA modulus or exponent present")
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.N, "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode modulus; %s", err.Error())
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.E, "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exponent; %s", err.Error())
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
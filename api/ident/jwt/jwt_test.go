@@ -0,0 +1,113 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/common"
+)
+
+func TestVerifyWithFetchedJWKs(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Errorf("failed to generate RSA key; %s", err.Error())
+		return
+	}
+
+	verifier := NewVerifier(common.StringOrNil("https://provide.services/api/v1"), common.StringOrNil("https://ident.provide.services"))
+	verifier.FetchKeys = func() ([]*ident.JSONWebKey, error) {
+		return []*ident.JSONWebKey{
+			{
+				Kid: "test",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		}, nil
+	}
+
+	sign := func(claims jwtgo.MapClaims) string {
+		token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		signed, _ := token.SignedString(key)
+		return signed
+	}
+
+	claims, err := verifier.Verify(sign(jwtgo.MapClaims{
+		"aud": "https://provide.services/api/v1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iss": "https://ident.provide.services",
+		"sub": "user:test",
+		"prvd": map[string]interface{}{
+			"permissions": uint32(ident.PermissionAuthenticate | ident.PermissionReadResources),
+		},
+	}))
+	if err != nil {
+		t.Errorf("failed to verify JWT; %s", err.Error())
+		return
+	}
+
+	if len(claims.Capabilities()) != 2 {
+		t.Errorf("expected 2 capabilities; got %v", claims.Capabilities())
+	}
+
+	_, err = verifier.Verify(sign(jwtgo.MapClaims{
+		"aud": "https://provide.services/api/v1",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+		"iss": "https://ident.provide.services",
+	}))
+	if err == nil {
+		t.Error("expected expired JWT to fail verification")
+	}
+
+	_, err = verifier.Verify(sign(jwtgo.MapClaims{
+		"aud": "https://example.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iss": "https://ident.provide.services",
+	}))
+	if err == nil {
+		t.Error("expected JWT with invalid audience to fail verification")
+	}
+}
+
+func TestVerifierLiteralCachesKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key; %s", err.Error())
+	}
+
+	fetches := 0
+	verifier := &Verifier{
+		FetchKeys: func() ([]*ident.JSONWebKey, error) {
+			fetches++
+			return []*ident.JSONWebKey{
+				{
+					Kid: "test",
+					N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			}, nil
+		},
+	}
+
+	token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, jwtgo.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "test"
+	signed, _ := token.SignedString(key)
+
+	for i := 0; i < 2; i++ {
+		if _, err := verifier.Verify(signed); err != nil {
+			t.Fatalf("failed to verify JWT using Verifier literal; %s", err.Error())
+		}
+	}
+
+	if fetches != 1 {
+		t.Errorf("expected keys to be fetched once and cached; fetched %d times", fetches)
+	}
+}
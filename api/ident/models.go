@@ -1,19 +1,19 @@
 package ident
 
 import (
+	"strconv"
 	"time"
 
-	uuid "github.com/kthomas/go.uuid"
-
 	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
 )
 
 // Application model which is initially owned by the user who created it
 type Application struct {
 	api.Model
 
-	NetworkID   uuid.UUID              `json:"network_id,omitempty"`
-	UserID      uuid.UUID              `json:"user_id,omitempty"` // this is the user that initially created the app
+	NetworkID   *common.ID             `json:"network_id,omitempty"`
+	UserID      *common.ID             `json:"user_id,omitempty"` // this is the user that initially created the app
 	Name        *string                `json:"name"`
 	Description *string                `json:"description"`
 	Status      *string                `json:"status,omitempty"` // this is for enrichment purposes only
@@ -22,27 +22,167 @@ type Application struct {
 	Hidden      bool                   `json:"hidden"`
 }
 
+// AuditEvent is an immutable record of an action taken by an actor on a resource
+type AuditEvent struct {
+	api.Model
+
+	ActorID        *common.ID             `json:"actor_id,omitempty"`
+	ActorType      *string                `json:"actor_type,omitempty"` // i.e., user, application, organization, token
+	ApplicationID  *common.ID             `json:"application_id,omitempty"`
+	OrganizationID *common.ID             `json:"organization_id,omitempty"`
+	Action         string                 `json:"action"` // i.e., token.create, user.update
+	ResourceID     *string                `json:"resource_id,omitempty"`
+	ResourceType   *string                `json:"resource_type,omitempty"`
+	Outcome        *string                `json:"outcome,omitempty"` // i.e., success, failure
+	IPAddress      *string                `json:"ip_address,omitempty"`
+	UserAgent      *string                `json:"user_agent,omitempty"`
+	OccurredAt     *time.Time             `json:"occurred_at,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// AuditEventQuery is a typed query for filtering and paginating audit events
+type AuditEventQuery struct {
+	ActorID        *string
+	ActorType      *string
+	ApplicationID  *string
+	OrganizationID *string
+	Action         *string
+	ResourceID     *string
+	ResourceType   *string
+
+	OccurredAfter  *time.Time
+	OccurredBefore *time.Time
+
+	Page uint64
+	RPP  uint64 // results per page
+}
+
+// Params returns the query parameters for the audit event query
+func (q *AuditEventQuery) Params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if q == nil {
+		return params
+	}
+
+	if q.ActorID != nil {
+		params["actor_id"] = *q.ActorID
+	}
+	if q.ActorType != nil {
+		params["actor_type"] = *q.ActorType
+	}
+	if q.ApplicationID != nil {
+		params["application_id"] = *q.ApplicationID
+	}
+	if q.OrganizationID != nil {
+		params["organization_id"] = *q.OrganizationID
+	}
+	if q.Action != nil {
+		params["action"] = *q.Action
+	}
+	if q.ResourceID != nil {
+		params["resource_id"] = *q.ResourceID
+	}
+	if q.ResourceType != nil {
+		params["resource_type"] = *q.ResourceType
+	}
+	if q.OccurredAfter != nil {
+		params["occurred_after"] = q.OccurredAfter.Format(time.RFC3339)
+	}
+	if q.OccurredBefore != nil {
+		params["occurred_before"] = q.OccurredBefore.Format(time.RFC3339)
+	}
+	if q.Page > 0 {
+		params["page"] = strconv.FormatUint(q.Page, 10)
+	}
+	if q.RPP > 0 {
+		params["rpp"] = strconv.FormatUint(q.RPP, 10)
+	}
+
+	return params
+}
+
 // AuthenticationResponse is returned upon successful authentication of a user (i.e., by email address)
 type AuthenticationResponse struct {
 	User  *User  `json:"user"`
 	Token *Token `json:"token"`
+
+	// MFAChallenge is present in lieu of a token when multi-factor authentication is required
+	MFAChallenge *MFAChallenge `json:"mfa_challenge,omitempty"`
+}
+
+// MFAChallenge is returned by the first step of authentication when the user has enrolled in
+// multi-factor authentication; the challenge is completed using VerifyMFAChallenge
+type MFAChallenge struct {
+	Token     *string    `json:"token"`
+	Type      *string    `json:"type,omitempty"` // i.e., totp
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// MFAEnrollment is returned upon provisioning a TOTP secret for multi-factor authentication;
+// enrollment is completed by verifying a code generated using the secret
+type MFAEnrollment struct {
+	Secret          *string  `json:"secret"`
+	ProvisioningURI *string  `json:"provisioning_uri,omitempty"` // otpauth:// uri, suitable for rendering as a QR code
+	RecoveryCodes   []string `json:"recovery_codes,omitempty"`
+}
+
+// IdentityProvider is an external SAML or OIDC identity provider configured on an application
+// or organization to enable enterprise single sign-on
+type IdentityProvider struct {
+	api.Model
+
+	ApplicationID  *common.ID `json:"application_id,omitempty"`
+	OrganizationID *common.ID `json:"organization_id,omitempty"`
+	Name           *string    `json:"name"`
+	Type           *string    `json:"type"` // i.e., saml, oidc
+	Enabled        bool       `json:"enabled"`
+
+	// Domains are the email domains for which sign-in is routed to the identity provider
+	Domains []string `json:"domains,omitempty"`
+
+	// AttributeMapping maps identity provider claims or attributes to user fields, i.e., email, first_name
+	AttributeMapping map[string]string `json:"attribute_mapping,omitempty"`
+
+	// OIDC
+	Issuer       *string  `json:"issuer,omitempty"`
+	DiscoveryURL *string  `json:"discovery_url,omitempty"`
+	ClientID     *string  `json:"client_id,omitempty"`
+	ClientSecret *string  `json:"client_secret,omitempty"` // write-only
+	Scopes       []string `json:"scopes,omitempty"`
+
+	// SAML
+	EntityID    *string `json:"entity_id,omitempty"`
+	MetadataURL *string `json:"metadata_url,omitempty"`
+	SSOURL      *string `json:"sso_url,omitempty"`
+	Certificate *string `json:"certificate,omitempty"` // PEM-encoded x509 signing certificate
+
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// SSOLoginRequest is returned upon initiating single sign-on with an identity provider; the user
+// is redirected to the URL and returned to the redirect uri upon completion
+type SSOLoginRequest struct {
+	URL       *string    `json:"url"`
+	State     *string    `json:"state,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 // Invite model
 type Invite struct {
 	api.Model
 
-	ApplicationID    *uuid.UUID             `json:"application_id,omitempty"`
-	UserID           *uuid.UUID             `json:"user_id,omitempty"`
+	ApplicationID    *common.ID             `json:"application_id,omitempty"`
+	UserID           *common.ID             `json:"user_id,omitempty"`
 	FirstName        *string                `json:"first_name,omitempty"`
 	LastName         *string                `json:"last_name,omitempty"`
 	Email            *string                `json:"email,omitempty"`
-	InvitorID        *uuid.UUID             `json:"invitor_id,omitempty"`
+	InvitorID        *common.ID             `json:"invitor_id,omitempty"`
 	InvitorName      *string                `json:"invitor_name,omitempty"`
-	OrganizationID   *uuid.UUID             `json:"organization_id,omitempty"`
+	OrganizationID   *common.ID             `json:"organization_id,omitempty"`
 	OrganizationName *string                `json:"organization_name,omitempty"`
 	Permissions      uint32                 `json:"permissions,omitempty"`
 	Params           map[string]interface{} `json:"params,omitempty"`
+	ExpiresAt        *time.Time             `json:"expires_at,omitempty"`
 }
 
 // JSONWebKey represents the public part of a JWT
@@ -62,12 +202,30 @@ type Organization struct {
 	api.Model
 
 	Name        *string                `json:"name"`
-	UserID      *uuid.UUID             `json:"user_id,omitempty"`
+	UserID      *common.ID             `json:"user_id,omitempty"`
 	Description *string                `json:"description"`
 	Permissions uint32                 `json:"permissions,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata"`
 }
 
+// Session is an authenticated user session; revoking a session invalidates its refresh token
+// and any access tokens issued using it
+type Session struct {
+	api.Model
+
+	UserID         *common.ID `json:"user_id,omitempty"`
+	ApplicationID  *common.ID `json:"application_id,omitempty"`
+	OrganizationID *common.ID `json:"organization_id,omitempty"`
+	TokenID        *common.ID `json:"token_id,omitempty"`
+	IPAddress      *string    `json:"ip_address,omitempty"`
+	UserAgent      *string    `json:"user_agent,omitempty"`
+	LastActiveAt   *time.Time `json:"last_active_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+
+	// Current is true when the session is the one in which the request was made
+	Current bool `json:"current"`
+}
+
 // Token represents a bearer JWT
 type Token struct {
 	api.Model
@@ -94,6 +252,88 @@ type Token struct {
 	Data        map[string]interface{} `json:"data,omitempty"`
 }
 
+// TokenRequest is a typed request to authorize a token using one of the supported OAuth 2 grants;
+// the authorized token may be scoped to an application or organization
+type TokenRequest struct {
+	GrantType string `json:"grant_type,omitempty"`
+
+	Scope    *string `json:"scope,omitempty"`
+	Audience *string `json:"audience,omitempty"`
+
+	ApplicationID  *string `json:"application_id,omitempty"`
+	OrganizationID *string `json:"organization_id,omitempty"`
+	UserID         *string `json:"user_id,omitempty"`
+
+	Permissions *uint32 `json:"permissions,omitempty"`
+
+	// client_credentials grant
+	ClientID     *string `json:"client_id,omitempty"`
+	ClientSecret *string `json:"client_secret,omitempty"`
+
+	// authorization_code grant
+	Code         *string `json:"code,omitempty"`
+	CodeVerifier *string `json:"code_verifier,omitempty"` // PKCE
+	RedirectURI  *string `json:"redirect_uri,omitempty"`
+}
+
+// UserQuery is a typed query for filtering, sorting and paginating users
+type UserQuery struct {
+	Email *string
+	Name  *string
+	Query *string // free-text search across name and email
+
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	Sort *string // i.e., created_at, -created_at, email
+
+	Page uint64
+	RPP  uint64 // results per page
+}
+
+// Params returns the query parameters for the user query
+func (q *UserQuery) Params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if q == nil {
+		return params
+	}
+
+	if q.Email != nil {
+		params["email"] = *q.Email
+	}
+	if q.Name != nil {
+		params["name"] = *q.Name
+	}
+	if q.Query != nil {
+		params["q"] = *q.Query
+	}
+	if q.CreatedAfter != nil {
+		params["created_after"] = q.CreatedAfter.Format(time.RFC3339)
+	}
+	if q.CreatedBefore != nil {
+		params["created_before"] = q.CreatedBefore.Format(time.RFC3339)
+	}
+	if q.Sort != nil {
+		params["sort"] = *q.Sort
+	}
+	if q.Page > 0 {
+		params["page"] = strconv.FormatUint(q.Page, 10)
+	}
+	if q.RPP > 0 {
+		params["rpp"] = strconv.FormatUint(q.RPP, 10)
+	}
+
+	return params
+}
+
+// UserSearchResult is a single page of users matching a UserQuery
+type UserSearchResult struct {
+	Users   []*User
+	Page    uint64
+	RPP     uint64
+	HasMore bool
+}
+
 // User represents a user
 type User struct {
 	api.Model
@@ -107,3 +347,37 @@ type User struct {
 	TermsOfServiceAgreedAt *time.Time             `json:"terms_of_service_agreed_at,omitempty"`
 	Metadata               map[string]interface{} `json:"metadata,omitempty"`
 }
+
+// Expired returns true if the token has expired
+func (t *Token) Expired() bool {
+	return t.ExpiresWithin(0)
+}
+
+// ExpiresWithin returns true if the token expires within the given duration;
+// tokens without a known expiration never expire
+func (t *Token) ExpiresWithin(d time.Duration) bool {
+	expiresAt := t.expiration()
+	if expiresAt == nil {
+		return false
+	}
+	return time.Now().Add(d).After(*expiresAt)
+}
+
+func (t *Token) expiration() *time.Time {
+	if t.ExpiresAt != nil {
+		return t.ExpiresAt
+	}
+
+	if t.ExpiresIn != nil {
+		issuedAt := t.CreatedAt
+		if t.IssuedAt != nil {
+			issuedAt = *t.IssuedAt
+		}
+		if !issuedAt.IsZero() {
+			expiresAt := issuedAt.Add(time.Duration(*t.ExpiresIn) * time.Second)
+			return &expiresAt
+		}
+	}
+
+	return nil
+}
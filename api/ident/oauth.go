@@ -0,0 +1,123 @@
+package ident
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultOAuthAuthorizePath = "oauth/authorize"
+const pkceCodeChallengeMethodS256 = "S256"
+const pkceVerifierEntropy = 32
+
+// AuthorizationRequest is used to build the URL to which a user is redirected to
+// sign in with Provide using the OAuth 2 authorization code flow
+type AuthorizationRequest struct {
+	ClientID    string
+	RedirectURI string
+	Scope       *string
+	State       string
+
+	// CodeChallenge is the PKCE challenge derived from the code verifier; see GeneratePKCE
+	CodeChallenge string
+}
+
+// PKCE is a proof key for code exchange verifier and its derived S256 challenge
+type PKCE struct {
+	Verifier        string
+	Challenge       string
+	ChallengeMethod string
+}
+
+// GeneratePKCE generates a random PKCE code verifier and its S256 challenge; the challenge is
+// sent with the authorization request and the verifier with the authorization code exchange
+func GeneratePKCE() (*PKCE, error) {
+	verifier, err := randomURLSafeString(pkceVerifierEntropy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PKCE code verifier; %s", err.Error())
+	}
+
+	return &PKCE{
+		Verifier:        verifier,
+		Challenge:       pkceChallenge(verifier),
+		ChallengeMethod: pkceCodeChallengeMethodS256,
+	}, nil
+}
+
+// GenerateOAuthState generates a random state value used to protect against CSRF; it must
+// be stored by the application and compared to the state returned to the redirect uri
+func GenerateOAuthState() (string, error) {
+	return randomURLSafeString(pkceVerifierEntropy)
+}
+
+// AuthorizationURL builds the URL to which the user is redirected to authorize the application
+func AuthorizationURL(req *AuthorizationRequest) (string, error) {
+	if req == nil || req.ClientID == "" || req.RedirectURI == "" {
+		return "", errors.New("failed to build authorization url; client id and redirect uri required")
+	}
+
+	host := defaultIdentHost
+	if os.Getenv("IDENT_API_HOST") != "" {
+		host = os.Getenv("IDENT_API_HOST")
+	}
+
+	scheme := defaultIdentScheme
+	if os.Getenv("IDENT_API_SCHEME") != "" {
+		scheme = os.Getenv("IDENT_API_SCHEME")
+	}
+
+	query := url.Values{}
+	query.Set("client_id", req.ClientID)
+	query.Set("redirect_uri", req.RedirectURI)
+	query.Set("response_type", "code")
+	if req.Scope != nil {
+		query.Set("scope", *req.Scope)
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	if req.CodeChallenge != "" {
+		query.Set("code_challenge", req.CodeChallenge)
+		query.Set("code_challenge_method", pkceCodeChallengeMethodS256)
+	}
+
+	authorizeURL := &url.URL{
+		Scheme:   scheme,
+		Host:     host,
+		Path:     defaultOAuthAuthorizePath,
+		RawQuery: query.Encode(),
+	}
+	return authorizeURL.String(), nil
+}
+
+// ExchangeAuthorizationCode exchanges the authorization code returned to the redirect uri
+// for an access token and, when the offline_access scope was authorized, a refresh token
+func ExchangeAuthorizationCode(clientID, code, redirectURI, codeVerifier string) (*Token, error) {
+	return CreateTokenWithGrant("", &TokenRequest{
+		GrantType:    GrantTypeAuthorizationCode,
+		ClientID:     common.StringOrNil(clientID),
+		Code:         common.StringOrNil(code),
+		CodeVerifier: common.StringOrNil(codeVerifier),
+		RedirectURI:  common.StringOrNil(redirectURI),
+	})
+}
+
+func pkceChallenge(verifier string) string {
+	digest := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func randomURLSafeString(entropy int) (string, error) {
+	buf := make([]byte, entropy)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,154 @@
+package ident
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Permission is a bitmask representing the capabilities authorized for a user, organization or
+// token; the values mirror the permissions bitmask enforced by ident (see common/permissions.go
+// in provideplatform/ident), in which generic resource permissions occupy the low-order bits and
+// ident-specific administrative permissions begin at 2^20
+type Permission uint32
+
+const (
+	// PermissionAuthenticate authorizes authentication
+	PermissionAuthenticate Permission = 1 << iota
+
+	// PermissionReadResources authorizes reading resources
+	PermissionReadResources
+
+	// PermissionCreateResource authorizes creating resources
+	PermissionCreateResource
+
+	// PermissionUpdateResource authorizes updating resources
+	PermissionUpdateResource
+
+	// PermissionDeleteResource authorizes deleting resources
+	PermissionDeleteResource
+
+	// PermissionGrantResourceAuthorization authorizes granting access to resources
+	PermissionGrantResourceAuthorization
+
+	// PermissionRevokeResourceAuthorization authorizes revoking access to resources
+	PermissionRevokeResourceAuthorization
+)
+
+const (
+	// PermissionListApplications authorizes administrative listing of applications
+	PermissionListApplications Permission = 1 << (iota + 20)
+
+	// PermissionCreateApplication authorizes administrative creation of applications
+	PermissionCreateApplication
+
+	// PermissionUpdateApplication authorizes administrative updates to applications
+	PermissionUpdateApplication
+
+	// PermissionDeleteApplication authorizes administrative removal of applications
+	PermissionDeleteApplication
+
+	// PermissionListUsers authorizes administrative listing of users
+	PermissionListUsers
+
+	// PermissionCreateUser authorizes administrative creation of users
+	PermissionCreateUser
+
+	// PermissionUpdateUser authorizes administrative updates to users
+	PermissionUpdateUser
+
+	// PermissionDeleteUser authorizes administrative removal of users
+	PermissionDeleteUser
+
+	// PermissionListTokens authorizes administrative listing of API tokens
+	PermissionListTokens
+
+	// PermissionCreateToken authorizes administrative creation of API tokens
+	PermissionCreateToken
+
+	// PermissionDeleteToken authorizes administrative revocation of API tokens
+	PermissionDeleteToken
+
+	// PermissionSudo authorizes privileged operations
+	PermissionSudo
+)
+
+var permissionNames = []struct {
+	permission Permission
+	name       string
+}{
+	{PermissionAuthenticate, "authenticate"},
+	{PermissionReadResources, "read_resources"},
+	{PermissionCreateResource, "create_resource"},
+	{PermissionUpdateResource, "update_resource"},
+	{PermissionDeleteResource, "delete_resource"},
+	{PermissionGrantResourceAuthorization, "grant_resource_authorization"},
+	{PermissionRevokeResourceAuthorization, "revoke_resource_authorization"},
+	{PermissionListApplications, "list_applications"},
+	{PermissionCreateApplication, "create_application"},
+	{PermissionUpdateApplication, "update_application"},
+	{PermissionDeleteApplication, "delete_application"},
+	{PermissionListUsers, "list_users"},
+	{PermissionCreateUser, "create_user"},
+	{PermissionUpdateUser, "update_user"},
+	{PermissionDeleteUser, "delete_user"},
+	{PermissionListTokens, "list_tokens"},
+	{PermissionCreateToken, "create_token"},
+	{PermissionDeleteToken, "delete_token"},
+	{PermissionSudo, "sudo"},
+}
+
+// Names returns the names of the permissions set in the bitmask
+func (p Permission) Names() []string {
+	names := make([]string, 0)
+	for _, perm := range permissionNames {
+		if p.Has(perm.permission) {
+			names = append(names, perm.name)
+		}
+	}
+	return names
+}
+
+// Has returns true if all of the given permissions are set in the bitmask
+func (p Permission) Has(permission Permission) bool {
+	return p&permission == permission
+}
+
+// Grant returns the bitmask with the given permissions set
+func (p Permission) Grant(permission Permission) Permission {
+	return p | permission
+}
+
+// Revoke returns the bitmask with the given permissions cleared
+func (p Permission) Revoke(permission Permission) Permission {
+	return p &^ permission
+}
+
+// String returns the comma-delimited names of the permissions set in the bitmask
+func (p Permission) String() string {
+	return strings.Join(p.Names(), ",")
+}
+
+// ParsePermissions returns the bitmask for the given permission names
+func ParsePermissions(names []string) (Permission, error) {
+	var p Permission
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		resolved := false
+		for _, perm := range permissionNames {
+			if perm.name == name {
+				p = p.Grant(perm.permission)
+				resolved = true
+				break
+			}
+		}
+
+		if !resolved {
+			return 0, fmt.Errorf("failed to parse permissions; unknown permission: %s", name)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,111 @@
+package ident
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultTokenRefreshLeeway = time.Minute * 5
+const defaultTokenRefreshInterval = time.Minute * 45
+const tokenRefreshRetryInterval = time.Second * 30
+
+// TokenRefresher maintains a valid access token on behalf of a long-running service by
+// proactively refreshing the access token before it expires
+type TokenRefresher struct {
+	// Leeway is the duration before expiration at which the access token is refreshed
+	Leeway time.Duration
+
+	refreshToken string
+	token        *Token
+	mutex        *sync.Mutex
+}
+
+// NewTokenRefresher initializes a TokenRefresher for the given refresh token
+func NewTokenRefresher(refreshToken string) *TokenRefresher {
+	return &TokenRefresher{
+		Leeway:       defaultTokenRefreshLeeway,
+		refreshToken: refreshToken,
+		mutex:        &sync.Mutex{},
+	}
+}
+
+// AccessToken returns a valid access token, refreshing it if it has expired or expires within the leeway
+func (r *TokenRefresher) AccessToken() (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.token == nil || r.token.AccessToken == nil || r.token.ExpiresWithin(r.Leeway) {
+		err := r.refresh()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return *r.token.AccessToken, nil
+}
+
+// Start proactively refreshes the access token until the given context is canceled
+func (r *TokenRefresher) Start(ctx context.Context) {
+	go func() {
+		for {
+			r.mutex.Lock()
+			delay := r.nextRefresh()
+			r.mutex.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			r.mutex.Lock()
+			err := r.refresh()
+			r.mutex.Unlock()
+
+			if err != nil {
+				common.Log.Warningf("failed to proactively refresh access token; %s", err.Error())
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(tokenRefreshRetryInterval):
+				}
+			}
+		}
+	}()
+}
+
+func (r *TokenRefresher) nextRefresh() time.Duration {
+	if r.token == nil {
+		return 0
+	}
+
+	expiresAt := r.token.expiration()
+	if expiresAt == nil {
+		return defaultTokenRefreshInterval
+	}
+
+	delay := time.Until(expiresAt.Add(-r.Leeway))
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+func (r *TokenRefresher) refresh() error {
+	if r.refreshToken == "" {
+		return errors.New("failed to refresh access token; no refresh token configured")
+	}
+
+	token, err := RefreshToken(r.refreshToken)
+	if err != nil {
+		return err
+	}
+
+	r.token = token
+	common.Log.Debugf("refreshed access token; expires at: %v", token.expiration())
+	return nil
+}
@@ -0,0 +1,178 @@
+package ident
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Scopes authorizing cross-service operations; scopes are of the form <service>:<capability>
+const (
+	// ScopeIdentRead authorizes reading ident resources (applications, organizations, users)
+	ScopeIdentRead = "ident:read"
+
+	// ScopeIdentWrite authorizes creating and updating ident resources
+	ScopeIdentWrite = "ident:write"
+
+	// ScopeVaultRead authorizes reading vaults, keys and secrets metadata
+	ScopeVaultRead = "vault:read"
+
+	// ScopeVaultSign authorizes signing and verifying using vault keys
+	ScopeVaultSign = "vault:sign"
+
+	// ScopeVaultEncrypt authorizes encrypting and decrypting using vault keys
+	ScopeVaultEncrypt = "vault:encrypt"
+
+	// ScopeVaultWrite authorizes creating and deleting vaults, keys and secrets
+	ScopeVaultWrite = "vault:write"
+
+	// ScopeNChainRead authorizes reading networks, accounts, wallets, contracts and transactions
+	ScopeNChainRead = "nchain:read"
+
+	// ScopeNChainExecute authorizes executing contracts and broadcasting transactions
+	ScopeNChainExecute = "nchain:execute"
+
+	// ScopeNChainWrite authorizes creating and updating nchain resources
+	ScopeNChainWrite = "nchain:write"
+
+	// ScopeBaselineRead authorizes reading workgroups, workflows and baselined objects
+	ScopeBaselineRead = "baseline:read"
+
+	// ScopeBaselineWrite authorizes creating workgroups, workflows and baselining objects
+	ScopeBaselineWrite = "baseline:write"
+
+	// ScopePrivacyProve authorizes generating and verifying proofs
+	ScopePrivacyProve = "privacy:prove"
+)
+
+// TokenScope composes the scopes, permissions and application or organization scoping required
+// by a token, so tokens can be requested with the least privilege required for a set of calls
+type TokenScope struct {
+	scopes      map[string]bool
+	permissions Permission
+
+	applicationID  *string
+	organizationID *string
+	audience       *string
+}
+
+// NewTokenScope initializes a TokenScope requiring the given scopes
+func NewTokenScope(scopes ...string) *TokenScope {
+	return (&TokenScope{scopes: map[string]bool{}}).With(scopes...)
+}
+
+// With adds the given scopes; space-delimited scope strings are split into their scopes
+func (s *TokenScope) With(scopes ...string) *TokenScope {
+	for _, scope := range scopes {
+		for _, _scope := range strings.Fields(scope) {
+			s.scopes[_scope] = true
+		}
+	}
+	return s
+}
+
+// WithPermissions adds the given permissions
+func (s *TokenScope) WithPermissions(permissions Permission) *TokenScope {
+	s.permissions = s.permissions.Grant(permissions)
+	return s
+}
+
+// WithAudience sets the audience of the token
+func (s *TokenScope) WithAudience(audience string) *TokenScope {
+	s.audience = &audience
+	return s
+}
+
+// ForApplication scopes the token to the given application
+func (s *TokenScope) ForApplication(applicationID string) *TokenScope {
+	s.applicationID = &applicationID
+	s.organizationID = nil
+	return s
+}
+
+// ForOrganization scopes the token to the given organization
+func (s *TokenScope) ForOrganization(organizationID string) *TokenScope {
+	s.organizationID = &organizationID
+	s.applicationID = nil
+	return s
+}
+
+// Merge adds the scopes and permissions of the given TokenScope, i.e. those required by another
+// set of calls; the application or organization scoping of the given TokenScope is not merged
+func (s *TokenScope) Merge(other *TokenScope) *TokenScope {
+	if other == nil {
+		return s
+	}
+	s.With(other.Scopes()...)
+	return s.WithPermissions(other.permissions)
+}
+
+// Scopes returns the sorted scopes
+func (s *TokenScope) Scopes() []string {
+	scopes := make([]string, 0, len(s.scopes))
+	for scope := range s.scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// Permissions returns the permissions
+func (s *TokenScope) Permissions() Permission {
+	return s.permissions
+}
+
+// String returns the space-delimited scopes, per the OAuth 2 scope parameter
+func (s *TokenScope) String() string {
+	return strings.Join(s.Scopes(), " ")
+}
+
+// SatisfiedBy returns true if the given token was granted each of the scopes and permissions
+func (s *TokenScope) SatisfiedBy(token *Token) bool {
+	if token == nil {
+		return false
+	}
+	if !Permission(token.Permissions).Has(s.permissions) {
+		return false
+	}
+
+	granted := map[string]bool{}
+	if token.Scope != nil {
+		for _, scope := range strings.Fields(*token.Scope) {
+			granted[scope] = true
+		}
+	}
+	for scope := range s.scopes {
+		if !granted[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// TokenRequest returns a token request for the scopes, permissions and scoping
+func (s *TokenScope) TokenRequest() *TokenRequest {
+	req := &TokenRequest{
+		Audience:       s.audience,
+		ApplicationID:  s.applicationID,
+		OrganizationID: s.organizationID,
+	}
+	if len(s.scopes) > 0 {
+		scope := s.String()
+		req.Scope = &scope
+	}
+	if s.permissions != 0 {
+		permissions := uint32(s.permissions)
+		req.Permissions = &permissions
+	}
+	return req
+}
+
+// CreateScopedToken authorizes a token having only the given scopes and permissions, on behalf of
+// the given (broader) API token
+func CreateScopedToken(token string, scope *TokenScope) (*Token, error) {
+	if scope == nil {
+		return nil, errors.New("failed to authorize scoped token; no scope provided")
+	}
+	return CreateTokenWithGrant(token, scope.TokenRequest())
+}
@@ -0,0 +1,47 @@
+package ident
+
+import (
+	"testing"
+)
+
+func TestTokenScope(t *testing.T) {
+	signing := NewTokenScope(ScopeVaultSign).WithPermissions(PermissionReadResources)
+	execution := NewTokenScope(ScopeNChainExecute, ScopeVaultSign).WithPermissions(PermissionCreateResource)
+
+	scope := NewTokenScope().Merge(signing).Merge(execution).ForApplication("app")
+	if scope.String() != "nchain:execute vault:sign" {
+		t.Errorf("unexpected scope %s", scope.String())
+	}
+
+	req := scope.TokenRequest()
+	if req.Scope == nil || *req.Scope != "nchain:execute vault:sign" || req.ApplicationID == nil || *req.ApplicationID != "app" {
+		t.Errorf("unexpected token request %+v", req)
+	}
+	if req.Permissions == nil || Permission(*req.Permissions) != PermissionReadResources|PermissionCreateResource {
+		t.Errorf("unexpected token request permissions %v", req.Permissions)
+	}
+
+	granted := "vault:sign nchain:execute offline_access"
+	token := &Token{Scope: &granted, Permissions: uint32(PermissionReadResources | PermissionCreateResource | PermissionAuthenticate)}
+	if !scope.SatisfiedBy(token) {
+		t.Error("expected token to satisfy scope")
+	}
+
+	if scope.With(ScopeVaultWrite).SatisfiedBy(token) {
+		t.Error("expected token not to satisfy scope requiring vault:write")
+	}
+}
+
+func TestPermissionValues(t *testing.T) {
+	for perm, expected := range map[Permission]uint32{
+		PermissionAuthenticate:                1,
+		PermissionRevokeResourceAuthorization: 64,
+		PermissionListApplications:            1048576,
+		PermissionDeleteToken:                 1073741824,
+		PermissionSudo:                        2147483648,
+	} {
+		if uint32(perm) != expected {
+			t.Errorf("expected %s permission to be %d; got %d", perm.String(), expected, uint32(perm))
+		}
+	}
+}
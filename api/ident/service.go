@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/provideplatform/provide-go/api"
 	"github.com/provideplatform/provide-go/common"
 )
@@ -13,6 +15,21 @@ const defaultIdentHost = "ident.provide.services"
 const defaultIdentPath = "api/v1"
 const defaultIdentScheme = "https"
 
+const defaultSearchUsersRPP = 25
+const invitationApplicationClaimsKey = "prvd"
+
+// GrantTypeAuthorizationCode is the OAuth 2 authorization code grant
+const GrantTypeAuthorizationCode = "authorization_code"
+
+// GrantTypeClientCredentials is the OAuth 2 client credentials grant, used for machine-to-machine authorization
+const GrantTypeClientCredentials = "client_credentials"
+
+// GrantTypeRefreshToken is the OAuth 2 refresh token grant
+const GrantTypeRefreshToken = "refresh_token"
+
+// ScopeOfflineAccess is the scope requested to authorize a refresh token
+const ScopeOfflineAccess = "offline_access"
+
 // Service for the ident api
 type Service struct {
 	api.Client
@@ -63,26 +80,140 @@ func Authenticate(email, passwd string) (*AuthenticationResponse, error) {
 	status, resp, err := prvd.Post("authenticate", map[string]interface{}{
 		"email":    email,
 		"password": passwd,
-		"scope":    "offline_access",
+		"scope":    ScopeOfflineAccess,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// FIXME...
+	if status != 201 {
+		return nil, fmt.Errorf("failed to authenticate user; status: %d", status)
+	}
+
 	authresp := &AuthenticationResponse{}
 	raw, _ := json.Marshal(resp)
 	err = json.Unmarshal(raw, &authresp)
-
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate user; status: %d; %s", status, err.Error())
-	} else if status != 201 {
+	}
+
+	return authresp, nil
+}
+
+// AuthenticateWithMFA authenticates a user by email address and password; when the user has
+// enrolled in multi-factor authentication, the returned response contains an MFAChallenge
+// in lieu of a token, which must be completed using VerifyMFAChallenge
+func AuthenticateWithMFA(email, passwd string) (*AuthenticationResponse, error) {
+	status, resp, err := InitIdentService(nil).Post("authenticate", map[string]interface{}{
+		"email":    email,
+		"password": passwd,
+		"scope":    ScopeOfflineAccess,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 && status != 202 {
 		return nil, fmt.Errorf("failed to authenticate user; status: %d", status)
 	}
 
+	authresp := &AuthenticationResponse{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &authresp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user; status: %d; %s", status, err.Error())
+	}
+
+	if status == 202 && authresp.MFAChallenge == nil {
+		return nil, fmt.Errorf("failed to authenticate user; no mfa challenge returned; status: %d", status)
+	}
+
 	return authresp, nil
 }
 
+// VerifyMFAChallenge completes a two-step authentication using the given challenge and code
+func VerifyMFAChallenge(challenge *MFAChallenge, code string) (*AuthenticationResponse, error) {
+	if challenge == nil || challenge.Token == nil {
+		return nil, fmt.Errorf("failed to verify mfa challenge; no challenge token provided")
+	}
+
+	status, resp, err := InitIdentService(nil).Post("authenticate/mfa", map[string]interface{}{
+		"challenge": *challenge.Token,
+		"code":      code,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to verify mfa challenge; status: %d", status)
+	}
+
+	authresp := &AuthenticationResponse{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &authresp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify mfa challenge; status: %d; %s", status, err.Error())
+	}
+
+	return authresp, nil
+}
+
+// EnrollMFA provisions a TOTP secret for the given user; enrollment is not active until
+// completed using VerifyMFAEnrollment
+func EnrollMFA(token, userID string) (*MFAEnrollment, error) {
+	uri := fmt.Sprintf("users/%s/mfa", userID)
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Post(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to enroll user in mfa; status: %v", status)
+	}
+
+	enrollment := &MFAEnrollment{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &enrollment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll user in mfa; status: %v; %s", status, err.Error())
+	}
+
+	return enrollment, nil
+}
+
+// VerifyMFAEnrollment completes mfa enrollment for the given user using a code generated by the provisioned secret
+func VerifyMFAEnrollment(token, userID, code string) error {
+	uri := fmt.Sprintf("users/%s/mfa/verify", userID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+		"code": code,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to verify mfa enrollment; status: %v", status)
+	}
+
+	return nil
+}
+
+// DisableMFA removes the mfa enrollment for the given user
+func DisableMFA(token, userID string) error {
+	uri := fmt.Sprintf("users/%s/mfa", userID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to disable mfa; status: %v", status)
+	}
+
+	return nil
+}
+
 // CreateApplication on behalf of the given API token
 func CreateApplication(token string, params map[string]interface{}) (*Application, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("applications", params)
@@ -121,6 +252,21 @@ func UpdateApplication(token, applicationID string, params map[string]interface{
 	return nil
 }
 
+// SaveApplication updates the given application using its modeled attributes; attributes
+// returned by the API which are not modeled are preserved (see api.UpdateParams)
+func SaveApplication(token string, app *Application) error {
+	if app == nil || app.ID.IsZero() {
+		return fmt.Errorf("failed to update application; id required")
+	}
+
+	params, err := api.UpdateParams(app)
+	if err != nil {
+		return fmt.Errorf("failed to update application; %s", err.Error())
+	}
+
+	return UpdateApplication(token, app.ID.String(), params)
+}
+
 // DeleteApplication soft-deletes the application using the given API token
 func DeleteApplication(token, applicationID string) error {
 	err := UpdateApplication(token, applicationID, map[string]interface{}{
@@ -133,6 +279,29 @@ func DeleteApplication(token, applicationID string) error {
 	return nil
 }
 
+// DestroyApplication permanently deletes the application using the given API token; use
+// DeleteApplication to soft-delete the application such that it may later be restored
+func DestroyApplication(token, applicationID string) error {
+	uri := fmt.Sprintf("applications/%s", applicationID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete application; status: %v", status)
+	}
+
+	return nil
+}
+
+// RestoreApplication restores a previously soft-deleted application using the given API token
+func RestoreApplication(token, applicationID string) error {
+	return UpdateApplication(token, applicationID, map[string]interface{}{
+		"hidden": false,
+	})
+}
+
 // ListApplications retrieves a paginated list of applications scoped to the given API token
 func ListApplications(token string, params map[string]interface{}) ([]*Application, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("applications", params)
@@ -144,12 +313,9 @@ func ListApplications(token string, params map[string]interface{}) ([]*Applicati
 		return nil, fmt.Errorf("failed to list applications; status: %v", status)
 	}
 
-	apps := make([]*Application, 0)
-	for _, item := range resp.([]interface{}) {
-		app := &Application{}
-		appraw, _ := json.Marshal(item)
-		json.Unmarshal(appraw, &app)
-		apps = append(apps, app)
+	apps, err := api.DecodeList[Application](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications; status: %v; %s", status, err.Error())
 	}
 
 	return apps, nil
@@ -169,8 +335,7 @@ func GetApplicationDetails(token, applicationID string, params map[string]interf
 
 	// FIXME...
 	app := &Application{}
-	appraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(appraw, &app)
+	err = api.DecodeModel(resp, app)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch application details; status: %v; %s", status, err.Error())
@@ -191,18 +356,17 @@ func ListApplicationTokens(token, applicationID string, params map[string]interf
 		return nil, fmt.Errorf("failed to list application tokens; status: %v", status)
 	}
 
-	tkns := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tkn := &Token{}
-		tknraw, _ := json.Marshal(item)
-		json.Unmarshal(tknraw, &tkn)
-		tkns = append(tkns, tkn)
+	tkns, err := api.DecodeList[Token](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application tokens; status: %v; %s", status, err.Error())
 	}
 
 	return tkns, nil
 }
 
 // ListApplicationInvitations retrieves a paginated list of invitations scoped to the given API token
+//
+// Deprecated: invitations are not users; use ListApplicationInvites, which returns typed invitations
 func ListApplicationInvitations(token, applicationID string, params map[string]interface{}) ([]*User, error) {
 	uri := fmt.Sprintf("applications/%s/invitations", applicationID)
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
@@ -214,17 +378,34 @@ func ListApplicationInvitations(token, applicationID string, params map[string]i
 		return nil, fmt.Errorf("failed to list application invitations; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
+	users, err := api.DecodeList[User](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application invitations; status: %v; %s", status, err.Error())
 	}
 
 	return users, nil
 }
 
+// ListApplicationInvites retrieves a paginated list of pending invitations to the given application
+func ListApplicationInvites(token, applicationID string, params map[string]interface{}) ([]*Invite, error) {
+	uri := fmt.Sprintf("applications/%s/invitations", applicationID)
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list application invitations; status: %v", status)
+	}
+
+	invitations, err := api.DecodeList[Invite](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application invitations; status: %v; %s", status, err.Error())
+	}
+
+	return invitations, nil
+}
+
 // ListApplicationOrganizations retrieves a paginated list of organizations scoped to the given API token
 func ListApplicationOrganizations(token, applicationID string, params map[string]interface{}) ([]*Organization, error) {
 	uri := fmt.Sprintf("applications/%s/organizations", applicationID)
@@ -237,12 +418,9 @@ func ListApplicationOrganizations(token, applicationID string, params map[string
 		return nil, fmt.Errorf("failed to list application organizations; status: %v", status)
 	}
 
-	orgs := make([]*Organization, 0)
-	for _, item := range resp.([]interface{}) {
-		org := &Organization{}
-		orgraw, _ := json.Marshal(item)
-		json.Unmarshal(orgraw, &org)
-		orgs = append(orgs, org)
+	orgs, err := api.DecodeList[Organization](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application organizations; status: %v; %s", status, err.Error())
 	}
 
 	return orgs, nil
@@ -290,12 +468,9 @@ func ListApplicationUsers(token, applicationID string, params map[string]interfa
 		return nil, fmt.Errorf("failed to list application users; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
+	users, err := api.DecodeList[User](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application users; status: %v; %s", status, err.Error())
 	}
 
 	return users, nil
@@ -333,12 +508,19 @@ func DeleteApplicationUser(token, applicationID, userID string) error {
 
 // CreateApplicationToken creates a new API token for the given application ID.
 func CreateApplicationToken(token, applicationID string, params map[string]interface{}) (*Token, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
 	params["application_id"] = applicationID
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("tokens", params)
 	if err != nil {
 		return nil, err
 	}
 
+	if status != 201 {
+		return nil, fmt.Errorf("failed to authorize application token; status: %v", status)
+	}
+
 	// FIXME...
 	tkn := &Token{}
 	tknraw, _ := json.Marshal(resp)
@@ -351,6 +533,31 @@ func CreateApplicationToken(token, applicationID string, params map[string]inter
 	return tkn, nil
 }
 
+// CreateApplicationOrganizationToken creates a new API token for the given application ID, scoped
+// to the given organization; the organization must be associated with the application
+func CreateApplicationOrganizationToken(token, applicationID, organizationID string, params map[string]interface{}) (*Token, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	params["organization_id"] = organizationID
+	return CreateApplicationToken(token, applicationID, params)
+}
+
+// DeleteApplicationToken revokes an API token previously authorized for the given application
+func DeleteApplicationToken(token, applicationID, tokenID string) error {
+	uri := fmt.Sprintf("applications/%s/tokens/%s", applicationID, tokenID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete application token; status: %v", status)
+	}
+
+	return nil
+}
+
 // ListOrganizations retrieves a paginated list of organizations scoped to the given API token
 func ListOrganizations(token string, params map[string]interface{}) ([]*Organization, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("organizations", params)
@@ -362,12 +569,9 @@ func ListOrganizations(token string, params map[string]interface{}) ([]*Organiza
 		return nil, fmt.Errorf("failed to list organizations; status: %v", status)
 	}
 
-	orgs := make([]*Organization, 0)
-	for _, item := range resp.([]interface{}) {
-		org := &Organization{}
-		orgraw, _ := json.Marshal(item)
-		json.Unmarshal(orgraw, &org)
-		orgs = append(orgs, org)
+	orgs, err := api.DecodeList[Organization](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations; status: %v; %s", status, err.Error())
 	}
 
 	return orgs, nil
@@ -396,6 +600,99 @@ func CreateToken(token string, params map[string]interface{}) (*Token, error) {
 	return tkn, nil
 }
 
+// CreateTokenWithGrant authorizes a new API token using the grant, scope, audience and
+// application or organization scoping specified in the given typed token request
+func CreateTokenWithGrant(token string, req *TokenRequest) (*Token, error) {
+	if req == nil {
+		return nil, fmt.Errorf("failed to authorize token; no token request provided")
+	}
+
+	switch req.GrantType {
+	case "":
+	case GrantTypeAuthorizationCode:
+		if req.Code == nil {
+			return nil, fmt.Errorf("failed to authorize token; code required for %s grant", req.GrantType)
+		}
+	case GrantTypeClientCredentials:
+		if req.ClientID == nil || req.ClientSecret == nil {
+			return nil, fmt.Errorf("failed to authorize token; client id and secret required for %s grant", req.GrantType)
+		}
+	case GrantTypeRefreshToken:
+	default:
+		return nil, fmt.Errorf("failed to authorize token; unsupported grant type: %s", req.GrantType)
+	}
+
+	params := map[string]interface{}{}
+	raw, _ := json.Marshal(req)
+	json.Unmarshal(raw, &params)
+
+	return CreateToken(token, params)
+}
+
+// CreateClientCredentialsToken authorizes a machine-to-machine API token using the given
+// client credentials; the token is optionally scoped to an application or organization
+func CreateClientCredentialsToken(clientID, clientSecret string, scope, applicationID, organizationID *string) (*Token, error) {
+	return CreateTokenWithGrant("", &TokenRequest{
+		GrantType:      GrantTypeClientCredentials,
+		ClientID:       common.StringOrNil(clientID),
+		ClientSecret:   common.StringOrNil(clientSecret),
+		Scope:          scope,
+		ApplicationID:  applicationID,
+		OrganizationID: organizationID,
+	})
+}
+
+// ListSessions retrieves a paginated list of active sessions for the given user
+func ListSessions(token, userID string, params map[string]interface{}) ([]*Session, error) {
+	uri := fmt.Sprintf("users/%s/sessions", userID)
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list sessions; status: %v", status)
+	}
+
+	sessions, err := api.DecodeList[Session](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions; status: %v; %s", status, err.Error())
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes the given session for the given user
+func RevokeSession(token, userID, sessionID string) error {
+	uri := fmt.Sprintf("users/%s/sessions/%s", userID, sessionID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to revoke session; status: %v", status)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions revokes every active session for the given user (i.e., "log out everywhere");
+// the session in which the request is made is also revoked
+func RevokeAllSessions(token, userID string) error {
+	uri := fmt.Sprintf("users/%s/sessions", userID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to revoke all sessions; status: %v", status)
+	}
+
+	return nil
+}
+
 // ListTokens retrieves a paginated list of API tokens scoped to the given API token
 func ListTokens(token string, params map[string]interface{}) ([]*Token, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("tokens", params)
@@ -407,12 +704,9 @@ func ListTokens(token string, params map[string]interface{}) ([]*Token, error) {
 		return nil, fmt.Errorf("failed to list application tokens; status: %v", status)
 	}
 
-	tkns := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tkn := &Token{}
-		tknraw, _ := json.Marshal(item)
-		json.Unmarshal(tknraw, &tkn)
-		tkns = append(tkns, tkn)
+	tkns, err := api.DecodeList[Token](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application tokens; status: %v; %s", status, err.Error())
 	}
 
 	return tkns, nil
@@ -457,6 +751,51 @@ func DeleteToken(token, tokenID string) error {
 	return nil
 }
 
+// RefreshToken authorizes a new access token using the given refresh token
+func RefreshToken(refreshToken string) (*Token, error) {
+	tkn, err := CreateTokenWithGrant(refreshToken, &TokenRequest{
+		GrantType: GrantTypeRefreshToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if tkn.AccessToken == nil {
+		return nil, fmt.Errorf("failed to refresh access token; no access token authorized for refresh token")
+	}
+
+	if tkn.ExpiresAt == nil && tkn.ExpiresIn != nil {
+		expiresAt := time.Now().Add(time.Duration(*tkn.ExpiresIn) * time.Second)
+		tkn.ExpiresAt = &expiresAt
+	}
+
+	return tkn, nil
+}
+
+// RevokeToken revokes a previously authorized API token; subsequent use of the token will fail authorization
+func RevokeToken(token, tokenID string) error {
+	return DeleteToken(token, tokenID)
+}
+
+// ListRevokedTokens retrieves a paginated list of revoked API tokens scoped to the given API token
+func ListRevokedTokens(token string, params map[string]interface{}) ([]*Token, error) {
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("tokens/revocations", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list revoked tokens; status: %v", status)
+	}
+
+	tkns, err := api.DecodeList[Token](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revoked tokens; status: %v; %s", status, err.Error())
+	}
+
+	return tkns, nil
+}
+
 // CreateOrganization creates a new organization
 func CreateOrganization(token string, params map[string]interface{}) (*Organization, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("organizations", params)
@@ -494,8 +833,7 @@ func GetOrganizationDetails(token, organizationID string, params map[string]inte
 
 	// FIXME...
 	org := &Organization{}
-	orgraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(orgraw, &org)
+	err = api.DecodeModel(resp, org)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch organization details; status: %v; %s", status, err.Error())
@@ -513,7 +851,193 @@ func UpdateOrganization(token, organizationID string, params map[string]interfac
 	}
 
 	if status != 204 {
-		return fmt.Errorf("failed to update associated organization user; status: %v", status)
+		return fmt.Errorf("failed to update organization; status: %v", status)
+	}
+
+	return nil
+}
+
+// SaveOrganization updates the given organization using its modeled attributes; attributes
+// returned by the API which are not modeled are preserved (see api.UpdateParams)
+func SaveOrganization(token string, org *Organization) error {
+	if org == nil || org.ID.IsZero() {
+		return fmt.Errorf("failed to update organization; id required")
+	}
+
+	params, err := api.UpdateParams(org)
+	if err != nil {
+		return fmt.Errorf("failed to update organization; %s", err.Error())
+	}
+
+	return UpdateOrganization(token, org.ID.String(), params)
+}
+
+// PatchOrganizationMetadata merges the given metadata into the organization's existing metadata;
+// keys which are not present are left unchanged, and keys with nil values are removed
+func PatchOrganizationMetadata(token, organizationID string, metadata map[string]interface{}) error {
+	uri := fmt.Sprintf("organizations/%s", organizationID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Patch(uri, map[string]interface{}{
+		"metadata": metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to patch organization metadata; status: %v", status)
+	}
+
+	return nil
+}
+
+// DeleteOrganization deletes the given organization
+func DeleteOrganization(token, organizationID string) error {
+	uri := fmt.Sprintf("organizations/%s", organizationID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete organization; status: %v", status)
+	}
+
+	return nil
+}
+
+// CreateApplicationIdentityProvider configures an external SAML or OIDC identity provider on the given application
+func CreateApplicationIdentityProvider(token, applicationID string, params map[string]interface{}) (*IdentityProvider, error) {
+	return createIdentityProvider(token, fmt.Sprintf("applications/%s/identity_providers", applicationID), params)
+}
+
+// ListApplicationIdentityProviders retrieves the identity providers configured on the given application
+func ListApplicationIdentityProviders(token, applicationID string, params map[string]interface{}) ([]*IdentityProvider, error) {
+	return listIdentityProviders(token, fmt.Sprintf("applications/%s/identity_providers", applicationID), params)
+}
+
+// UpdateApplicationIdentityProvider updates an identity provider configured on the given application
+func UpdateApplicationIdentityProvider(token, applicationID, identityProviderID string, params map[string]interface{}) error {
+	return updateIdentityProvider(token, fmt.Sprintf("applications/%s/identity_providers/%s", applicationID, identityProviderID), params)
+}
+
+// DeleteApplicationIdentityProvider removes an identity provider from the given application
+func DeleteApplicationIdentityProvider(token, applicationID, identityProviderID string) error {
+	return deleteIdentityProvider(token, fmt.Sprintf("applications/%s/identity_providers/%s", applicationID, identityProviderID))
+}
+
+// CreateOrganizationIdentityProvider configures an external SAML or OIDC identity provider on the given organization
+func CreateOrganizationIdentityProvider(token, organizationID string, params map[string]interface{}) (*IdentityProvider, error) {
+	return createIdentityProvider(token, fmt.Sprintf("organizations/%s/identity_providers", organizationID), params)
+}
+
+// ListOrganizationIdentityProviders retrieves the identity providers configured on the given organization
+func ListOrganizationIdentityProviders(token, organizationID string, params map[string]interface{}) ([]*IdentityProvider, error) {
+	return listIdentityProviders(token, fmt.Sprintf("organizations/%s/identity_providers", organizationID), params)
+}
+
+// UpdateOrganizationIdentityProvider updates an identity provider configured on the given organization
+func UpdateOrganizationIdentityProvider(token, organizationID, identityProviderID string, params map[string]interface{}) error {
+	return updateIdentityProvider(token, fmt.Sprintf("organizations/%s/identity_providers/%s", organizationID, identityProviderID), params)
+}
+
+// DeleteOrganizationIdentityProvider removes an identity provider from the given organization
+func DeleteOrganizationIdentityProvider(token, organizationID, identityProviderID string) error {
+	return deleteIdentityProvider(token, fmt.Sprintf("organizations/%s/identity_providers/%s", organizationID, identityProviderID))
+}
+
+// InitiateSSOLogin initiates single sign-on with the given identity provider, returning the URL to which
+// the user is redirected to authenticate; the user is returned to the redirect uri upon completion
+func InitiateSSOLogin(identityProviderID, redirectURI string, state *string) (*SSOLoginRequest, error) {
+	params := map[string]interface{}{
+		"redirect_uri": redirectURI,
+	}
+	if state != nil {
+		params["state"] = *state
+	}
+
+	uri := fmt.Sprintf("identity_providers/%s/sso", identityProviderID)
+	status, resp, err := InitIdentService(nil).Post(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to initiate sso login; status: %v", status)
+	}
+
+	req := &SSOLoginRequest{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate sso login; status: %v; %s", status, err.Error())
+	}
+
+	if req.URL == nil {
+		return nil, fmt.Errorf("failed to initiate sso login; no login url returned; status: %v", status)
+	}
+
+	return req, nil
+}
+
+func createIdentityProvider(token, uri string, params map[string]interface{}) (*IdentityProvider, error) {
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Post(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to create identity provider; status: %v", status)
+	}
+
+	idp := &IdentityProvider{}
+	idpraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(idpraw, &idp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity provider; status: %v; %s", status, err.Error())
+	}
+
+	return idp, nil
+}
+
+func listIdentityProviders(token, uri string, params map[string]interface{}) ([]*IdentityProvider, error) {
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list identity providers; status: %v", status)
+	}
+
+	idps, err := api.DecodeList[IdentityProvider](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity providers; status: %v; %s", status, err.Error())
+	}
+
+	return idps, nil
+}
+
+func updateIdentityProvider(token, uri string, params map[string]interface{}) error {
+	status, _, err := InitIdentService(common.StringOrNil(token)).Put(uri, params)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to update identity provider; status: %v", status)
+	}
+
+	return nil
+}
+
+func deleteIdentityProvider(token, uri string) error {
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete identity provider; status: %v", status)
 	}
 
 	return nil
@@ -533,6 +1057,93 @@ func CreateInvitation(token string, params map[string]interface{}) error {
 	return nil
 }
 
+// ListInvitations retrieves a paginated list of pending invitations scoped to the given API token
+func ListInvitations(token string, params map[string]interface{}) ([]*Invite, error) {
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("invitations", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list invitations; status: %v", status)
+	}
+
+	invitations, err := api.DecodeList[Invite](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations; status: %v; %s", status, err.Error())
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation creates a user on behalf of the given invitation token; the user is
+// associated with the inviting application and/or organization, if any
+func AcceptInvitation(invitationToken string, params map[string]interface{}) (*User, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	params["invitation_token"] = invitationToken
+
+	status, resp, err := InitIdentService(nil).Post("users", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to accept invitation; status: %v", status)
+	}
+
+	usr := &User{}
+	usrraw, _ := json.Marshal(resp)
+	err = json.Unmarshal(usrraw, &usr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept invitation; status: %v; %s", status, err.Error())
+	}
+
+	return usr, nil
+}
+
+// ParseInvitation parses the metadata encoded in the given invitation token; the signature
+// is not verified, as the token is verified by ident when the invitation is accepted
+func ParseInvitation(invitationToken string) (*Invite, error) {
+	claims := jwt.MapClaims{}
+	_, _, err := (&jwt.Parser{}).ParseUnverified(invitationToken, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse invitation token; %s", err.Error())
+	}
+
+	prvd, ok := claims[invitationApplicationClaimsKey].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to parse invitation token; no %s claim present", invitationApplicationClaimsKey)
+	}
+
+	invite := &Invite{}
+	if data, ok := prvd["data"].(map[string]interface{}); ok {
+		inviteraw, _ := json.Marshal(data)
+		err = json.Unmarshal(inviteraw, &invite)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse invitation token; %s", err.Error())
+		}
+	}
+
+	if jti, ok := claims["jti"].(string); ok {
+		if id, err := common.ParseID(jti); err == nil {
+			invite.ID = id
+		}
+	}
+
+	if iat, ok := claims["iat"].(float64); ok {
+		invite.CreatedAt = time.Unix(int64(iat), 0)
+	}
+
+	if exp, ok := claims["exp"].(float64); ok {
+		expiresAt := time.Unix(int64(exp), 0)
+		invite.ExpiresAt = &expiresAt
+	}
+
+	return invite, nil
+}
+
 // CreateUser creates a new user for which API tokens and managed signing identities can be authorized
 func CreateUser(token string, params map[string]interface{}) (*User, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Post("users", params)
@@ -540,7 +1151,10 @@ func CreateUser(token string, params map[string]interface{}) (*User, error) {
 		return nil, err
 	}
 
-	// FIXME...
+	if status != 201 {
+		return nil, fmt.Errorf("failed to create user; status: %v", status)
+	}
+
 	usr := &User{}
 	usrraw, _ := json.Marshal(resp)
 	err = json.Unmarshal(usrraw, &usr)
@@ -564,12 +1178,9 @@ func ListOrganizationUsers(token, orgID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list users; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
+	users, err := api.DecodeList[User](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users; status: %v; %s", status, err.Error())
 	}
 
 	return users, nil
@@ -590,7 +1201,7 @@ func CreateOrganizationUser(token, orgID string, params map[string]interface{})
 	return nil
 }
 
-// UpdateOrganizationUser updates an associated organization user=
+// UpdateOrganizationUser updates an associated organization user
 func UpdateOrganizationUser(token, orgID, userID string, params map[string]interface{}) error {
 	uri := fmt.Sprintf("organizations/%s/users/%s", orgID, userID)
 	status, _, err := InitIdentService(common.StringOrNil(token)).Put(uri, params)
@@ -605,6 +1216,13 @@ func UpdateOrganizationUser(token, orgID, userID string, params map[string]inter
 	return nil
 }
 
+// SetOrganizationUserPermissions sets the permissions of an associated organization user
+func SetOrganizationUserPermissions(token, orgID, userID string, permissions uint32) error {
+	return UpdateOrganizationUser(token, orgID, userID, map[string]interface{}{
+		"permissions": permissions,
+	})
+}
+
 // DeleteOrganizationUser disassociates a user with an organization
 func DeleteOrganizationUser(token, orgID, userID string) error {
 	uri := fmt.Sprintf("organizations/%s/users/%s", orgID, userID)
@@ -621,6 +1239,8 @@ func DeleteOrganizationUser(token, orgID, userID string) error {
 }
 
 // ListOrganizationInvitations retrieves a paginated list of organization invitations scoped to the given API token
+//
+// Deprecated: invitations are not users; use ListOrganizationInvites, which returns typed invitations
 func ListOrganizationInvitations(token, organizationID string, params map[string]interface{}) ([]*User, error) {
 	uri := fmt.Sprintf("organizations/%s/invitations", organizationID)
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
@@ -632,17 +1252,34 @@ func ListOrganizationInvitations(token, organizationID string, params map[string
 		return nil, fmt.Errorf("failed to list organization invitations; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
+	users, err := api.DecodeList[User](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations; status: %v; %s", status, err.Error())
 	}
 
 	return users, nil
 }
 
+// ListOrganizationInvites retrieves a paginated list of pending invitations to the given organization
+func ListOrganizationInvites(token, organizationID string, params map[string]interface{}) ([]*Invite, error) {
+	uri := fmt.Sprintf("organizations/%s/invitations", organizationID)
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list organization invitations; status: %v", status)
+	}
+
+	invitations, err := api.DecodeList[Invite](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization invitations; status: %v; %s", status, err.Error())
+	}
+
+	return invitations, nil
+}
+
 // ListUsers retrieves a paginated list of users scoped to the given API token
 func ListUsers(token string, params map[string]interface{}) ([]*User, error) {
 	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("users", params)
@@ -654,17 +1291,53 @@ func ListUsers(token string, params map[string]interface{}) ([]*User, error) {
 		return nil, fmt.Errorf("failed to list users; status: %v", status)
 	}
 
-	users := make([]*User, 0)
-	for _, item := range resp.([]interface{}) {
-		usr := &User{}
-		usrraw, _ := json.Marshal(item)
-		json.Unmarshal(usrraw, &usr)
-		users = append(users, usr)
+	users, err := api.DecodeList[User](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users; status: %v; %s", status, err.Error())
 	}
 
 	return users, nil
 }
 
+// ListUsersWithQuery retrieves a paginated list of users matching the given typed query
+func ListUsersWithQuery(token string, query *UserQuery) ([]*User, error) {
+	return ListUsers(token, query.Params())
+}
+
+// SearchUsers retrieves a single page of users matching the given typed query; subsequent
+// pages are retrieved by incrementing the query page while the result HasMore
+func SearchUsers(token string, query *UserQuery) (*UserSearchResult, error) {
+	if query == nil {
+		query = &UserQuery{}
+	}
+
+	page := query.Page
+	if page == 0 {
+		page = 1
+	}
+
+	rpp := query.RPP
+	if rpp == 0 {
+		rpp = defaultSearchUsersRPP
+	}
+
+	q := *query
+	q.Page = page
+	q.RPP = rpp
+
+	users, err := ListUsers(token, q.Params())
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserSearchResult{
+		Users:   users,
+		Page:    page,
+		RPP:     rpp,
+		HasMore: uint64(len(users)) == rpp,
+	}, nil
+}
+
 // GetUserDetails retrieves details for the given user id
 func GetUserDetails(token, userID string, params map[string]interface{}) (*User, error) {
 	uri := fmt.Sprintf("users/%s", userID)
@@ -673,10 +1346,12 @@ func GetUserDetails(token, userID string, params map[string]interface{}) (*User,
 		return nil, err
 	}
 
-	// FIXME...
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch user details; status: %v", status)
+	}
+
 	usr := &User{}
-	usrraw, _ := json.Marshal(resp)
-	err = json.Unmarshal(usrraw, &usr)
+	err = api.DecodeModel(resp, usr)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch user details; status: %v; %s", status, err.Error())
@@ -700,6 +1375,54 @@ func UpdateUser(token, userID string, params map[string]interface{}) error {
 	return nil
 }
 
+// SaveUser updates the given user using its modeled attributes; attributes
+// returned by the API which are not modeled are preserved (see api.UpdateParams)
+func SaveUser(token string, usr *User) error {
+	if usr == nil || usr.ID.IsZero() {
+		return fmt.Errorf("failed to update user; id required")
+	}
+
+	params, err := api.UpdateParams(usr)
+	if err != nil {
+		return fmt.Errorf("failed to update user; %s", err.Error())
+	}
+
+	return UpdateUser(token, usr.ID.String(), params)
+}
+
+// PatchUserMetadata merges the given metadata into the user's existing metadata; keys
+// which are not present are left unchanged, and keys with nil values are removed
+func PatchUserMetadata(token, userID string, metadata map[string]interface{}) error {
+	uri := fmt.Sprintf("users/%s", userID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Patch(uri, map[string]interface{}{
+		"metadata": metadata,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to patch user metadata; status: %v", status)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes the given user
+func DeleteUser(token, userID string) error {
+	uri := fmt.Sprintf("users/%s", userID)
+	status, _, err := InitIdentService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete user; status: %v", status)
+	}
+
+	return nil
+}
+
 // RequestPasswordReset initiates a password reset request
 func RequestPasswordReset(token, applicationID *string, email string) error {
 	params := map[string]interface{}{
@@ -737,6 +1460,86 @@ func ResetPassword(token *string, resetPasswordToken, passwd string) error {
 	return nil
 }
 
+// RequestEmailVerification initiates email verification for the user with the given email address
+func RequestEmailVerification(token, applicationID *string, email string) error {
+	params := map[string]interface{}{
+		"email": email,
+	}
+	if applicationID != nil {
+		params["application_id"] = applicationID
+	}
+
+	status, _, err := InitIdentService(token).Post("users/verify_email", params)
+	if err != nil {
+		return fmt.Errorf("failed to request email verification; status: %v; %s", status, err.Error())
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to request email verification for user: %s; status: %v", email, status)
+	}
+
+	return nil
+}
+
+// VerifyEmail completes a previously-requested email verification for a user
+func VerifyEmail(token *string, verificationToken string) error {
+	uri := fmt.Sprintf("users/verify_email/%s", verificationToken)
+	status, _, err := InitIdentService(token).Post(uri, map[string]interface{}{})
+	if err != nil {
+		return fmt.Errorf("failed to verify email; status: %v; %s", status, err.Error())
+	}
+	if status != 204 {
+		return fmt.Errorf("failed to verify email; status: %v", status)
+	}
+
+	return nil
+}
+
+// ListAuditEvents retrieves a paginated list of audit events scoped to the given API token
+func ListAuditEvents(token string, params map[string]interface{}) ([]*AuditEvent, error) {
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get("audit_events", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list audit events; status: %v", status)
+	}
+
+	events, err := api.DecodeList[AuditEvent](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit events; status: %v; %s", status, err.Error())
+	}
+
+	return events, nil
+}
+
+// ListAuditEventsWithQuery retrieves a paginated list of audit events matching the given typed query
+func ListAuditEventsWithQuery(token string, query *AuditEventQuery) ([]*AuditEvent, error) {
+	return ListAuditEvents(token, query.Params())
+}
+
+// GetAuditEvent retrieves details for the given audit event id
+func GetAuditEvent(token, eventID string, params map[string]interface{}) (*AuditEvent, error) {
+	uri := fmt.Sprintf("audit_events/%s", eventID)
+	status, resp, err := InitIdentService(common.StringOrNil(token)).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch audit event; status: %v", status)
+	}
+
+	event := &AuditEvent{}
+	err = api.DecodeModel(resp, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch audit event; status: %v; %s", status, err.Error())
+	}
+
+	return event, nil
+}
+
 // Status returns the status of the endpoint
 func Status() error {
 	host := defaultIdentHost
@@ -798,12 +1601,9 @@ func GetJWKs() ([]*JSONWebKey, error) {
 		return nil, fmt.Errorf("well-known JWKs endpoint returned %d status code", status)
 	}
 
-	keys := make([]*JSONWebKey, 0)
-	for _, item := range resp.([]interface{}) {
-		key := &JSONWebKey{}
-		keyraw, _ := json.Marshal(item)
-		json.Unmarshal(keyraw, &key)
-		keys = append(keys, key)
+	keys, err := api.DecodeList[JSONWebKey](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch well-known JWKs; status: %v; %s", status, err.Error())
 	}
 
 	return keys, nil
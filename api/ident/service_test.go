@@ -0,0 +1,75 @@
+package ident
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+func TestSaveApplicationPreservesUnmodeledAttributes(t *testing.T) {
+	const appID = "0b1bcf4d-5c3e-4fd4-8b3a-1f7e0a7f5a2e"
+
+	var updated map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/"+appID {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"` + appID + `","name":"app","unmodeled":"preserved"}`))
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(204)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("IDENT_API_HOST", srvURL.Host)
+	t.Setenv("IDENT_API_SCHEME", srvURL.Scheme)
+
+	app, err := GetApplicationDetails("token", appID, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to fetch application; %s", err.Error())
+	}
+
+	app.Name = common.StringOrNil("renamed")
+	err = SaveApplication("token", app)
+	if err != nil {
+		t.Fatalf("failed to save application; %s", err.Error())
+	}
+
+	if updated["name"] != "renamed" {
+		t.Errorf("expected modeled attribute to be updated; got %v", updated["name"])
+	}
+	if updated["unmodeled"] != "preserved" {
+		t.Errorf("expected unmodeled attribute to be preserved; got %v", updated["unmodeled"])
+	}
+
+	if err := SaveApplication("token", &Application{}); err == nil {
+		t.Error("expected error saving application without id")
+	}
+}
+
+func TestListApplicationsUnexpectedResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"errors":[{"message":"not a list"}]}`))
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("IDENT_API_HOST", srvURL.Host)
+	t.Setenv("IDENT_API_SCHEME", srvURL.Scheme)
+
+	apps, err := ListApplications("token", map[string]interface{}{})
+	if err == nil || apps != nil {
+		t.Error("expected non-list response to be returned as an error")
+	}
+}
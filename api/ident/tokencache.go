@@ -0,0 +1,304 @@
+package ident
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+const tokenCacheVersion = 1
+const tokenCacheLockTimeout = time.Second * 30
+const tokenCacheSaltSize = 16
+
+// scrypt parameters used to derive the token cache encryption key from the passphrase
+const tokenCacheScryptN = 32768
+const tokenCacheScryptR = 8
+const tokenCacheScryptP = 1
+
+// ErrTokenCacheDecryption is returned when the token cache cannot be decrypted using the
+// configured passphrase
+var ErrTokenCacheDecryption = errors.New("failed to decrypt token cache")
+
+// FileTokenCache is an on-disk token cache shared across processes, i.e., by CLI tools and
+// short-lived jobs which reuse access and refresh tokens rather than authenticating upon each
+// invocation. Tokens are encrypted at rest using a key derived from the configured passphrase,
+// and the cache file is locked while it is read or written.
+type FileTokenCache struct {
+	// Leeway is the duration before expiration at which a cached access token is refreshed
+	Leeway time.Duration
+
+	path       string
+	passphrase []byte
+}
+
+// tokenCacheEnvelope is the encrypted representation of the token cache on disk
+type tokenCacheEnvelope struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// DefaultTokenCachePath returns the default path of the token cache within the user cache
+// directory, i.e., ~/.cache/provide/tokens on linux
+func DefaultTokenCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve token cache path; %s", err.Error())
+	}
+	return filepath.Join(dir, "provide", "tokens"), nil
+}
+
+// NewFileTokenCache initializes a FileTokenCache at the given path, or the default path if the
+// path is empty; the given passphrase is required to decrypt the cached tokens
+func NewFileTokenCache(path, passphrase string) (*FileTokenCache, error) {
+	if passphrase == "" {
+		return nil, errors.New("failed to initialize token cache; passphrase is required")
+	}
+
+	if path == "" {
+		var err error
+		path, err = DefaultTokenCachePath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize token cache; %s", err.Error())
+	}
+
+	return &FileTokenCache{
+		Leeway:     defaultTokenRefreshLeeway,
+		path:       path,
+		passphrase: []byte(passphrase),
+	}, nil
+}
+
+// Load returns the token cached under the given key, or nil if no token is cached
+func (c *FileTokenCache) Load(key string) (*Token, error) {
+	var token *Token
+	err := c.withLock(func() error {
+		tokens, err := c.read()
+		if err != nil {
+			return err
+		}
+		token = tokens[key]
+		return nil
+	})
+	return token, err
+}
+
+// Store caches the given token under the given key, i.e., an email address or application id
+func (c *FileTokenCache) Store(key string, token *Token) error {
+	return c.withLock(func() error {
+		tokens, err := c.read()
+		if err != nil {
+			return err
+		}
+		tokens[key] = token
+		return c.write(tokens)
+	})
+}
+
+// Remove removes the token cached under the given key, i.e., upon logout
+func (c *FileTokenCache) Remove(key string) error {
+	return c.withLock(func() error {
+		tokens, err := c.read()
+		if err != nil {
+			return err
+		}
+		delete(tokens, key)
+		return c.write(tokens)
+	})
+}
+
+// AccessToken returns a valid access token for the given key; the cached access token is
+// returned unless it has expired or expires within the leeway, in which case it is refreshed
+// using the cached refresh token or, failing that, the given authenticate func is invoked.
+// The cache remains locked throughout, so concurrent processes do not authenticate redundantly.
+func (c *FileTokenCache) AccessToken(key string, authenticate func() (*Token, error)) (string, error) {
+	var accessToken string
+	err := c.withLock(func() error {
+		tokens, err := c.read()
+		if err != nil {
+			return err
+		}
+
+		token := tokens[key]
+		if bearer := token.bearer(); bearer != "" && !token.ExpiresWithin(c.Leeway) {
+			accessToken = bearer
+			return nil
+		}
+
+		var refreshed *Token
+		if token != nil && token.RefreshToken != nil {
+			refreshed, err = RefreshToken(*token.RefreshToken)
+			if err != nil {
+				common.Log.Debugf("failed to refresh cached access token; %s", err.Error())
+			} else if refreshed.RefreshToken == nil {
+				refreshed.RefreshToken = token.RefreshToken
+			}
+		}
+
+		if refreshed == nil {
+			if authenticate == nil {
+				return errors.New("no valid token cached")
+			}
+			refreshed, err = authenticate()
+			if err != nil {
+				return err
+			}
+		}
+
+		accessToken = refreshed.bearer()
+		if accessToken == "" {
+			return errors.New("no access token returned")
+		}
+
+		tokens[key] = refreshed
+		return c.write(tokens)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve access token from token cache; %s", err.Error())
+	}
+	return accessToken, nil
+}
+
+// bearer returns the access token, or the legacy bearer token if no access token was issued
+func (t *Token) bearer() string {
+	if t == nil {
+		return ""
+	}
+	if t.AccessToken != nil {
+		return *t.AccessToken
+	}
+	return common.Deref(t.Token)
+}
+
+func (c *FileTokenCache) withLock(fn func() error) error {
+	unlock, err := lockFile(c.path+".lock", tokenCacheLockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock token cache; %s", err.Error())
+	}
+	defer unlock()
+	return fn()
+}
+
+// read returns the decrypted tokens; the cache must be locked
+func (c *FileTokenCache) read() (map[string]*Token, error) {
+	tokens := map[string]*Token{}
+
+	raw, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return tokens, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read token cache; %s", err.Error())
+	}
+
+	envelope := &tokenCacheEnvelope{}
+	err = json.Unmarshal(raw, &envelope)
+	if err != nil || envelope == nil || envelope.Version != tokenCacheVersion {
+		return nil, fmt.Errorf("failed to read token cache; unsupported format")
+	}
+
+	aead, err := c.cipher(envelope.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Data, nil)
+	if err != nil {
+		return nil, ErrTokenCacheDecryption
+	}
+
+	err = json.Unmarshal(plaintext, &tokens)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token cache; %s", err.Error())
+	}
+	if tokens == nil {
+		tokens = map[string]*Token{}
+	}
+
+	return tokens, nil
+}
+
+// write encrypts and atomically writes the given tokens; the cache must be locked
+func (c *FileTokenCache) write(tokens map[string]*Token) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("failed to write token cache; %s", err.Error())
+	}
+
+	envelope := &tokenCacheEnvelope{
+		Version: tokenCacheVersion,
+		Salt:    make([]byte, tokenCacheSaltSize),
+	}
+	if _, err := rand.Read(envelope.Salt); err != nil {
+		return fmt.Errorf("failed to write token cache; %s", err.Error())
+	}
+
+	aead, err := c.cipher(envelope.Salt)
+	if err != nil {
+		return err
+	}
+
+	envelope.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return fmt.Errorf("failed to write token cache; %s", err.Error())
+	}
+	envelope.Data = aead.Seal(nil, envelope.Nonce, plaintext, nil)
+
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to write token cache; %s", err.Error())
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write token cache; %s", err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0600)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write token cache; %s", err.Error())
+	}
+
+	return nil
+}
+
+// cipher returns the AES-256-GCM cipher keyed using the passphrase and the given salt
+func (c *FileTokenCache) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(c.passphrase, salt, tokenCacheScryptN, tokenCacheScryptR, tokenCacheScryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive token cache key; %s", err.Error())
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize token cache cipher; %s", err.Error())
+	}
+
+	return cipher.NewGCM(block)
+}
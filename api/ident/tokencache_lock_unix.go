@@ -0,0 +1,38 @@
+//go:build !windows
+// +build !windows
+
+package ident
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// lockFile acquires an exclusive flock on the given lock file, shared across processes; the
+// returned func releases the lock
+func lockFile(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+
+		if err != syscall.EWOULDBLOCK || time.Now().After(deadline) {
+			f.Close()
+			return nil, err
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,41 @@
+//go:build windows
+// +build windows
+
+package ident
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile acquires an exclusive LockFileEx lock on the given lock file, shared across processes;
+// the lock is released by the operating system if the process exits without releasing it. The
+// returned func releases the lock
+func lockFile(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	handle := windows.Handle(f.Fd())
+	deadline := time.Now().Add(timeout)
+	for {
+		err = windows.LockFileEx(handle, windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, &windows.Overlapped{})
+		if err == nil {
+			break
+		}
+
+		if err != windows.ERROR_LOCK_VIOLATION || time.Now().After(deadline) {
+			f.Close()
+			return nil, err
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+
+	return func() {
+		windows.UnlockFileEx(handle, 0, 1, 0, &windows.Overlapped{})
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,87 @@
+package ident
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+func TestFileTokenCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	cache, err := NewFileTokenCache(path, "passphrase")
+	if err != nil {
+		t.Fatalf("failed to initialize token cache; %s", err.Error())
+	}
+
+	authenticated := 0
+	authenticate := func() (*Token, error) {
+		authenticated++
+		return &Token{
+			AccessToken: common.StringOrNil("access"),
+			ExpiresAt:   common.Ptr(time.Now().Add(time.Hour)),
+		}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		accessToken, err := cache.AccessToken("user@example.com", authenticate)
+		if err != nil {
+			t.Fatalf("failed to resolve access token; %s", err.Error())
+		}
+		if accessToken != "access" {
+			t.Errorf("expected cached access token; got %s", accessToken)
+		}
+	}
+
+	if authenticated != 1 {
+		t.Errorf("expected to authenticate once; authenticated %d times", authenticated)
+	}
+
+	shared, _ := NewFileTokenCache(path, "passphrase")
+	token, err := shared.Load("user@example.com")
+	if err != nil || token == nil || common.Deref(token.AccessToken) != "access" {
+		t.Errorf("expected token to be shared across caches; %v", err)
+	}
+
+	wrong, _ := NewFileTokenCache(path, "wrong")
+	if _, err := wrong.Load("user@example.com"); err != ErrTokenCacheDecryption {
+		t.Errorf("expected decryption error using wrong passphrase; got %v", err)
+	}
+
+	err = cache.Remove("user@example.com")
+	if err != nil {
+		t.Fatalf("failed to remove cached token; %s", err.Error())
+	}
+	if token, _ := cache.Load("user@example.com"); token != nil {
+		t.Errorf("expected cached token to be removed")
+	}
+}
+
+func TestFileTokenCacheMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	cache, err := NewFileTokenCache(path, "passphrase")
+	if err != nil {
+		t.Fatalf("failed to initialize token cache; %s", err.Error())
+	}
+
+	err = ioutil.WriteFile(path, []byte("null"), 0600)
+	if err != nil {
+		t.Fatalf("failed to write token cache; %s", err.Error())
+	}
+
+	if _, err := cache.Load("user@example.com"); err == nil {
+		t.Error("expected error reading malformed token cache")
+	}
+
+	err = cache.write(nil)
+	if err != nil {
+		t.Fatalf("failed to write token cache; %s", err.Error())
+	}
+
+	err = cache.Store("user@example.com", &Token{AccessToken: common.StringOrNil("access")})
+	if err != nil {
+		t.Errorf("failed to store token in empty token cache; %s", err.Error())
+	}
+}
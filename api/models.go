@@ -5,7 +5,7 @@ import (
 	"strings"
 	"time"
 
-	uuid "github.com/kthomas/go.uuid"
+	"github.com/provideplatform/provide-go/common"
 )
 
 // AutoIncrementingModel base class with int primary key
@@ -15,11 +15,46 @@ type AutoIncrementingModel struct {
 	Errors    []*Error  `sql:"-" json:"errors,omitempty"`
 }
 
-// Model base class with uuid v4 primary key id
+// Model base class with uuid v4 primary key id; the zero ID is omitted when marshaled
 type Model struct {
-	ID        uuid.UUID `sql:"primary_key;type:uuid;default:uuid_generate_v4()" json:"id"`
-	CreatedAt time.Time `sql:"not null;default:now()" json:"created_at,omitempty"`
-	Errors    []*Error  `sql:"-" json:"errors,omitempty"`
+	ID        common.ID  `sql:"primary_key;type:uuid;default:uuid_generate_v4()" json:"id,omitzero"`
+	CreatedAt time.Time  `sql:"not null;default:now()" json:"created_at,omitempty"`
+	UpdatedAt *time.Time `sql:"-" json:"updated_at,omitempty"`
+	DeletedAt *time.Time `sql:"-" json:"deleted_at,omitempty"`
+	Errors    Errors     `sql:"-" json:"errors,omitempty"`
+
+	raw map[string]interface{} // attributes as returned by the API, including those not modeled
+}
+
+// Deleted returns true if the model has been soft-deleted
+func (m *Model) Deleted() bool {
+	return m != nil && m.DeletedAt != nil && !m.DeletedAt.IsZero()
+}
+
+// LastModifiedAt returns the time the model was last updated, or when it was created
+// if it has never been updated
+func (m *Model) LastModifiedAt() time.Time {
+	if m.UpdatedAt != nil && !m.UpdatedAt.IsZero() {
+		return *m.UpdatedAt
+	}
+	return m.CreatedAt
+}
+
+// Raw returns the attributes of the model as returned by the API, including attributes
+// which are not modeled; it is nil unless the model was decoded using DecodeModel
+func (m *Model) Raw() map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	return m.raw
+}
+
+// SetRaw sets the raw attributes of the model
+func (m *Model) SetRaw(raw map[string]interface{}) {
+	if m == nil {
+		return
+	}
+	m.raw = raw
 }
 
 // IModel interface
@@ -37,6 +72,25 @@ type Error struct {
 	Status  *int    `json:"status,omitempty"`
 }
 
+// Errors is the collection of errors returned by the platform APIs
+type Errors []*Error
+
+// Error implements the error interface by joining the messages of the collected errors
+func (e Errors) Error() string {
+	return strings.Join(e.Messages(), "; ")
+}
+
+// Messages returns the messages of the collected errors
+func (e Errors) Messages() []string {
+	msgs := make([]string, 0)
+	for _, err := range e {
+		if err != nil && err.Message != nil {
+			msgs = append(msgs, *err.Message)
+		}
+	}
+	return msgs
+}
+
 // Manifest defines the contents of a Provide release
 type Manifest struct {
 	Name       string             `json:"name"`
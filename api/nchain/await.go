@@ -0,0 +1,144 @@
+package nchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// TxStatusFailed is the terminal status of a transaction which failed or was reverted
+const TxStatusFailed = "failed"
+
+// TxStatusPending is the status of a transaction which has not yet been finalized
+const TxStatusPending = "pending"
+
+// TxStatusSuccess is the terminal status of a transaction which was successfully finalized
+const TxStatusSuccess = "success"
+
+const defaultAwaitTransactionInterval = time.Second * 2
+
+// ErrTransactionFailed is returned when an awaited transaction reaches the failed state
+var ErrTransactionFailed = errors.New("transaction failed")
+
+// ExecuteContractMethod executes the given method on the given contract using the given
+// positional method params; additional execution params (i.e., account_id, wallet_id, value)
+// may be provided using opts
+func ExecuteContractMethod(token, contractID, method string, params []interface{}, opts map[string]interface{}) (*ContractExecutionResponse, error) {
+	execParams := map[string]interface{}{}
+	for key, val := range opts {
+		execParams[key] = val
+	}
+	execParams["method"] = method
+	if params == nil {
+		params = make([]interface{}, 0)
+	}
+	execParams["params"] = params
+
+	return ExecuteContract(token, contractID, execParams)
+}
+
+// AwaitTransaction polls the transaction identified by the given id or reference until it
+// reaches a terminal state or the context is canceled; ErrTransactionFailed is returned,
+// along with the transaction, if the transaction failed
+func AwaitTransaction(ctx context.Context, token, ref string, interval time.Duration) (*Transaction, error) {
+	if interval <= 0 {
+		interval = defaultAwaitTransactionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	poll := transactionPoller(token)
+	for {
+		tx, done, err := poll(ctx, ref)
+		if err != nil {
+			return tx, err
+		} else if done {
+			return tx, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to await transaction %s; %s", ref, ctx.Err().Error())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExecuteContractOperation executes the given contract and returns an Operation which completes
+// when the resulting transaction reaches a terminal state; see api.Operation. The operation
+// fails with ErrTransactionFailed if the transaction failed
+func ExecuteContractOperation(token, contractID string, params map[string]interface{}) (*api.Operation[Transaction], error) {
+	resp, err := ExecuteContract(token, contractID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Reference == nil {
+		return nil, fmt.Errorf("failed to track contract execution; no transaction reference returned")
+	}
+
+	op, err := api.NewOperation(map[string]interface{}{
+		"reference": *resp.Reference,
+	}, transactionPoller(token))
+	if err != nil {
+		return nil, err
+	}
+	op.Response = resp
+	op.PollInterval = defaultAwaitTransactionInterval
+	return op, nil
+}
+
+// transactionPoller polls the transaction identified by a given id or reference; network
+// errors, rate limiting and server errors are treated as transient, while any other error
+// (i.e., an unknown reference or revoked authorization) fails the poll
+func transactionPoller(token string) api.OperationPoller[Transaction] {
+	return func(ctx context.Context, ref string) (*Transaction, bool, error) {
+		uri := fmt.Sprintf("transactions/%s", ref)
+		status, resp, err := InitNChainService(token).Get(uri, map[string]interface{}{})
+		if err != nil || status != 200 {
+			if api.TransientStatus(status) {
+				common.Log.Debugf("failed to resolve awaited transaction %s; status: %v", ref, status)
+				return nil, false, nil
+			}
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to fetch tx; status: %v; %s", status, err.Error())
+			}
+			return nil, false, fmt.Errorf("failed to fetch tx; status %v", status)
+		}
+
+		tx, err := api.Decode[Transaction](resp)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to fetch tx; status: %v; %s", status, err.Error())
+		}
+
+		switch common.Deref(tx.Status) {
+		case TxStatusSuccess:
+			return tx, true, nil
+		case TxStatusFailed:
+			return tx, true, ErrTransactionFailed
+		}
+		return tx, false, nil
+	}
+}
+
+// ExecuteContractAndAwait executes the given contract method and awaits finality of the
+// resulting transaction; for read-only methods, the response is returned immediately
+// and the returned transaction is nil
+func ExecuteContractAndAwait(ctx context.Context, token, contractID, method string, params []interface{}, opts map[string]interface{}) (*ContractExecutionResponse, *Transaction, error) {
+	resp, err := ExecuteContractMethod(token, contractID, method, params, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.Reference == nil {
+		return resp, nil, nil
+	}
+
+	tx, err := AwaitTransaction(ctx, token, *resp.Reference, defaultAwaitTransactionInterval)
+	return resp, tx, err
+}
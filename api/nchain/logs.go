@@ -0,0 +1,192 @@
+package nchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ABI parses the ABI from the compiled artifact stored in the contract params
+func (c *Contract) ABI() (*abi.ABI, error) {
+	if c.Params == nil {
+		return nil, fmt.Errorf("failed to resolve ABI for contract %s; no params", c.ID)
+	}
+
+	var params struct {
+		ABI              interface{}       `json:"abi"`
+		CompiledArtifact *CompiledArtifact `json:"compiled_artifact"`
+	}
+	err := json.Unmarshal(*c.Params, &params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve ABI for contract %s; %s", c.ID, err.Error())
+	}
+
+	abiRaw := params.ABI
+	if params.CompiledArtifact != nil && params.CompiledArtifact.ABI != nil {
+		abiRaw = params.CompiledArtifact.ABI
+	}
+	if abiRaw == nil {
+		return nil, fmt.Errorf("failed to resolve ABI for contract %s; no ABI", c.ID)
+	}
+
+	raw, _ := json.Marshal(abiRaw)
+	contractABI, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI for contract %s; %s", c.ID, err.Error())
+	}
+
+	return &contractABI, nil
+}
+
+// ReceiptLogs returns the logs from the transaction receipt, if the receipt has been populated
+func (t *Transaction) ReceiptLogs() ([]*TxLog, error) {
+	if t.Receipt == nil {
+		return nil, nil
+	}
+
+	var receipt struct {
+		Logs []*TxLog `json:"logs"`
+	}
+	err := json.Unmarshal(*t.Receipt, &receipt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tx receipt logs; %s", err.Error())
+	}
+
+	return receipt.Logs, nil
+}
+
+// DecodeLogs decodes the given logs using the given contract ABI; logs emitted by events
+// which are not present in the ABI are returned undecoded
+func DecodeLogs(contractABI *abi.ABI, logs []*TxLog) ([]*DecodedTxLog, error) {
+	decoded := make([]*DecodedTxLog, 0)
+	for _, log := range logs {
+		decodedLog, err := decodeLog(contractABI, log)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, decodedLog)
+	}
+	return decoded, nil
+}
+
+func decodeLog(contractABI *abi.ABI, log *TxLog) (*DecodedTxLog, error) {
+	decoded := &DecodedTxLog{TxLog: log}
+	if contractABI == nil || len(log.Topics) == 0 {
+		return decoded, nil
+	}
+
+	event, err := contractABI.EventByID(common.HexToHash(log.Topics[0]))
+	if err != nil {
+		return decoded, nil // not an event described by the ABI
+	}
+
+	values := map[string]interface{}{}
+
+	data, err := hexutil.Decode(log.Data)
+	if err != nil && log.Data != "" && log.Data != "0x" {
+		return nil, fmt.Errorf("failed to decode %s log data; %s", event.Name, err.Error())
+	}
+	err = event.Inputs.NonIndexed().UnpackIntoMap(values, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s log data; %s", event.Name, err.Error())
+	}
+
+	indexed := make(abi.Arguments, 0)
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	topics := make([]common.Hash, 0)
+	for _, topic := range log.Topics[1:] {
+		topics = append(topics, common.HexToHash(topic))
+	}
+	err = abi.ParseTopicsIntoMap(values, indexed, topics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s log topics; %s", event.Name, err.Error())
+	}
+
+	decoded.Event = &event.Name
+	decoded.Signature = &event.Sig
+	decoded.Values = values
+	return decoded, nil
+}
+
+// DecodeTransactionLogs decodes the receipt logs of the given transaction which were emitted
+// by the given contract, populating DecodedLogs on the transaction
+func DecodeTransactionLogs(tx *Transaction, contract *Contract) error {
+	contractABI, err := contract.ABI()
+	if err != nil {
+		return err
+	}
+
+	logs, err := tx.ReceiptLogs()
+	if err != nil {
+		return err
+	}
+
+	tx.DecodedLogs = make([]*DecodedTxLog, 0)
+	for _, log := range logs {
+		logABI := contractABI
+		if contract.Address == nil || !strings.EqualFold(log.Address, *contract.Address) {
+			logABI = nil
+		}
+
+		decoded, err := decodeLog(logABI, log)
+		if err != nil {
+			return err
+		}
+		tx.DecodedLogs = append(tx.DecodedLogs, decoded)
+	}
+
+	return nil
+}
+
+// ListContractTransactions lists transactions, decoding the logs emitted by the given contract
+// using its stored ABI
+func ListContractTransactions(token, contractID string, params map[string]interface{}) ([]*Transaction, error) {
+	contract, err := GetContractDetails(token, contractID, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := ListTransactions(token, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		err = DecodeTransactionLogs(tx, contract)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return txs, nil
+}
+
+// GetContractTransactionDetails fetches the given transaction, decoding the logs emitted by
+// the given contract using its stored ABI
+func GetContractTransactionDetails(token, contractID, txID string, params map[string]interface{}) (*Transaction, error) {
+	contract, err := GetContractDetails(token, contractID, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := GetTransactionDetails(token, txID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	err = DecodeTransactionLogs(tx, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
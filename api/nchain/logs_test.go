@@ -0,0 +1,72 @@
+package nchain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const erc20TransferABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"}]`
+
+func TestDecodeTransactionLogs(t *testing.T) {
+	params := json.RawMessage(`{"compiled_artifact":{"abi":` + erc20TransferABI + `}}`)
+	address := "0x5fbdb2315678afecb367f032d93f642f64180aa3"
+	contract := &Contract{
+		Address: &address,
+		Params:  &params,
+	}
+
+	receipt := json.RawMessage(`{"logs":[{
+		"address":"0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		"topics":[
+			"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0x000000000000000000000000f39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+			"0x00000000000000000000000070997970c51812dc3a010c7d01b50e0d17dc79c8"
+		],
+		"data":"0x00000000000000000000000000000000000000000000000000000000000003e8",
+		"blockNumber":"0x2",
+		"transactionIndex":"0x0",
+		"logIndex":"0x0",
+		"removed":false
+	},{
+		"address":"0x0000000000000000000000000000000000000001",
+		"topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],
+		"data":"0x",
+		"blockNumber":"0x2",
+		"transactionIndex":"0x0",
+		"logIndex":"0x1",
+		"removed":false
+	}]}`)
+	tx := &Transaction{Receipt: &receipt}
+
+	err := DecodeTransactionLogs(tx, contract)
+	if err != nil {
+		t.Fatalf("failed to decode tx logs; %s", err.Error())
+	}
+
+	if len(tx.DecodedLogs) != 2 {
+		t.Fatalf("expected 2 decoded logs; got %d", len(tx.DecodedLogs))
+	}
+
+	transfer := tx.DecodedLogs[0]
+	if transfer.Event == nil || *transfer.Event != "Transfer" {
+		t.Fatalf("expected Transfer event; got %v", transfer.Event)
+	}
+	if transfer.Values["value"].(interface{ String() string }).String() != "1000" {
+		t.Errorf("expected transfer value of 1000; got %v", transfer.Values["value"])
+	}
+	if !strings.EqualFold(transfer.Values["to"].(interface{ Hex() string }).Hex(), "0x70997970c51812dc3a010c7d01b50e0d17dc79c8") {
+		t.Errorf("unexpected transfer recipient %v", transfer.Values["to"])
+	}
+
+	if tx.DecodedLogs[1].Event != nil {
+		t.Errorf("expected log emitted by another contract to remain undecoded")
+	}
+}
+
+func TestContractABIWithoutParams(t *testing.T) {
+	_, err := (&Contract{}).ABI()
+	if err == nil {
+		t.Errorf("expected error resolving ABI without params")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	uuid "github.com/kthomas/go.uuid"
 	"github.com/provideplatform/provide-go/api"
 )
@@ -36,6 +37,17 @@ type Account struct {
 	AccessedAt *time.Time `json:"accessed_at,omitempty"`
 }
 
+// AccountBalance is the balance of an account, denominated in the native currency of
+// its network or in the given token
+type AccountBalance struct {
+	AccountID *uuid.UUID `json:"account_id,omitempty"`
+	TokenID   *uuid.UUID `json:"token_id,omitempty"`
+	Address   *string    `json:"address,omitempty"`
+	Balance   *big.Int   `json:"balance"`
+	Decimals  *uint64    `json:"decimals,omitempty"`
+	Symbol    *string    `json:"symbol,omitempty"`
+}
+
 // CompiledArtifact represents compiled sourcecode
 type CompiledArtifact struct {
 	Name        string          `json:"name"`
@@ -108,33 +120,75 @@ type TxReceipt struct {
 	Logs              []interface{}  `json:"logs"`
 }
 
+// TxLog is a log emitted during the execution of a transaction
+type TxLog struct {
+	Address          string         `json:"address"`
+	Topics           []string       `json:"topics"`
+	Data             string         `json:"data"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	BlockHash        *string        `json:"blockHash,omitempty"`
+	TxHash           *string        `json:"transactionHash,omitempty"`
+	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
+	LogIndex         hexutil.Uint   `json:"logIndex"`
+	Removed          bool           `json:"removed"`
+}
+
+// DecodedTxLog is a transaction log decoded using the ABI of the contract which emitted it;
+// Event is nil when the log could not be matched to an event in the ABI
+type DecodedTxLog struct {
+	*TxLog
+
+	Event     *string                `json:"event,omitempty"`
+	Signature *string                `json:"signature,omitempty"`
+	Values    map[string]interface{} `json:"values,omitempty"`
+}
+
 // EthereumTxTraceResponse is returned upon successful contract execution
 type EthereumTxTraceResponse struct {
-	Result []struct {
-		Action struct {
-			CallType *string `json:"callType"`
-			From     *string `json:"from"`
-			Gas      *string `json:"gas"`
-			Init     *string `json:"init"`
-			Input    *string `json:"input"`
-			To       *string `json:"to"`
-			Value    *string `json:"value"`
-		} `json:"action"`
-		BlockHash   *string `json:"blockHash"`
-		BlockNumber int     `json:"blockNumber"`
-		Result      struct {
-			Address *string `json:"address"`
-			Code    *string `json:"code"`
-			GasUsed *string `json:"gasUsed"`
-			Output  *string `json:"output"`
-		} `json:"result"`
-		Error               *string       `json:"error"`
-		Subtraces           int           `json:"subtraces"`
-		TraceAddress        []interface{} `json:"traceAddress"`
-		TransactionHash     *string       `json:"transactionHash"`
-		TransactionPosition int           `json:"transactionPosition"`
-		Type                *string       `json:"type"`
-	} `json:"result"`
+	Result []*Trace `json:"result"`
+}
+
+// Trace is a single parity-style trace; see TraceTypeCall, TraceTypeCreate, TraceTypeSuicide and TraceTypeReward
+type Trace struct {
+	Action              *TraceAction `json:"action"`
+	BlockHash           *string      `json:"blockHash"`
+	BlockNumber         int          `json:"blockNumber"`
+	Result              *TraceResult `json:"result"`
+	Error               *string      `json:"error"`
+	Subtraces           int          `json:"subtraces"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     *string      `json:"transactionHash"`
+	TransactionPosition int          `json:"transactionPosition"`
+	Type                *string      `json:"type"`
+}
+
+// TraceAction is the action of a trace; which fields are populated depends on the trace type
+type TraceAction struct {
+	// call and create
+	CallType *string `json:"callType,omitempty"`
+	From     *string `json:"from,omitempty"`
+	Gas      *string `json:"gas,omitempty"`
+	Init     *string `json:"init,omitempty"`
+	Input    *string `json:"input,omitempty"`
+	To       *string `json:"to,omitempty"`
+	Value    *string `json:"value,omitempty"`
+
+	// suicide
+	Address       *string `json:"address,omitempty"`
+	Balance       *string `json:"balance,omitempty"`
+	RefundAddress *string `json:"refundAddress,omitempty"`
+
+	// reward
+	Author     *string `json:"author,omitempty"`
+	RewardType *string `json:"rewardType,omitempty"`
+}
+
+// TraceResult is the result of a call or create trace
+type TraceResult struct {
+	Address *string `json:"address,omitempty"`
+	Code    *string `json:"code,omitempty"`
+	GasUsed *string `json:"gasUsed,omitempty"`
+	Output  *string `json:"output,omitempty"`
 }
 
 // ContractExecutionResponse is a response from the contract execution call
@@ -178,9 +232,104 @@ type Network struct {
 	Config        *json.RawMessage `json:"config,omitempty"`
 }
 
+// LoadBalancer distributes JSON-RPC and websocket traffic across the nodes of a network
+type LoadBalancer struct {
+	api.Model
+
+	NetworkID      *uuid.UUID `json:"network_id,omitempty"`
+	ApplicationID  *uuid.UUID `json:"application_id,omitempty"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+
+	Name        *string                `json:"name"`
+	Type        *string                `json:"type,omitempty"`
+	Host        *string                `json:"host,omitempty"`
+	IPv4        *string                `json:"ipv4,omitempty"`
+	IPv6        *string                `json:"ipv6,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	Region      *string                `json:"region,omitempty"`
+	Status      *string                `json:"status,omitempty"`
+	Config      map[string]interface{} `json:"config,omitempty"`
+}
+
+// Node is a peer, validator or full node provisioned on a network
+type Node struct {
+	api.Model
+
+	NetworkID      *uuid.UUID `json:"network_id,omitempty"`
+	ApplicationID  *uuid.UUID `json:"application_id,omitempty"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+
+	Bootnode    bool                   `json:"is_bootnode"`
+	Host        *string                `json:"host,omitempty"`
+	IPv4        *string                `json:"ipv4,omitempty"`
+	IPv6        *string                `json:"ipv6,omitempty"`
+	PrivateIPv4 *string                `json:"private_ipv4,omitempty"`
+	PrivateIPv6 *string                `json:"private_ipv6,omitempty"`
+	Description *string                `json:"description,omitempty"`
+	Role        *string                `json:"role,omitempty"` // i.e., peer, full, validator
+	Status      *string                `json:"status,omitempty"`
+	Config      map[string]interface{} `json:"config,omitempty"`
+}
+
+// NodeLog is a single log event emitted by a node
+type NodeLog struct {
+	Message     string `json:"message"`
+	Timestamp   *int64 `json:"timestamp,omitempty"`
+	IngestedAt  *int64 `json:"ingest_timestamp,omitempty"`
+	EventTypeID *int64 `json:"event_type_id,omitempty"`
+}
+
+// NodeLogs is a page of log events emitted by a node
+type NodeLogs struct {
+	Logs      []*NodeLog `json:"logs"`
+	NextToken *string    `json:"next_token,omitempty"`
+	PrevToken *string    `json:"prev_token,omitempty"`
+}
+
+// NetworkConfig is the typed config of a network
+type NetworkConfig struct {
+	BlockExplorerURL *string `json:"block_explorer_url,omitempty"`
+	JSONRPCURL       *string `json:"json_rpc_url,omitempty"`
+	WebsocketURL     *string `json:"websocket_url,omitempty"`
+
+	Chain          *string `json:"chain,omitempty"` // i.e., mainnet, ropsten, etc
+	NativeCurrency *string `json:"native_currency,omitempty"`
+	Platform       *string `json:"platform,omitempty"` // i.e., evm, bcoin
+	NetworkID      *uint64 `json:"network_id,omitempty"`
+
+	// Consensus parameters
+	ProtocolID      *string                `json:"protocol_id,omitempty"` // i.e., poa, pos, pow
+	EngineID        *string                `json:"engine_id,omitempty"`   // i.e., aura, clique, ethash
+	BlockTime       *uint64                `json:"block_time,omitempty"`  // target block time in seconds
+	Validators      []string               `json:"validators,omitempty"`
+	ConsensusParams map[string]interface{} `json:"consensus,omitempty"`
+
+	// Chainspec (or genesis) used to bootstrap the network
+	Chainspec       json.RawMessage `json:"chainspec,omitempty"`
+	ChainspecURL    *string         `json:"chainspec_url,omitempty"`
+	ChainspecABI    json.RawMessage `json:"chainspec_abi,omitempty"`
+	ChainspecABIURL *string         `json:"chainspec_abi_url,omitempty"`
+
+	Cloneable       bool `json:"cloneable"`
+	EthereumNetwork bool `json:"is_ethereum_network,omitempty"`
+
+	Security *NetworkSecurityConfig `json:"security,omitempty"`
+
+	// Raw contains additional config which is not otherwise represented by the typed config
+	Raw map[string]interface{} `json:"-"`
+}
+
+// NetworkSecurityConfig contains the ingress and egress rules applied to network nodes
+type NetworkSecurityConfig struct {
+	Egress  interface{}            `json:"egress,omitempty"`
+	Ingress map[string]interface{} `json:"ingress,omitempty"` // i.e., {"0.0.0.0/0": {"tcp": [8545, 8546], "udp": [30303]}}
+}
+
 // NetworkStatus provides network-agnostic status
 type NetworkStatus struct {
 	Block           uint64                 `json:"block,omitempty"`            // current block
+	FinalizedBlock  *uint64                `json:"finalized_block,omitempty"`  // latest finalized block; only reported by networks with finality, i.e. post-merge
 	ChainID         *string                `json:"chain_id,omitempty"`         // the chain id
 	Height          *uint64                `json:"height,omitempty"`           // total height of the blockchain; null after syncing completed
 	LastBlockAt     *uint64                `json:"last_block_at,omitempty"`    // unix timestamp of the last block; i.e., when the last block was collated
@@ -191,6 +340,20 @@ type NetworkStatus struct {
 	Meta            map[string]interface{} `json:"meta,omitempty"`             // network-specific metadata
 }
 
+// KeyCustody describes the vault key backing a managed signing identity; keys which are
+// not managed by vault are custodied by the application or user
+type KeyCustody struct {
+	AccountID *uuid.UUID `json:"account_id,omitempty"`
+	WalletID  *uuid.UUID `json:"wallet_id,omitempty"`
+	VaultID   *uuid.UUID `json:"vault_id,omitempty"`
+	KeyID     *uuid.UUID `json:"key_id,omitempty"`
+
+	HDDerivationPath *string `json:"hd_derivation_path,omitempty"`
+	PublicKey        *string `json:"public_key,omitempty"`
+
+	Managed bool `json:"managed"`
+}
+
 // Oracle instances are smart contracts whose terms are fulfilled
 // writing data from a configured feed onto the blockchain
 type Oracle struct {
@@ -206,6 +369,16 @@ type Oracle struct {
 	AttachmentIds []*uuid.UUID     `json:"attachment_ids"`
 }
 
+// PriceFeed is the latest price published by a base/quote price feed
+type PriceFeed struct {
+	Base      string     `json:"base"`
+	Quote     string     `json:"quote"`
+	Price     float64    `json:"price"`
+	OracleID  *uuid.UUID `json:"oracle_id,omitempty"`
+	Source    *string    `json:"source,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
 // Token contract
 type Token struct {
 	api.Model
@@ -251,7 +424,11 @@ type Transaction struct {
 	Description *string          `json:"description"`
 
 	// Ephemeral fields for managing the tx/rx and tracing lifecycles
-	Traces interface{} `json:"traces,omitempty"`
+	Traces  interface{}      `json:"traces,omitempty"`
+	Receipt *json.RawMessage `json:"receipt,omitempty"`
+
+	// DecodedLogs are the receipt logs decoded using the stored contract ABI; see DecodeTransactionLogs
+	DecodedLogs []*DecodedTxLog `json:"decoded_logs,omitempty"`
 
 	// Transaction metadata/instrumentation
 	Block          *uint64    `json:"block"`
@@ -353,3 +530,9 @@ type Wallet struct {
 	PublicKey  *string `json:"public_key,omitempty"`
 	PrivateKey *string `json:"private_key,omitempty"`
 }
+
+// NetworkStatusProvider is satisfied by any backend capable of reporting network status; applications
+// may use the hosted nchain API (see NewNetworkStatusProvider) or direct JSON-RPC interchangeably
+type NetworkStatusProvider interface {
+	Status() (*NetworkStatus, error)
+}
@@ -0,0 +1,164 @@
+package nchain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultStatusMonitorInterval = time.Second * 15
+const defaultStatusMonitorBufferSize = 32
+
+const (
+	// StatusEventSyncStateChanged is emitted when the syncing flag or state of the network changes
+	StatusEventSyncStateChanged = "sync_state_changed"
+
+	// StatusEventHeadAdvanced is emitted when the current block advances
+	StatusEventHeadAdvanced = "head_advanced"
+
+	// StatusEventPeerCountLow is emitted when the peer count drops below the configured threshold
+	StatusEventPeerCountLow = "peer_count_low"
+
+	// StatusEventError is emitted when the network status could not be resolved
+	StatusEventError = "error"
+)
+
+// StatusEvent describes a change in network status observed by a StatusMonitor
+type StatusEvent struct {
+	Type     string         `json:"type"`
+	Previous *NetworkStatus `json:"previous,omitempty"`
+	Current  *NetworkStatus `json:"current,omitempty"`
+	Error    error          `json:"-"`
+}
+
+// StatusMonitorOptions configures a StatusMonitor
+type StatusMonitorOptions struct {
+	// Interval between polls; defaults to 15 seconds
+	Interval time.Duration
+
+	// MinPeerCount is the threshold below which StatusEventPeerCountLow is emitted; 0 disables the event
+	MinPeerCount uint64
+
+	// BufferSize of the events channel; events are dropped when the buffer is full
+	BufferSize int
+}
+
+// StatusMonitor polls a NetworkStatusProvider on an interval, caches the latest status for
+// cheap reads and emits change events; it is itself a NetworkStatusProvider
+type StatusMonitor struct {
+	provider NetworkStatusProvider
+	opts     StatusMonitorOptions
+
+	mutex  sync.RWMutex
+	latest *NetworkStatus
+	err    error
+
+	events chan *StatusEvent
+}
+
+// NewStatusMonitor initializes a StatusMonitor for the given provider
+func NewStatusMonitor(provider NetworkStatusProvider, opts *StatusMonitorOptions) *StatusMonitor {
+	monitor := &StatusMonitor{provider: provider}
+	if opts != nil {
+		monitor.opts = *opts
+	}
+	if monitor.opts.Interval <= 0 {
+		monitor.opts.Interval = defaultStatusMonitorInterval
+	}
+	if monitor.opts.BufferSize <= 0 {
+		monitor.opts.BufferSize = defaultStatusMonitorBufferSize
+	}
+	monitor.events = make(chan *StatusEvent, monitor.opts.BufferSize)
+	return monitor
+}
+
+// Events returns the channel on which change events are emitted; it is closed when Run returns
+func (m *StatusMonitor) Events() <-chan *StatusEvent {
+	return m.events
+}
+
+// Status returns the cached network status, polling the provider if no status has been cached
+func (m *StatusMonitor) Status() (*NetworkStatus, error) {
+	m.mutex.RLock()
+	latest, err := m.latest, m.err
+	m.mutex.RUnlock()
+
+	if latest == nil && err == nil {
+		m.poll()
+		m.mutex.RLock()
+		latest, err = m.latest, m.err
+		m.mutex.RUnlock()
+	}
+	return latest, err
+}
+
+// Run polls the provider until the given context is done
+func (m *StatusMonitor) Run(ctx context.Context) {
+	defer close(m.events)
+
+	ticker := time.NewTicker(m.opts.Interval)
+	defer ticker.Stop()
+
+	m.poll()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.poll()
+		}
+	}
+}
+
+func (m *StatusMonitor) poll() {
+	status, err := m.provider.Status()
+
+	m.mutex.Lock()
+	previous := m.latest
+	if err == nil {
+		m.latest = status
+	}
+	m.err = err
+	m.mutex.Unlock()
+
+	if err != nil {
+		m.emit(&StatusEvent{Type: StatusEventError, Previous: previous, Error: err})
+		return
+	}
+
+	for _, evt := range statusEvents(previous, status, m.opts.MinPeerCount) {
+		m.emit(evt)
+	}
+}
+
+func (m *StatusMonitor) emit(evt *StatusEvent) {
+	select {
+	case m.events <- evt:
+	default:
+		common.Log.Debugf("dropped %s network status event; buffer full", evt.Type)
+	}
+}
+
+// statusEvents returns the events describing the transition between the given statuses
+func statusEvents(previous, current *NetworkStatus, minPeerCount uint64) []*StatusEvent {
+	events := make([]*StatusEvent, 0)
+	if current == nil {
+		return events
+	}
+
+	if previous == nil || previous.Syncing != current.Syncing || common.Deref(previous.State) != common.Deref(current.State) {
+		events = append(events, &StatusEvent{Type: StatusEventSyncStateChanged, Previous: previous, Current: current})
+	}
+
+	if previous != nil && current.Block > previous.Block {
+		events = append(events, &StatusEvent{Type: StatusEventHeadAdvanced, Previous: previous, Current: current})
+	}
+
+	if minPeerCount > 0 && current.PeerCount < minPeerCount && (previous == nil || previous.PeerCount >= minPeerCount) {
+		events = append(events, &StatusEvent{Type: StatusEventPeerCountLow, Previous: previous, Current: current})
+	}
+
+	return events
+}
@@ -0,0 +1,74 @@
+package nchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+type fakeStatusProvider struct {
+	statuses []*NetworkStatus
+	err      error
+}
+
+func (p *fakeStatusProvider) Status() (*NetworkStatus, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	status := p.statuses[0]
+	if len(p.statuses) > 1 {
+		p.statuses = p.statuses[1:]
+	}
+	return status, nil
+}
+
+func drainStatusEvents(m *StatusMonitor) []string {
+	types := make([]string, 0)
+	for {
+		select {
+		case evt := <-m.Events():
+			types = append(types, evt.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func TestStatusMonitorEvents(t *testing.T) {
+	provider := &fakeStatusProvider{
+		statuses: []*NetworkStatus{
+			{Block: 1, Syncing: true, State: common.StringOrNil("syncing"), PeerCount: 5},
+			{Block: 2, State: common.StringOrNil("synced"), PeerCount: 5},
+			{Block: 2, State: common.StringOrNil("synced"), PeerCount: 1},
+		},
+	}
+	monitor := NewStatusMonitor(provider, &StatusMonitorOptions{MinPeerCount: 3})
+
+	monitor.poll()
+	if evts := drainStatusEvents(monitor); len(evts) != 1 || evts[0] != StatusEventSyncStateChanged {
+		t.Errorf("unexpected initial events %v", evts)
+	}
+
+	monitor.poll()
+	evts := drainStatusEvents(monitor)
+	if len(evts) != 2 || evts[0] != StatusEventSyncStateChanged || evts[1] != StatusEventHeadAdvanced {
+		t.Errorf("unexpected events after sync %v", evts)
+	}
+
+	monitor.poll()
+	if evts := drainStatusEvents(monitor); len(evts) != 1 || evts[0] != StatusEventPeerCountLow {
+		t.Errorf("unexpected events after peer drop %v", evts)
+	}
+
+	status, err := monitor.Status()
+	if err != nil || status.PeerCount != 1 {
+		t.Errorf("expected cached status to reflect latest poll")
+	}
+
+	provider.err = errors.New("unreachable")
+	monitor.poll()
+	if evts := drainStatusEvents(monitor); len(evts) != 1 || evts[0] != StatusEventError {
+		t.Errorf("unexpected events after error %v", evts)
+	}
+}
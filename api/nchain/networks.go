@@ -0,0 +1,131 @@
+package nchain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// NetworkProtocolPoA is the proof-of-authority consensus protocol
+const NetworkProtocolPoA = "poa"
+
+// NetworkProtocolPoS is the proof-of-stake consensus protocol
+const NetworkProtocolPoS = "pos"
+
+// NetworkProtocolPoW is the proof-of-work consensus protocol
+const NetworkProtocolPoW = "pow"
+
+// ParseConfig parses the typed network config from the raw network config
+func (n *Network) ParseConfig() (*NetworkConfig, error) {
+	cfg := &NetworkConfig{}
+	if n.Config == nil {
+		return cfg, nil
+	}
+
+	err := json.Unmarshal(*n.Config, &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse network config; %s", err.Error())
+	}
+
+	// retain the raw config so it survives a round trip through UpdateNetworkConfig
+	json.Unmarshal(*n.Config, &cfg.Raw)
+
+	return cfg, nil
+}
+
+// Params returns the network config as params suitable for creating or updating a network
+func (cfg *NetworkConfig) Params() map[string]interface{} {
+	params := map[string]interface{}{}
+	raw, _ := json.Marshal(cfg)
+	json.Unmarshal(raw, &params)
+	for key, val := range cfg.Raw {
+		if _, ok := params[key]; !ok {
+			params[key] = val
+		}
+	}
+	return params
+}
+
+// CreateNetworkWithConfig creates a new network using the given typed config
+func CreateNetworkWithConfig(token, name string, cfg *NetworkConfig, params map[string]interface{}) (*Network, error) {
+	if cfg == nil {
+		return nil, errors.New("failed to create network; config is required")
+	}
+
+	networkParams := map[string]interface{}{}
+	for key, val := range params {
+		networkParams[key] = val
+	}
+	networkParams["name"] = name
+	networkParams["config"] = cfg.Params()
+
+	return CreateNetwork(token, networkParams)
+}
+
+// BootstrapNetwork creates a new network from the given chainspec (or genesis) JSON; when
+// parentNetworkID is provided, the network is bootstrapped as a clone of the parent network,
+// which must be cloneable
+func BootstrapNetwork(token, name string, parentNetworkID *string, chainspec json.RawMessage, cfg *NetworkConfig) (*Network, error) {
+	if len(chainspec) == 0 && parentNetworkID == nil {
+		return nil, errors.New("failed to bootstrap network; chainspec or parent network is required")
+	}
+
+	if cfg == nil {
+		cfg = &NetworkConfig{}
+	}
+
+	if parentNetworkID != nil {
+		parent, err := GetNetworkDetails(token, *parentNetworkID, map[string]interface{}{})
+		if err != nil {
+			return nil, err
+		}
+
+		parentCfg, err := parent.ParseConfig()
+		if err != nil {
+			return nil, err
+		}
+
+		if !parentCfg.Cloneable {
+			return nil, fmt.Errorf("failed to bootstrap network; parent network %s is not cloneable", *parentNetworkID)
+		}
+
+		if len(chainspec) == 0 {
+			chainspec = parentCfg.Chainspec
+		}
+		if cfg.ChainspecABI == nil {
+			cfg.ChainspecABI = parentCfg.ChainspecABI
+		}
+		if cfg.ProtocolID == nil {
+			cfg.ProtocolID = parentCfg.ProtocolID
+		}
+		if cfg.EngineID == nil {
+			cfg.EngineID = parentCfg.EngineID
+		}
+		if cfg.Platform == nil {
+			cfg.Platform = parentCfg.Platform
+		}
+		if cfg.NativeCurrency == nil {
+			cfg.NativeCurrency = parentCfg.NativeCurrency
+		}
+	}
+
+	cfg.Chainspec = chainspec
+
+	params := map[string]interface{}{}
+	if parentNetworkID != nil {
+		params["network_id"] = *parentNetworkID
+	}
+
+	return CreateNetworkWithConfig(token, name, cfg, params)
+}
+
+// UpdateNetworkConfig replaces the config of the given network with the given typed config
+func UpdateNetworkConfig(token, networkID string, cfg *NetworkConfig) error {
+	if cfg == nil {
+		return errors.New("failed to update network config; config is required")
+	}
+
+	return UpdateNetwork(token, networkID, map[string]interface{}{
+		"config": cfg.Params(),
+	})
+}
@@ -0,0 +1,181 @@
+package nchain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+// CreateNetworkNode provisions a new node on the given network; the node is deployed to the
+// target infrastructure described by the config in params (i.e., provider, region, role)
+func CreateNetworkNode(token, networkID string, params map[string]interface{}) (*Node, error) {
+	uri := fmt.Sprintf("networks/%s/nodes", networkID)
+	status, resp, err := InitNChainService(token).Post(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to create node; status: %v", status)
+	}
+
+	node := &Node{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node; status: %v; %s", status, err.Error())
+	}
+
+	return node, nil
+}
+
+// ListNetworkNodes lists the nodes provisioned on the given network
+func ListNetworkNodes(token, networkID string, params map[string]interface{}) ([]*Node, error) {
+	uri := fmt.Sprintf("networks/%s/nodes", networkID)
+	status, resp, err := InitNChainService(token).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list nodes; status: %v", status)
+	}
+
+	nodes, err := api.DecodeList[Node](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes; status: %v; %s", status, err.Error())
+	}
+	return nodes, nil
+}
+
+// GetNetworkNodeDetails fetches the details of the given network node
+func GetNetworkNodeDetails(token, networkID, nodeID string, params map[string]interface{}) (*Node, error) {
+	uri := fmt.Sprintf("networks/%s/nodes/%s", networkID, nodeID)
+	status, resp, err := InitNChainService(token).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch node; status: %v", status)
+	}
+
+	node := &Node{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch node; status: %v; %s", status, err.Error())
+	}
+
+	return node, nil
+}
+
+// GetNetworkNodeLogs fetches a page of logs emitted by the given network node; the next
+// page may be fetched by providing the returned NextToken as the next_token param
+func GetNetworkNodeLogs(token, networkID, nodeID string, params map[string]interface{}) (*NodeLogs, error) {
+	uri := fmt.Sprintf("networks/%s/nodes/%s/logs", networkID, nodeID)
+	status, resp, err := InitNChainService(token).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch node logs; status: %v", status)
+	}
+
+	logs := &NodeLogs{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &logs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch node logs; status: %v; %s", status, err.Error())
+	}
+
+	return logs, nil
+}
+
+// RestartNetworkNode restarts the given network node
+func RestartNetworkNode(token, networkID, nodeID string) error {
+	uri := fmt.Sprintf("networks/%s/nodes/%s/restart", networkID, nodeID)
+	status, _, err := InitNChainService(token).Post(uri, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	if status != 202 && status != 204 {
+		return fmt.Errorf("failed to restart node; status: %v", status)
+	}
+
+	return nil
+}
+
+// DeleteNetworkNode undeploys and deletes the given network node
+func DeleteNetworkNode(token, networkID, nodeID string) error {
+	uri := fmt.Sprintf("networks/%s/nodes/%s", networkID, nodeID)
+	status, _, err := InitNChainService(token).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete node; status: %v", status)
+	}
+
+	return nil
+}
+
+// CreateLoadBalancer provisions a new load balancer for the given network
+func CreateLoadBalancer(token, networkID string, params map[string]interface{}) (*LoadBalancer, error) {
+	uri := fmt.Sprintf("networks/%s/load_balancers", networkID)
+	status, resp, err := InitNChainService(token).Post(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to create load balancer; status: %v", status)
+	}
+
+	balancer := &LoadBalancer{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &balancer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create load balancer; status: %v; %s", status, err.Error())
+	}
+
+	return balancer, nil
+}
+
+// ListLoadBalancers lists the load balancers provisioned for the given network
+func ListLoadBalancers(token, networkID string, params map[string]interface{}) ([]*LoadBalancer, error) {
+	uri := fmt.Sprintf("networks/%s/load_balancers", networkID)
+	status, resp, err := InitNChainService(token).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list load balancers; status: %v", status)
+	}
+
+	balancers, err := api.DecodeList[LoadBalancer](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list load balancers; status: %v; %s", status, err.Error())
+	}
+	return balancers, nil
+}
+
+// UpdateLoadBalancer updates the given load balancer (i.e., its config or the nodes in rotation)
+func UpdateLoadBalancer(token, networkID, loadBalancerID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("networks/%s/load_balancers/%s", networkID, loadBalancerID)
+	status, _, err := InitNChainService(token).Put(uri, params)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to update load balancer; status: %v", status)
+	}
+
+	return nil
+}
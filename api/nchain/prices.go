@@ -0,0 +1,96 @@
+package nchain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/provideplatform/provide-go/api"
+)
+
+// OracleTypePriceFeed is the type of oracle which publishes an exchange rate from its feed
+const OracleTypePriceFeed = "price_feed"
+
+// CreatePriceFeedOracle registers an oracle which publishes the base/quote exchange rate
+// read from the given feed url to the given oracle contract
+func CreatePriceFeedOracle(token, networkID, contractID, name, feedURL, base, quote string, params map[string]interface{}) (*Oracle, error) {
+	oracleParams := map[string]interface{}{}
+	for key, val := range params {
+		oracleParams[key] = val
+	}
+	oracleParams["type"] = OracleTypePriceFeed
+	oracleParams["base"] = strings.ToUpper(base)
+	oracleParams["quote"] = strings.ToUpper(quote)
+
+	return CreateOracle(token, map[string]interface{}{
+		"network_id":  networkID,
+		"contract_id": contractID,
+		"name":        name,
+		"feed_url":    feedURL,
+		"params":      oracleParams,
+	})
+}
+
+// ListPriceFeeds lists the price feeds available to the caller
+func ListPriceFeeds(token string, params map[string]interface{}) ([]*PriceFeed, error) {
+	status, resp, err := InitNChainService(token).Get("prices", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to list price feeds; status: %v", status)
+	}
+
+	feeds, err := api.DecodeList[PriceFeed](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price feeds; status: %v; %s", status, err.Error())
+	}
+	return feeds, nil
+}
+
+// GetPriceFeed fetches the latest price published by the base/quote price feed
+func GetPriceFeed(token, base, quote string) (*PriceFeed, error) {
+	uri := fmt.Sprintf("prices/%s/%s", strings.ToUpper(base), strings.ToUpper(quote))
+	status, resp, err := InitNChainService(token).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch %s/%s price feed; status: %v", base, quote, status)
+	}
+
+	feed := &PriceFeed{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s/%s price feed; status: %v; %s", base, quote, status, err.Error())
+	}
+
+	return feed, nil
+}
+
+// GetExchangeRate returns the number of units of quote currency per unit of base currency
+// (i.e., GetExchangeRate(token, "ETH", "USD")); when only the inverse feed is available,
+// the rate is derived from it
+func GetExchangeRate(token, base, quote string) (float64, error) {
+	if strings.EqualFold(base, quote) {
+		return 1, nil
+	}
+
+	feed, err := GetPriceFeed(token, base, quote)
+	if err == nil && feed.Price > 0 {
+		return feed.Price, nil
+	}
+
+	inverse, inverseErr := GetPriceFeed(token, quote, base)
+	if inverseErr != nil || inverse.Price <= 0 {
+		if err == nil {
+			err = fmt.Errorf("failed to resolve %s/%s exchange rate; price unavailable", base, quote)
+		}
+		return 0, err
+	}
+
+	return 1 / inverse.Price, nil
+}
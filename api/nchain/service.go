@@ -13,6 +13,8 @@ const defaultNChainHost = "nchain.provide.services"
 const defaultNChainPath = "api/v1"
 const defaultNChainScheme = "https"
 
+const defaultHDWalletPurpose = 44
+
 // Service for the nchain api
 type Service struct {
 	api.Client
@@ -79,12 +81,9 @@ func ListAccounts(token string, params map[string]interface{}) ([]*Account, erro
 		return nil, fmt.Errorf("failed to list accounts; status: %v", status)
 	}
 
-	accounts := make([]*Account, 0)
-	for _, item := range resp.([]interface{}) {
-		account := &Account{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &account)
-		accounts = append(accounts, account)
+	accounts, err := api.DecodeList[Account](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts; status: %v; %s", status, err.Error())
 	}
 	return accounts, nil
 }
@@ -111,10 +110,65 @@ func GetAccountDetails(token, accountID string, params map[string]interface{}) (
 	return account, nil
 }
 
-// GetAccountBalance
-func GetAccountBalance(token, accountID, tokenID string, params map[string]interface{}) (int, interface{}, error) {
+// GetAccountBalance fetches the balance of the given account; when tokenID is empty, the
+// balance of the native currency of the account's network is returned
+func GetAccountBalance(token, accountID, tokenID string, params map[string]interface{}) (*AccountBalance, error) {
 	uri := fmt.Sprintf("accounts/%s/balances/%s", accountID, tokenID)
-	return InitNChainService(token).Get(uri, params)
+	if tokenID == "" {
+		uri = fmt.Sprintf("accounts/%s/balance", accountID)
+	}
+
+	// balances (i.e., in wei) routinely exceed the precision of float64
+	service := InitNChainService(token)
+	service.UseNumber = true
+
+	status, resp, err := service.Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch account balance; status: %v", status)
+	}
+
+	balance := &AccountBalance{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &balance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account balance; status: %v; %s", status, err.Error())
+	}
+
+	return balance, nil
+}
+
+// GetAccountKeyCustody resolves the key custody metadata for the given account; accounts derived
+// from an HD wallet report the custody of the wallet which owns the underlying vault key
+func GetAccountKeyCustody(token, accountID string) (*KeyCustody, error) {
+	account, err := GetAccountDetails(token, accountID, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	custody := &KeyCustody{
+		AccountID:        common.Ptr(account.ID.UUID()),
+		WalletID:         account.WalletID,
+		VaultID:          account.VaultID,
+		KeyID:            account.KeyID,
+		HDDerivationPath: account.HDDerivationPath,
+		PublicKey:        account.PublicKey,
+	}
+
+	if account.WalletID != nil && (custody.VaultID == nil || custody.KeyID == nil) {
+		wallet, err := GetWalletDetails(token, account.WalletID.String(), map[string]interface{}{})
+		if err != nil {
+			return nil, err
+		}
+		custody.VaultID = wallet.VaultID
+		custody.KeyID = wallet.KeyID
+	}
+
+	custody.Managed = custody.VaultID != nil && custody.KeyID != nil
+	return custody, nil
 }
 
 // CreateBridge
@@ -165,12 +219,9 @@ func ListConnectors(token string, params map[string]interface{}) ([]*Connector,
 		return nil, fmt.Errorf("failed to list connectors; status: %v", status)
 	}
 
-	connectors := make([]*Connector, 0)
-	for _, item := range resp.([]interface{}) {
-		connector := &Connector{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &connector)
-		connectors = append(connectors, connector)
+	connectors, err := api.DecodeList[Connector](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connectors; status: %v; %s", status, err.Error())
 	}
 	return connectors, nil
 }
@@ -233,6 +284,21 @@ func CreateContract(token string, params map[string]interface{}) (*Contract, err
 	return contract, nil
 }
 
+// DeleteContract deletes the given contract
+func DeleteContract(token, contractID string) error {
+	uri := fmt.Sprintf("contracts/%s", contractID)
+	status, _, err := InitNChainService(token).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete contract; status: %v", status)
+	}
+
+	return nil
+}
+
 // CreatePublicContract loads an already deployed contract into nchain
 // for arbitrary transaction execution
 // this can be used for org registries, erc20 etc.
@@ -291,12 +357,9 @@ func ListContracts(token string, params map[string]interface{}) ([]*Contract, er
 		return nil, fmt.Errorf("failed to list contracts; status: %v", status)
 	}
 
-	contracts := make([]*Contract, 0)
-	for _, item := range resp.([]interface{}) {
-		contract := &Contract{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &contract)
-		contracts = append(contracts, contract)
+	contracts, err := api.DecodeList[Contract](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts; status: %v; %s", status, err.Error())
 	}
 	return contracts, nil
 }
@@ -372,12 +435,9 @@ func ListNetworks(token string, params map[string]interface{}) ([]*Network, erro
 		return nil, fmt.Errorf("failed to list networks. status: %v", status)
 	}
 
-	networks := make([]*Network, 0)
-	for _, item := range resp.([]interface{}) {
-		netwrk := &Network{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &netwrk)
-		networks = append(networks, netwrk)
+	networks, err := api.DecodeList[Network](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks; status: %v; %s", status, err.Error())
 	}
 	return networks, nil
 }
@@ -416,12 +476,9 @@ func ListNetworkAccounts(token, networkID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to list accounts; status: %v", status)
 	}
 
-	accounts := make([]*Account, 0)
-	for _, item := range resp.([]interface{}) {
-		account := &Account{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &account)
-		accounts = append(accounts, account)
+	accounts, err := api.DecodeList[Account](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts; status: %v; %s", status, err.Error())
 	}
 	return accounts, nil
 }
@@ -450,12 +507,9 @@ func ListNetworkConnectors(token, networkID string, params map[string]interface{
 		return nil, fmt.Errorf("failed to list connectors; status: %v", status)
 	}
 
-	connectors := make([]*Connector, 0)
-	for _, item := range resp.([]interface{}) {
-		connector := &Connector{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &connector)
-		connectors = append(connectors, connector)
+	connectors, err := api.DecodeList[Connector](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connectors; status: %v; %s", status, err.Error())
 	}
 	return connectors, nil
 }
@@ -472,12 +526,9 @@ func ListNetworkContracts(token, networkID string, params map[string]interface{}
 		return nil, fmt.Errorf("failed to list contracts; status: %v", status)
 	}
 
-	contracts := make([]*Contract, 0)
-	for _, item := range resp.([]interface{}) {
-		contract := &Contract{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &contract)
-		contracts = append(contracts, contract)
+	contracts, err := api.DecodeList[Contract](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts; status: %v; %s", status, err.Error())
 	}
 	return contracts, nil
 }
@@ -516,12 +567,9 @@ func ListNetworkOracles(token, networkID string, params map[string]interface{})
 		return nil, fmt.Errorf("failed to list oracles; status: %v", status)
 	}
 
-	oracles := make([]*Oracle, 0)
-	for _, item := range resp.([]interface{}) {
-		oracle := &Oracle{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &oracle)
-		oracles = append(oracles, oracle)
+	oracles, err := api.DecodeList[Oracle](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oracles; status: %v; %s", status, err.Error())
 	}
 	return oracles, nil
 }
@@ -538,12 +586,9 @@ func ListNetworkTokens(token, networkID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list token contracts; status: %v", status)
 	}
 
-	tknContracts := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tknContract := &Token{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tknContract)
-		tknContracts = append(tknContracts, tknContract)
+	tknContracts, err := api.DecodeList[Token](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list token contracts; status: %v; %s", status, err.Error())
 	}
 	return tknContracts, nil
 }
@@ -560,12 +605,9 @@ func ListNetworkTransactions(token, networkID string, params map[string]interfac
 		return nil, fmt.Errorf("failed to list transactions; status: %v", status)
 	}
 
-	txs := make([]*Transaction, 0)
-	for _, item := range resp.([]interface{}) {
-		tx := &Transaction{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tx)
-		txs = append(txs, tx)
+	txs, err := api.DecodeList[Transaction](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions; status: %v; %s", status, err.Error())
 	}
 	return txs, nil
 }
@@ -646,12 +688,9 @@ func ListOracles(token string, params map[string]interface{}) ([]*Oracle, error)
 		return nil, fmt.Errorf("failed to list oracles; status: %v", status)
 	}
 
-	oracles := make([]*Oracle, 0)
-	for _, item := range resp.([]interface{}) {
-		oracle := &Oracle{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &oracle)
-		oracles = append(oracles, oracle)
+	oracles, err := api.DecodeList[Oracle](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oracles; status: %v; %s", status, err.Error())
 	}
 	return oracles, nil
 }
@@ -710,12 +749,9 @@ func ListTokenContracts(token string, params map[string]interface{}) ([]*Token,
 		return nil, fmt.Errorf("failed to list token contracts; status: %v", status)
 	}
 
-	tknContracts := make([]*Token, 0)
-	for _, item := range resp.([]interface{}) {
-		tknContract := &Token{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tknContract)
-		tknContracts = append(tknContracts, tknContract)
+	tknContracts, err := api.DecodeList[Token](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list token contracts; status: %v; %s", status, err.Error())
 	}
 	return tknContracts, nil
 }
@@ -774,12 +810,9 @@ func ListTransactions(token string, params map[string]interface{}) ([]*Transacti
 		return nil, fmt.Errorf("failed to list transactions; status: %v", status)
 	}
 
-	txs := make([]*Transaction, 0)
-	for _, item := range resp.([]interface{}) {
-		tx := &Transaction{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &tx)
-		txs = append(txs, tx)
+	txs, err := api.DecodeList[Transaction](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions; status: %v; %s", status, err.Error())
 	}
 	return txs, nil
 }
@@ -827,6 +860,36 @@ func CreateWallet(token string, params map[string]interface{}) (*Wallet, error)
 	return wallet, nil
 }
 
+// CreateHDWallet creates a new hierarchical deterministic wallet using the given BIP-44 purpose;
+// the wallet seed is generated and custodied by vault unless a mnemonic is provided in params
+func CreateHDWallet(token string, purpose int, params map[string]interface{}) (*Wallet, error) {
+	walletParams := map[string]interface{}{}
+	for key, val := range params {
+		walletParams[key] = val
+	}
+	if purpose == 0 {
+		purpose = defaultHDWalletPurpose
+	}
+	walletParams["purpose"] = purpose
+
+	return CreateWallet(token, walletParams)
+}
+
+// DeriveAccount derives a new account from the given HD wallet on the given network; the next
+// unused address index is derived unless an explicit hd_derivation_path is provided in params
+func DeriveAccount(token, walletID, networkID string, params map[string]interface{}) (*Account, error) {
+	accountParams := map[string]interface{}{}
+	for key, val := range params {
+		accountParams[key] = val
+	}
+	accountParams["wallet_id"] = walletID
+	if networkID != "" {
+		accountParams["network_id"] = networkID
+	}
+
+	return CreateAccount(token, accountParams)
+}
+
 // ListWallets
 func ListWallets(token string, params map[string]interface{}) ([]*Wallet, error) {
 	status, resp, err := InitNChainService(token).Get("wallets", params)
@@ -838,12 +901,9 @@ func ListWallets(token string, params map[string]interface{}) ([]*Wallet, error)
 		return nil, fmt.Errorf("failed to list wallets; status: %v", status)
 	}
 
-	wallets := make([]*Wallet, 0)
-	for _, item := range resp.([]interface{}) {
-		wallet := &Wallet{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &wallet)
-		wallets = append(wallets, wallet)
+	wallets, err := api.DecodeList[Wallet](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets; status: %v; %s", status, err.Error())
 	}
 	return wallets, nil
 }
@@ -882,12 +942,28 @@ func ListWalletAccounts(token, walletID string, params map[string]interface{}) (
 		return nil, fmt.Errorf("failed to list accounts; status: %v", status)
 	}
 
-	accounts := make([]*Account, 0)
-	for _, item := range resp.([]interface{}) {
-		account := &Account{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &account)
-		accounts = append(accounts, account)
+	accounts, err := api.DecodeList[Account](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts; status: %v; %s", status, err.Error())
 	}
 	return accounts, nil
 }
+
+// hostedNetwork resolves network status using the nchain REST API
+type hostedNetwork struct {
+	token     string
+	networkID string
+}
+
+// NewNetworkStatusProvider returns a NetworkStatusProvider which resolves status using the nchain REST API
+func NewNetworkStatusProvider(token, networkID string) NetworkStatusProvider {
+	return &hostedNetwork{
+		token:     token,
+		networkID: networkID,
+	}
+}
+
+// Status implements NetworkStatusProvider
+func (n *hostedNetwork) Status() (*NetworkStatus, error) {
+	return GetNetworkStatusMeta(n.token, n.networkID, map[string]interface{}{})
+}
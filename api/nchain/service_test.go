@@ -0,0 +1,80 @@
+package nchain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetAccountBalancePrecision(t *testing.T) {
+	const wei = "123456789012345678901234567"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"address":"0x01","balance":` + wei + `,"decimals":18,"symbol":"ETH"}`))
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("NCHAIN_API_HOST", srvURL.Host)
+	t.Setenv("NCHAIN_API_SCHEME", srvURL.Scheme)
+
+	balance, err := GetAccountBalance("token", "account", "", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to fetch account balance; %s", err.Error())
+	}
+
+	if balance.Balance == nil || balance.Balance.String() != wei {
+		t.Errorf("expected balance %s; got %v", wei, balance.Balance)
+	}
+}
+
+func TestAwaitTransactionErrors(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/transactions/unauthorized":
+			w.WriteHeader(401)
+			w.Write([]byte(`{}`))
+		case "/api/v1/transactions/ref":
+			switch atomic.AddInt32(&requests, 1) {
+			case 1:
+				w.WriteHeader(503)
+				w.Write([]byte(`{}`))
+			case 2:
+				w.Write([]byte(`{"status":"pending"}`))
+			default:
+				w.Write([]byte(`{"status":"success"}`))
+			}
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("NCHAIN_API_HOST", srvURL.Host)
+	t.Setenv("NCHAIN_API_SCHEME", srvURL.Scheme)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	tx, err := AwaitTransaction(ctx, "token", "ref", time.Millisecond*10)
+	if err != nil {
+		t.Fatalf("failed to await transaction; %s", err.Error())
+	}
+	if tx == nil || tx.Status == nil || *tx.Status != TxStatusSuccess {
+		t.Errorf("expected successful transaction; got %v", tx)
+	}
+
+	_, err = AwaitTransaction(ctx, "token", "unauthorized", time.Millisecond*10)
+	if err == nil {
+		t.Error("expected non-transient error to fail the await")
+	}
+	if ctx.Err() != nil {
+		t.Error("expected non-transient error to be returned before the context expired")
+	}
+}
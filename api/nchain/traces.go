@@ -0,0 +1,160 @@
+package nchain
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/provideplatform/provide-go/crypto/abi"
+)
+
+const (
+	// TraceTypeCall is the trace type for message calls
+	TraceTypeCall = "call"
+
+	// TraceTypeCreate is the trace type for contract creation
+	TraceTypeCreate = "create"
+
+	// TraceTypeSuicide is the trace type for selfdestruct
+	TraceTypeSuicide = "suicide"
+
+	// TraceTypeReward is the trace type for block and uncle rewards
+	TraceTypeReward = "reward"
+)
+
+// CallTreeNode is a trace positioned within the call hierarchy of a transaction
+type CallTreeNode struct {
+	*Trace
+
+	Children []*CallTreeNode `json:"children,omitempty"`
+
+	// GasUsed is the gas used by this frame, inclusive of its children
+	GasUsed uint64 `json:"gas_used"`
+
+	// SelfGasUsed is the gas used by this frame, exclusive of its children
+	SelfGasUsed uint64 `json:"self_gas_used"`
+
+	// Reverted is true when this frame itself failed
+	Reverted bool `json:"reverted"`
+
+	// ParentReverted is true when an ancestor frame failed; the effects of this frame were
+	// rolled back even if it succeeded
+	ParentReverted bool `json:"parent_reverted"`
+
+	// ChildReverted is true when any descendant frame failed
+	ChildReverted bool `json:"child_reverted"`
+}
+
+// IsType returns true if the trace is of the given type
+func (t *Trace) IsType(typ string) bool {
+	return t.Type != nil && *t.Type == typ
+}
+
+// GasUsed returns the gas used by the trace, or 0 if the trace has no result
+func (t *Trace) GasUsed() uint64 {
+	if t.Result == nil || t.Result.GasUsed == nil {
+		return 0
+	}
+	gasUsed, err := hexutil.DecodeUint64(*t.Result.GasUsed)
+	if err != nil {
+		return 0
+	}
+	return gasUsed
+}
+
+// DecodeInput decodes the call input using the abi selector registry
+func (t *Trace) DecodeInput() (*abi.DecodedCall, error) {
+	if t.Action == nil || t.Action.Input == nil {
+		return nil, fmt.Errorf("failed to decode trace input; no input")
+	}
+	return abi.DecodeCalldataHex(*t.Action.Input)
+}
+
+// CallTree reconstructs the call hierarchy from the traces in the response
+func (r *EthereumTxTraceResponse) CallTree() (*CallTreeNode, error) {
+	return BuildCallTree(r.Result)
+}
+
+// BuildCallTree reconstructs the nested call hierarchy of a single transaction from its flat list of
+// traces, aggregating gas usage and propagating revert flags; reward traces are ignored
+func BuildCallTree(traces []*Trace) (*CallTreeNode, error) {
+	var root *CallTreeNode
+	nodes := map[string]*CallTreeNode{}
+	ordered := make([]*CallTreeNode, 0, len(traces))
+
+	for _, trace := range traces {
+		if trace == nil || trace.IsType(TraceTypeReward) {
+			continue
+		}
+
+		node := &CallTreeNode{
+			Trace:    trace,
+			Children: make([]*CallTreeNode, 0),
+			GasUsed:  trace.GasUsed(),
+			Reverted: trace.Error != nil,
+		}
+
+		key := traceAddressKey(trace.TraceAddress)
+		if _, exists := nodes[key]; exists {
+			return nil, fmt.Errorf("failed to build call tree; duplicate trace address %s", key)
+		}
+		nodes[key] = node
+		ordered = append(ordered, node)
+
+		if len(trace.TraceAddress) == 0 {
+			root = node
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("failed to build call tree; no root trace")
+	}
+
+	for _, node := range ordered {
+		if node == root {
+			continue
+		}
+		parentKey := traceAddressKey(node.TraceAddress[0 : len(node.TraceAddress)-1])
+		parent, ok := nodes[parentKey]
+		if !ok {
+			return nil, fmt.Errorf("failed to build call tree; no parent trace for trace address %s", traceAddressKey(node.TraceAddress))
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	root.aggregate(false)
+	return root, nil
+}
+
+// Walk visits the node and its descendants depth-first
+func (n *CallTreeNode) Walk(fn func(node *CallTreeNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *CallTreeNode) walk(fn func(node *CallTreeNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// aggregate computes the exclusive gas usage and revert propagation flags for the subtree
+func (n *CallTreeNode) aggregate(parentReverted bool) {
+	n.ParentReverted = parentReverted
+
+	childGasUsed := uint64(0)
+	for _, child := range n.Children {
+		child.aggregate(parentReverted || n.Reverted)
+		childGasUsed += child.GasUsed
+		if child.Reverted || child.ChildReverted {
+			n.ChildReverted = true
+		}
+	}
+
+	if childGasUsed < n.GasUsed {
+		n.SelfGasUsed = n.GasUsed - childGasUsed
+	}
+}
+
+func traceAddressKey(traceAddress []int) string {
+	return fmt.Sprintf("%v", traceAddress)
+}
@@ -0,0 +1,66 @@
+package nchain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const nestedTraces = `{"result":[
+	{"type":"call","action":{"callType":"call","from":"0x01","to":"0x02","gas":"0x10000","input":"0x"},"result":{"gasUsed":"0x5000","output":"0x"},"subtraces":2,"traceAddress":[]},
+	{"type":"call","action":{"callType":"call","from":"0x02","to":"0x03","gas":"0x8000","input":"0x"},"result":{"gasUsed":"0x1000","output":"0x"},"subtraces":1,"traceAddress":[0]},
+	{"type":"call","action":{"callType":"staticcall","from":"0x03","to":"0x04","gas":"0x4000","input":"0x"},"result":{"gasUsed":"0x500","output":"0x"},"subtraces":0,"traceAddress":[0,0]},
+	{"type":"call","action":{"callType":"call","from":"0x02","to":"0x05","gas":"0x4000","input":"0x"},"error":"Reverted","subtraces":1,"traceAddress":[1]},
+	{"type":"create","action":{"from":"0x05","gas":"0x2000","init":"0x"},"result":{"address":"0x06","gasUsed":"0x800","code":"0x"},"subtraces":0,"traceAddress":[1,0]}
+]}`
+
+func TestBuildCallTree(t *testing.T) {
+	var resp EthereumTxTraceResponse
+	err := json.Unmarshal([]byte(nestedTraces), &resp)
+	if err != nil {
+		t.Fatalf("failed to unmarshal traces; %s", err.Error())
+	}
+
+	root, err := resp.CallTree()
+	if err != nil {
+		t.Fatalf("failed to build call tree; %s", err.Error())
+	}
+
+	if len(root.Children) != 2 {
+		t.Fatalf("expected 2 children of root; got %d", len(root.Children))
+	}
+	if root.SelfGasUsed != 0x5000-0x1000 {
+		t.Errorf("unexpected root self gas used %d", root.SelfGasUsed)
+	}
+	if !root.ChildReverted || root.Reverted {
+		t.Error("expected root to report a reverted descendant")
+	}
+
+	reverted := root.Children[1]
+	if !reverted.Reverted {
+		t.Error("expected second call to be reverted")
+	}
+	created := reverted.Children[0]
+	if !created.IsType(TraceTypeCreate) || !created.ParentReverted {
+		t.Error("expected nested create to be rolled back by its reverted parent")
+	}
+	if root.Children[0].Children[0].ParentReverted {
+		t.Error("expected successful branch not to be flagged as rolled back")
+	}
+
+	depths := 0
+	root.Walk(func(node *CallTreeNode, depth int) {
+		if depth > depths {
+			depths = depth
+		}
+	})
+	if depths != 2 {
+		t.Errorf("expected max depth 2; got %d", depths)
+	}
+}
+
+func TestBuildCallTreeMissingRoot(t *testing.T) {
+	_, err := BuildCallTree([]*Trace{{TraceAddress: []int{0}}})
+	if err == nil {
+		t.Error("expected error building call tree without root trace")
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const defaultOperationPollInterval = time.Second * 2
+
+// operationIDKeys are the response attributes, in order of precedence, which identify the
+// asynchronous operation initiated by a request accepted with a 202 response
+var operationIDKeys = []string{"operation_id", "reference", "ref", "baseline_id", "id"}
+
+// TransientStatus returns true if a request which failed with the given status may succeed when
+// retried, i.e., upon a network error (reported as status 0), rate limiting or a server error
+func TransientStatus(status int) bool {
+	return status == 0 || status == 429 || status >= 500
+}
+
+// OperationPoller polls the status of the operation with the given id; it returns the result and
+// true once the operation has completed, or an error if the operation failed. Transient errors,
+// i.e., network errors, rate limiting and server errors (see TransientStatus), should be logged
+// and reported as incomplete so polling continues; any other error (i.e., revoked authorization
+// or an unknown operation) should be returned, as it fails the operation.
+type OperationPoller[T any] func(ctx context.Context, id string) (*T, bool, error)
+
+// Operation tracks the completion of an asynchronous operation initiated by a request which
+// was accepted with a 202 response
+type Operation[T any] struct {
+	// ID is the operation or reference id extracted from the 202 response
+	ID string
+
+	// Response is the 202 response
+	Response interface{}
+
+	// PollInterval is the interval at which the operation is polled; a default is used when unset
+	PollInterval time.Duration
+
+	poll   OperationPoller[T]
+	notify <-chan *T
+}
+
+// OperationID extracts the operation or reference id from the given 202 response
+func OperationID(resp interface{}) (string, bool) {
+	attrs, ok := resp.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	for _, key := range operationIDKeys {
+		if id, ok := attrs[key].(string); ok && id != "" {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// NewOperation returns an Operation tracking the operation identified by the given 202 response
+// using the given poller
+func NewOperation[T any](resp interface{}, poll OperationPoller[T]) (*Operation[T], error) {
+	id, ok := OperationID(resp)
+	if !ok {
+		return nil, fmt.Errorf("failed to resolve operation id from response: %v", resp)
+	}
+
+	return &Operation[T]{
+		ID:       id,
+		Response: resp,
+		poll:     poll,
+	}, nil
+}
+
+// NewOperationWithID returns an Operation tracking the operation with the given id using the given
+// poller, i.e., to await an operation whose id was returned by an earlier request
+func NewOperationWithID[T any](id string, poll OperationPoller[T]) *Operation[T] {
+	return &Operation[T]{
+		ID:   id,
+		poll: poll,
+	}
+}
+
+// Notify configures push-based completion; the operation completes as soon as its result is
+// delivered on the given channel, i.e., by a webhook handler or a Stream consumer. Polling
+// continues as a fallback.
+func (o *Operation[T]) Notify(results <-chan *T) {
+	o.notify = results
+}
+
+// Wait blocks until the operation completes, fails or the context is done; the operation is
+// polled immediately and then at each PollInterval, unless a result was already delivered on
+// the channel configured using Notify
+func (o *Operation[T]) Wait(ctx context.Context) (*T, error) {
+	interval := o.PollInterval
+	if interval <= 0 {
+		interval = defaultOperationPollInterval
+	}
+
+	select {
+	case result, ok := <-o.notify:
+		if ok && result != nil {
+			return result, nil
+		}
+		if !ok {
+			o.notify = nil
+		}
+	default:
+	}
+
+	result, done, err := o.pollOnce(ctx)
+	if err != nil || done {
+		return result, err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to await operation %s; %s", o.ID, ctx.Err().Error())
+		case result, ok := <-o.notify:
+			if ok && result != nil {
+				return result, nil
+			}
+			if !ok {
+				o.notify = nil
+			}
+		case <-ticker.C:
+			result, done, err := o.pollOnce(ctx)
+			if err != nil || done {
+				return result, err
+			}
+		}
+	}
+}
+
+func (o *Operation[T]) pollOnce(ctx context.Context) (*T, bool, error) {
+	if ctx.Err() != nil {
+		return nil, false, fmt.Errorf("failed to await operation %s; %s", o.ID, ctx.Err().Error())
+	}
+
+	result, done, err := o.poll(ctx, o.ID)
+	if err != nil {
+		return result, true, fmt.Errorf("failed to await operation %s; %w", o.ID, err)
+	}
+	return result, done, nil
+}
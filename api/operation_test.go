@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type testOperationResult struct {
+	Status string
+}
+
+func TestOperationWait(t *testing.T) {
+	polls := 0
+	op, err := NewOperation(map[string]interface{}{"reference": "ref"}, func(ctx context.Context, id string) (*testOperationResult, bool, error) {
+		polls++
+		if id != "ref" {
+			t.Errorf("expected operation id ref; got %s", id)
+		}
+		return &testOperationResult{Status: "success"}, polls == 2, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize operation; %s", err.Error())
+	}
+	op.PollInterval = time.Millisecond
+
+	result, err := op.Wait(context.Background())
+	if err != nil || result.Status != "success" || polls != 2 {
+		t.Errorf("expected operation to complete after 2 polls; got %d polls; %v", polls, err)
+	}
+}
+
+func TestOperationWaitNotify(t *testing.T) {
+	failed := errors.New("failed")
+	op, _ := NewOperation(map[string]interface{}{"id": "op"}, func(ctx context.Context, id string) (*testOperationResult, bool, error) {
+		return nil, true, failed
+	})
+	op.PollInterval = time.Hour
+
+	results := make(chan *testOperationResult, 1)
+	results <- &testOperationResult{Status: "pushed"}
+	op.Notify(results)
+
+	result, err := op.Wait(context.Background())
+	if err != nil || result.Status != "pushed" {
+		t.Errorf("expected pushed result; got %v", err)
+	}
+
+	op.PollInterval = time.Millisecond
+	_, err = op.Wait(context.Background())
+	if !errors.Is(err, failed) {
+		t.Errorf("expected operation failure; got %v", err)
+	}
+
+	if _, err := NewOperation[testOperationResult](map[string]interface{}{}, nil); err == nil {
+		t.Errorf("expected error resolving operation id")
+	}
+}
+
+func TestOperationWaitPollsImmediately(t *testing.T) {
+	polls := 0
+	op, _ := NewOperation(map[string]interface{}{"id": "op"}, func(ctx context.Context, id string) (*testOperationResult, bool, error) {
+		polls++
+		return &testOperationResult{Status: "success"}, true, nil
+	})
+	op.PollInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	result, err := op.Wait(ctx)
+	if err != nil || result.Status != "success" || polls != 1 {
+		t.Errorf("expected operation to complete upon the initial poll; got %d polls; %v", polls, err)
+	}
+
+	for status, transient := range map[int]bool{0: true, 401: false, 404: false, 429: true, 503: true} {
+		if TransientStatus(status) != transient {
+			t.Errorf("expected status %d transient: %v", status, transient)
+		}
+	}
+}
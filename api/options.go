@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestOption customizes a single request sent by a Client, i.e., to pass a tenant header,
+// feature flag or preview API opt-in without modifying the Client
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	headers http.Header
+	query   url.Values
+	cookies []*http.Cookie
+}
+
+// WithHeader sets the given header on the request, overriding any default or Client header
+func WithHeader(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.headers.Add(name, value)
+	}
+}
+
+// WithQuery adds the given query parameter to the request
+func WithQuery(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Add(name, value)
+	}
+}
+
+// WithCookie adds the given cookie to the request, in addition to the Client cookie
+func WithCookie(name, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.cookies = append(o.cookies, &http.Cookie{Name: name, Value: value})
+	}
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	options := &requestOptions{
+		headers: http.Header{},
+		query:   url.Values{},
+		cookies: make([]*http.Cookie, 0),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// applyQuery adds the query parameters to the given url
+func (o *requestOptions) applyQuery(reqURL *url.URL) {
+	if len(o.query) == 0 {
+		return
+	}
+
+	q := reqURL.Query()
+	for name, vals := range o.query {
+		for _, val := range vals {
+			q.Add(name, val)
+		}
+	}
+	reqURL.RawQuery = q.Encode()
+}
+
+// applyHeaders sets the headers and cookies on the given headers, replacing any existing
+// values of the same (case-insensitive) name
+func (o *requestOptions) applyHeaders(headers map[string][]string) {
+	for name, vals := range o.headers {
+		for existing := range headers {
+			if strings.EqualFold(existing, name) {
+				delete(headers, existing)
+			}
+		}
+		headers[name] = vals
+	}
+
+	if len(o.cookies) == 0 {
+		return
+	}
+
+	cookies := make([]string, 0, len(o.cookies)+1)
+	for existing, vals := range headers {
+		if strings.EqualFold(existing, "Cookie") {
+			cookies = append(cookies, vals...)
+			delete(headers, existing)
+		}
+	}
+	for _, cookie := range o.cookies {
+		cookies = append(cookies, fmt.Sprintf("%s=%s", cookie.Name, cookie.Value))
+	}
+	headers["Cookie"] = []string{strings.Join(cookies, "; ")}
+}
@@ -0,0 +1,213 @@
+package pgrok
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const pgrokDefaultReconnectInitialBackoff = time.Second
+const pgrokDefaultReconnectMaxBackoff = time.Second * 30
+const pgrokEndpointPollInterval = time.Millisecond * 50
+
+// TunnelState is the connection state of a tunnel
+type TunnelState string
+
+// TunnelStateClosed is the state of a tunnel which has been shut down
+const TunnelStateClosed TunnelState = "closed"
+
+// TunnelStateConnected is the state of a tunnel with an established session
+const TunnelStateConnected TunnelState = "connected"
+
+// TunnelStateConnecting is the state of a tunnel which is dialing the pgrok server
+const TunnelStateConnecting TunnelState = "connecting"
+
+// TunnelStateDisconnected is the state of a tunnel which has lost its connection
+const TunnelStateDisconnected TunnelState = "disconnected"
+
+// ReconnectOptions configures the exponential backoff applied between reconnection
+// attempts; reconnection is retried indefinitely unless MaxAttempts is non-zero
+type ReconnectOptions struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxAttempts    int
+
+	// OnStateChange, if provided, is invoked each time the tunnel state changes
+	OnStateChange func(TunnelState)
+}
+
+// State returns the current connection state of the tunnel
+func (t *Tunnel) State() TunnelState {
+	if state, ok := t.state.Load().(TunnelState); ok {
+		return state
+	}
+	return TunnelStateDisconnected
+}
+
+func (t *Tunnel) setState(state TunnelState) {
+	t.state.Store(state)
+}
+
+// Endpoint returns the public endpoint of the tunnel, once it has been assigned by the pgrok server
+func (t *Tunnel) Endpoint() *string {
+	if t.mutex != nil {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+	}
+	return t.RemoteAddr
+}
+
+// AwaitEndpoint blocks until the pgrok server assigns the public endpoint of the tunnel,
+// the tunnel is shut down or the context is canceled
+func (t *Tunnel) AwaitEndpoint(ctx context.Context) (string, error) {
+	ticker := time.NewTicker(pgrokEndpointPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if endpoint := t.Endpoint(); endpoint != nil {
+			return *endpoint, nil
+		}
+
+		if t.shuttingDown() {
+			return "", errors.New("pgrok tunnel closed before endpoint was assigned")
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run connects the tunnel and monitors the connection, reconnecting with exponential backoff
+// whenever it is lost; Run blocks until the context is canceled, the tunnel is shut down
+// (i.e., upon expiration) or the maximum number of reconnection attempts is exhausted
+func (t *Tunnel) Run(ctx context.Context, opts *ReconnectOptions) error {
+	t.init(ctx)
+	return t.run(ctx, opts)
+}
+
+// init initializes the shutdown context and mutex of the tunnel; it must be invoked before
+// the tunnel is run or otherwise shared with other goroutines
+func (t *Tunnel) init(ctx context.Context) {
+	t.shutdownCtx, t.cancelF = context.WithCancel(ctx)
+
+	if t.mutex == nil {
+		t.mutex = &sync.Mutex{}
+	}
+}
+
+// run connects and monitors the tunnel; the tunnel must have been initialized
+func (t *Tunnel) run(ctx context.Context, opts *ReconnectOptions) error {
+	if opts == nil {
+		opts = &ReconnectOptions{}
+	}
+
+	initialBackoff := opts.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = pgrokDefaultReconnectInitialBackoff
+	}
+
+	maxBackoff := opts.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = pgrokDefaultReconnectMaxBackoff
+	}
+
+	defer t.shutdown()
+
+	notify := func(state TunnelState) {
+		if opts.OnStateChange != nil {
+			opts.OnStateChange(state)
+		}
+	}
+
+	backoff := initialBackoff
+	attempts := 0
+
+	for !t.shuttingDown() {
+		notify(TunnelStateConnecting)
+		err := t.connect()
+		if err == nil {
+			attempts = 0
+			backoff = initialBackoff
+			notify(TunnelStateConnected)
+
+			disconnected := make(chan struct{})
+			client := t.client
+			go func() {
+				client.Wait()
+				close(disconnected)
+			}()
+
+			select {
+			case <-disconnected:
+				if t.shuttingDown() {
+					break
+				}
+				common.Log.Warningf("pgrok tunnel client lost connection to %s; reconnecting", *t.ServerAddr)
+				t.setState(TunnelStateDisconnected)
+				t.clearEndpoint()
+				t.teardown()
+				notify(TunnelStateDisconnected)
+			case <-t.shutdownCtx.Done():
+				notify(TunnelStateClosed)
+				return ctx.Err()
+			}
+			continue
+		}
+
+		attempts++
+		common.Log.Warningf("pgrok tunnel client failed to connect to %s (attempt %d); %s", *t.ServerAddr, attempts, err.Error())
+		notify(TunnelStateDisconnected)
+		if opts.MaxAttempts > 0 && attempts >= opts.MaxAttempts {
+			return err
+		}
+
+		select {
+		case <-t.shutdownCtx.Done():
+			notify(TunnelStateClosed)
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	notify(TunnelStateClosed)
+	return nil
+}
+
+func (t *Tunnel) clearEndpoint() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.RemoteAddr = nil
+}
+
+// CreateTunnel initializes a tunnel forwarding to the given local address, connects it with
+// automatic reconnection and blocks until its public endpoint has been assigned; the tunnel
+// remains connected until the context is canceled or the client is closed
+func (c *Client) CreateTunnel(ctx context.Context, name, localAddr string, serverAddr, protocol, jwt *string, opts *ReconnectOptions) (*Tunnel, string, error) {
+	tun, err := c.TunnelFactory(name, localAddr, serverAddr, protocol, jwt, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.AddTunnel(tun)
+	tun.init(ctx)
+	go tun.run(ctx, opts)
+
+	endpoint, err := tun.AwaitEndpoint(ctx)
+	if err != nil {
+		tun.shutdown()
+		return nil, "", err
+	}
+
+	return tun, endpoint, nil
+}
@@ -2,6 +2,7 @@ package pgrok
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/provideplatform/provide-go/common"
 )
@@ -29,6 +30,7 @@ func (c *Client) TunnelFactory(name, localAddr string, serverAddr, protocol, jwt
 		Name:      &name,
 		LocalAddr: &localAddr,
 		Protocol:  &proto,
+		mutex:     &sync.Mutex{},
 	}
 
 	if jwt != nil {
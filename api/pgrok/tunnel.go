@@ -44,6 +44,8 @@ type Tunnel struct {
 
 	cancelF     context.CancelFunc
 	closing     uint32
+	generation  uint32 // incremented each time the tunnel (re)connects
+	state       atomic.Value
 	shutdownCtx context.Context
 	shutdownFn  func(reason *string)
 
@@ -66,21 +68,19 @@ func (t *Tunnel) main() {
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 	t.shutdownCtx, t.cancelF = context.WithCancel(context.Background())
 
-	t.mutex = &sync.Mutex{}
+	if t.mutex == nil {
+		t.mutex = &sync.Mutex{}
+	}
 
 	if t.ServerAddr == nil {
 		t.ServerAddr = common.StringOrNil(fmt.Sprintf("%s:%d", pgrokDefaultServerHost, pgrokDefaultServerPort))
 	}
 
-	var err error
-	t.client, err = ssh.Dial("tcp", *t.ServerAddr, sshClientConfigFactory())
+	err := t.connect()
 	if err != nil {
 		common.Log.Panicf("pgrok tunnel client failed to connect; %s", err.Error())
 	}
 
-	t.checkDestinationReachability()
-	t.initSession()
-
 	common.Log.Debugf("running pgrok tunnel client")
 	timer := time.NewTicker(pgrokClientStatusTickerInterval)
 	defer timer.Stop()
@@ -150,14 +150,52 @@ func sshClientConfigFactory() *ssh.ClientConfig {
 	return cfg
 }
 
-func (t *Tunnel) shutdown() {
-	if atomic.AddUint32(&t.closing, 1) == 1 {
+// connect dials the pgrok server and establishes the tunnel session and channel
+func (t *Tunnel) connect() error {
+	t.setState(TunnelStateConnecting)
+	atomic.AddUint32(&t.generation, 1)
+
+	var err error
+	t.client, err = ssh.Dial("tcp", *t.ServerAddr, sshClientConfigFactory())
+	if err != nil {
+		t.setState(TunnelStateDisconnected)
+		return err
+	}
+
+	t.checkDestinationReachability()
+	err = t.initSession()
+	if err != nil {
+		t.teardown()
+		t.setState(TunnelStateDisconnected)
+		return err
+	}
+
+	t.setState(TunnelStateConnected)
+	return nil
+}
+
+// teardown closes the underlying connection without shutting down the tunnel
+func (t *Tunnel) teardown() {
+	if t.channel != nil {
 		t.channel.Close()
+	}
+	if t.session != nil {
 		t.session.Close()
+	}
+	if t.client != nil {
 		t.client.Close()
+	}
+}
+
+func (t *Tunnel) shutdown() {
+	if atomic.AddUint32(&t.closing, 1) == 1 {
+		t.teardown()
+		t.setState(TunnelStateClosed)
 
 		common.Log.Debug("shutting down pgrok tunnel client")
-		t.cancelF()
+		if t.cancelF != nil {
+			t.cancelF()
+		}
 	}
 }
 
@@ -165,16 +203,22 @@ func (t *Tunnel) shuttingDown() bool {
 	return (atomic.LoadUint32(&t.closing) > 0)
 }
 
+// active returns true if the tunnel is not shutting down and has not reconnected since the given generation
+func (t *Tunnel) active(generation uint32) bool {
+	return !t.shuttingDown() && atomic.LoadUint32(&t.generation) == generation
+}
+
 func (t *Tunnel) tick() {
 
 }
 
-func (t *Tunnel) initSession() {
+func (t *Tunnel) initSession() error {
+	generation := atomic.LoadUint32(&t.generation)
+
 	var err error
 	t.session, err = t.client.NewSession()
 	if err != nil {
-		t.client.Close()
-		common.Log.Panicf("pgrok tunnel client failed to open session; %s", err.Error())
+		return fmt.Errorf("pgrok tunnel client failed to open session; %s", err.Error())
 	}
 
 	t.sessionID = common.StringOrNil(hex.EncodeToString(t.client.SessionID()))
@@ -182,25 +226,24 @@ func (t *Tunnel) initSession() {
 
 	t.stdin, err = t.session.StdinPipe()
 	if err != nil {
-		common.Log.Panicf("failed to resolve pgrok tunnel session stdin pipe; %s", err.Error())
+		return fmt.Errorf("failed to resolve pgrok tunnel session stdin pipe; %s", err.Error())
 	}
 
 	t.stdout, err = t.session.StdoutPipe()
 	if err != nil {
-		common.Log.Panicf("failed to resolve pgrok tunnel session stdout pipe; %s", err.Error())
+		return fmt.Errorf("failed to resolve pgrok tunnel session stdout pipe; %s", err.Error())
 	}
 
 	t.stderr, err = t.session.StderrPipe()
 	if err != nil {
-		common.Log.Panicf("failed to resolve pgrok tunnel session stderr pipe; %s", err.Error())
+		return fmt.Errorf("failed to resolve pgrok tunnel session stderr pipe; %s", err.Error())
 	}
 
 	// stdout
 	go func() {
-		for !t.shuttingDown() {
-			var n int
+		for t.active(generation) {
 			buffer := make([]byte, pgrokClientBufferSize)
-			if n, err = t.stdout.Read(buffer); err != nil && err != io.EOF {
+			if n, err := t.stdout.Read(buffer); err != nil && err != io.EOF {
 				common.Log.Warningf("pgrok tunnel client failed to consume stdout stream; %s", err.Error())
 			} else if n > 0 {
 				common.Log.Tracef("pgrok tunnel client read %d bytes from ssh stdout stream", n)
@@ -211,10 +254,9 @@ func (t *Tunnel) initSession() {
 
 	// stderr
 	go func() {
-		for !t.shuttingDown() {
-			var n int
+		for t.active(generation) {
 			buffer := make([]byte, pgrokClientBufferSize)
-			if n, err = t.stderr.Read(buffer); err != nil && err != io.EOF {
+			if n, err := t.stderr.Read(buffer); err != nil && err != io.EOF {
 				common.Log.Warningf("pgrok tunnel client failed to consume stderr stream; %s", err.Error())
 			} else if n > 0 {
 				common.Log.Tracef("pgrok tunnel client read %d bytes from ssh stderr stream", n)
@@ -225,12 +267,12 @@ func (t *Tunnel) initSession() {
 
 	err = t.initChannel()
 	if err != nil {
-		common.Log.Panicf("failed to initialize channel; %s", err.Error())
+		return fmt.Errorf("failed to initialize channel; %s", err.Error())
 	}
 
 	go func() {
 		c := t.client.HandleChannelOpen(pgrokClientChannelTypeForward)
-		for !t.shuttingDown() {
+		for t.active(generation) {
 			if newChannel := <-c; newChannel != nil {
 				fchan, freqs, err := newChannel.Accept()
 				if err != nil {
@@ -242,13 +284,15 @@ func (t *Tunnel) initSession() {
 						}
 					}()
 
-					go t.forward(fchan)
+					go t.forward(fchan, generation)
 				}
 			}
 
 			time.Sleep(pgrokConnSessionBufferSleepTimeout)
 		}
 	}()
+
+	return nil
 }
 
 func (t *Tunnel) initChannel() error {
@@ -285,7 +329,9 @@ func (t *Tunnel) initChannel() error {
 					req.Reply(false, nil)
 				}
 				if addr, addrOk := payload["addr"].(string); addrOk {
+					t.mutex.Lock()
 					t.RemoteAddr = &addr
+					t.mutex.Unlock()
 					common.Log.Debugf("pgrok tunnel client resolved address: %s", *t.RemoteAddr)
 				}
 				req.Reply(true, nil)
@@ -311,7 +357,7 @@ func (t *Tunnel) initChannel() error {
 	return nil
 }
 
-func (t *Tunnel) forward(channel ssh.Channel) {
+func (t *Tunnel) forward(channel ssh.Channel, generation uint32) {
 	dest, err := net.Dial("tcp", *t.LocalAddr)
 	if err != nil {
 		common.Log.Warningf("pgrok tunnel client failed to dial local destination address %s; %s", *t.LocalAddr, err.Error())
@@ -346,7 +392,7 @@ func (t *Tunnel) forward(channel ssh.Channel) {
 
 	// channel > local destination
 	go func() {
-		for !t.shuttingDown() {
+		for t.active(generation) {
 			if dest != nil {
 				var n int
 				var err error
@@ -374,7 +420,7 @@ func (t *Tunnel) forward(channel ssh.Channel) {
 	}()
 
 	go func() {
-		for !t.shuttingDown() {
+		for t.active(generation) {
 			io.Copy(io.Discard, channel.Stderr())
 			time.Sleep(pgrokConnSessionBufferSleepTimeout)
 		}
@@ -384,7 +430,7 @@ func (t *Tunnel) forward(channel ssh.Channel) {
 
 	// local destination > channel
 	go func() {
-		for !t.shuttingDown() {
+		for t.active(generation) {
 			if dest != nil {
 				var n int
 				var err error
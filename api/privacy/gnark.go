@@ -0,0 +1,364 @@
+package privacy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+)
+
+// bn254FieldModulus is the modulus of the BN254 (alt_bn128) base field
+var bn254FieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+
+// bn254FieldHalf is (p - 1) / 2; field elements greater than it are lexicographically largest
+var bn254FieldHalf = new(big.Int).Rsh(bn254FieldModulus, 1)
+
+// bn254TwistB is the coefficient b' = 3 / (9 + u) of the BN254 twist y^2 = x^3 + b'
+var bn254TwistB = func() *fp2 {
+	inv := new(big.Int).ModInverse(big.NewInt(82), bn254FieldModulus) // (9 + u)^-1 = (9 - u) / 82
+	return &fp2{
+		a0: new(big.Int).Mod(new(big.Int).Mul(big.NewInt(27), inv), bn254FieldModulus),
+		a1: new(big.Int).Mod(new(big.Int).Mul(big.NewInt(-3), inv), bn254FieldModulus),
+	}
+}()
+
+// gnark encodes the point format in the two most significant bits of the first byte of a point;
+// uncompressed points are encoded as x || y and compressed points as x, with the flag selecting y
+const (
+	gnarkMask               byte = 0b11 << 6
+	gnarkUncompressed       byte = 0b00 << 6
+	gnarkInfinity           byte = 0b01 << 6
+	gnarkCompressedSmallest byte = 0b10 << 6
+	gnarkCompressedLargest  byte = 0b11 << 6
+)
+
+// VerifyGnarkGroth16 verifies the given gnark-encoded BN254 groth16 proof against the public inputs
+// locally using the given gnark-encoded verifying key, i.e., the verifying key artifact of a circuit
+// returned by the privacy API; keys and proofs may use the compressed or raw encoding. Circuits with
+// commitments (i.e., api.Commit) are not supported.
+func VerifyGnarkGroth16(verifyingKey, proof []byte, publicInputs []string) (bool, error) {
+	vk, err := decodeGnarkGroth16VerifyingKey(verifyingKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse groth16 verifying key; %s", err.Error())
+	}
+
+	dec := &gnarkDecoder{buf: proof}
+	a, err := dec.g1()
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proof Ar; %s", err.Error())
+	}
+	b, err := dec.g2()
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proof Bs; %s", err.Error())
+	}
+	c, err := dec.g1()
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proof Krs; %s", err.Error())
+	}
+	if dec.hasCommitments() {
+		return false, fmt.Errorf("%w; groth16 proof has commitments", ErrUnsupportedProvingScheme)
+	}
+
+	return vk.verify(a, b, c, publicInputs)
+}
+
+// decodeGnarkGroth16VerifyingKey decodes a gnark BN254 groth16 verifying key, encoded as
+// [alpha]1, [beta]1, [beta]2, [gamma]2, [delta]1, [delta]2, uint32(len(K)), [K]1
+func decodeGnarkGroth16VerifyingKey(raw []byte) (*groth16VerifyingKey, error) {
+	dec := &gnarkDecoder{buf: raw}
+	vk := &groth16VerifyingKey{}
+
+	var err error
+	if vk.alpha, err = dec.g1(); err != nil {
+		return nil, err
+	}
+	if _, err = dec.g1(); err != nil { // [beta]1 is only used by the prover
+		return nil, err
+	}
+	if vk.beta, err = dec.g2(); err != nil {
+		return nil, err
+	}
+	if vk.gamma, err = dec.g2(); err != nil {
+		return nil, err
+	}
+	if _, err = dec.g1(); err != nil { // [delta]1 is only used by the prover
+		return nil, err
+	}
+	if vk.delta, err = dec.g2(); err != nil {
+		return nil, err
+	}
+
+	n, err := dec.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 || int(n) > len(dec.buf)/dec.g1Size() {
+		return nil, fmt.Errorf("invalid number of K points: %d", n)
+	}
+	for i := uint32(0); i < n; i++ {
+		k, err := dec.g1()
+		if err != nil {
+			return nil, err
+		}
+		vk.ic = append(vk.ic, k)
+	}
+
+	if dec.hasCommitments() {
+		return nil, fmt.Errorf("%w; groth16 verifying key has commitments", ErrUnsupportedProvingScheme)
+	}
+
+	return vk, nil
+}
+
+// gnarkDecoder reads gnark-encoded BN254 points; whether points are compressed is determined
+// by the first point read, as gnark encodes all points of a key or proof alike
+type gnarkDecoder struct {
+	buf        []byte
+	compressed *bool
+}
+
+func (d *gnarkDecoder) next(n int) ([]byte, error) {
+	if len(d.buf) < n {
+		return nil, errors.New("unexpected end of encoding")
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b, nil
+}
+
+func (d *gnarkDecoder) uint32() (uint32, error) {
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// hasCommitments returns true if the remainder of a key or proof encodes a non-empty
+// list of commitments, as encoded by gnark v0.9 and later
+func (d *gnarkDecoder) hasCommitments() bool {
+	return len(d.buf) >= 4 && binary.BigEndian.Uint32(d.buf[:4]) != 0
+}
+
+func (d *gnarkDecoder) isCompressed() bool {
+	if d.compressed == nil {
+		compressed := len(d.buf) > 0 && d.buf[0]&gnarkMask != gnarkUncompressed
+		d.compressed = &compressed
+	}
+	return *d.compressed
+}
+
+func (d *gnarkDecoder) g1Size() int {
+	if d.isCompressed() {
+		return 32
+	}
+	return 64
+}
+
+func (d *gnarkDecoder) g1() (*bn256.G1, error) {
+	b, err := d.next(d.g1Size())
+	if err != nil {
+		return nil, err
+	}
+	flag, raw := gnarkFlag(b)
+
+	if flag == gnarkInfinity || isZero(raw) {
+		return unmarshalG1(make([]byte, 64))
+	}
+
+	if !d.isCompressed() {
+		if flag != gnarkUncompressed {
+			return nil, errors.New("invalid G1 point encoding")
+		}
+		return unmarshalG1(raw)
+	}
+
+	x, err := gnarkFieldElement(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// y^2 = x^3 + 3
+	y2 := new(big.Int).Exp(x, big.NewInt(3), bn254FieldModulus)
+	y2.Add(y2, big.NewInt(3))
+	y := new(big.Int).ModSqrt(y2.Mod(y2, bn254FieldModulus), bn254FieldModulus)
+	if y == nil {
+		return nil, errors.New("invalid G1 point; not on curve")
+	}
+	if (y.Cmp(bn254FieldHalf) > 0) != (flag == gnarkCompressedLargest) {
+		y.Sub(bn254FieldModulus, y)
+	}
+
+	return unmarshalG1(append(fieldElementBytes(x), fieldElementBytes(y)...))
+}
+
+// g2 reads a G2 point; gnark encodes each coordinate of the twist as a1 || a0, which matches
+// the coordinate order of bn256.G2
+func (d *gnarkDecoder) g2() (*bn256.G2, error) {
+	b, err := d.next(d.g1Size() * 2)
+	if err != nil {
+		return nil, err
+	}
+	flag, raw := gnarkFlag(b)
+
+	if flag == gnarkInfinity || isZero(raw) {
+		return unmarshalG2(make([]byte, 128))
+	}
+
+	if !d.isCompressed() {
+		if flag != gnarkUncompressed {
+			return nil, errors.New("invalid G2 point encoding")
+		}
+		return unmarshalG2(raw)
+	}
+
+	x1, err := gnarkFieldElement(raw[:32])
+	if err != nil {
+		return nil, err
+	}
+	x0, err := gnarkFieldElement(raw[32:])
+	if err != nil {
+		return nil, err
+	}
+	x := &fp2{a0: x0, a1: x1}
+
+	// y^2 = x^3 + b'
+	y, ok := x.mul(x).mul(x).add(bn254TwistB).sqrt()
+	if !ok {
+		return nil, errors.New("invalid G2 point; not on curve")
+	}
+	if y.lexicographicallyLargest() != (flag == gnarkCompressedLargest) {
+		y = y.neg()
+	}
+
+	buf := append(fieldElementBytes(x.a1), fieldElementBytes(x.a0)...)
+	buf = append(buf, fieldElementBytes(y.a1)...)
+	buf = append(buf, fieldElementBytes(y.a0)...)
+	return unmarshalG2(buf)
+}
+
+// gnarkFlag returns the flag of the given encoded point and the point with the flag cleared
+func gnarkFlag(b []byte) (byte, []byte) {
+	raw := append([]byte{}, b...)
+	flag := raw[0] & gnarkMask
+	raw[0] &^= gnarkMask
+	return flag, raw
+}
+
+func gnarkFieldElement(b []byte) (*big.Int, error) {
+	el := new(big.Int).SetBytes(b)
+	if el.Cmp(bn254FieldModulus) >= 0 {
+		return nil, errors.New("invalid field element; exceeds field modulus")
+	}
+	return el, nil
+}
+
+func unmarshalG1(raw []byte) (*bn256.G1, error) {
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(raw); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func unmarshalG2(raw []byte) (*bn256.G2, error) {
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(raw); err != nil {
+		return nil, err
+	}
+	if !inG2Subgroup(p) {
+		return nil, errors.New("invalid G2 point; not in subgroup")
+	}
+	return p, nil
+}
+
+// fieldElementBytes returns the given reduced field element as 32 big-endian bytes
+func fieldElementBytes(el *big.Int) []byte {
+	buf := make([]byte, 32)
+	el.FillBytes(buf)
+	return buf
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// fp2 is an element a0 + a1*u of the quadratic extension of the BN254 base field, where u^2 = -1
+type fp2 struct {
+	a0, a1 *big.Int
+}
+
+func (z *fp2) add(x *fp2) *fp2 {
+	return &fp2{
+		a0: new(big.Int).Mod(new(big.Int).Add(z.a0, x.a0), bn254FieldModulus),
+		a1: new(big.Int).Mod(new(big.Int).Add(z.a1, x.a1), bn254FieldModulus),
+	}
+}
+
+func (z *fp2) mul(x *fp2) *fp2 {
+	a0 := new(big.Int).Sub(new(big.Int).Mul(z.a0, x.a0), new(big.Int).Mul(z.a1, x.a1))
+	a1 := new(big.Int).Add(new(big.Int).Mul(z.a0, x.a1), new(big.Int).Mul(z.a1, x.a0))
+	return &fp2{
+		a0: a0.Mod(a0, bn254FieldModulus),
+		a1: a1.Mod(a1, bn254FieldModulus),
+	}
+}
+
+func (z *fp2) neg() *fp2 {
+	return &fp2{
+		a0: new(big.Int).Mod(new(big.Int).Neg(z.a0), bn254FieldModulus),
+		a1: new(big.Int).Mod(new(big.Int).Neg(z.a1), bn254FieldModulus),
+	}
+}
+
+// lexicographicallyLargest compares a1 or, when a1 is zero, a0, as gnark does
+func (z *fp2) lexicographicallyLargest() bool {
+	if z.a1.Sign() == 0 {
+		return z.a0.Cmp(bn254FieldHalf) > 0
+	}
+	return z.a1.Cmp(bn254FieldHalf) > 0
+}
+
+// sqrt returns a square root of z using the norm z * conj(z) = a0^2 + a1^2, if one exists
+func (z *fp2) sqrt() (*fp2, bool) {
+	p := bn254FieldModulus
+	if z.a1.Sign() == 0 {
+		if r := new(big.Int).ModSqrt(z.a0, p); r != nil {
+			return &fp2{a0: r, a1: big.NewInt(0)}, true
+		}
+		r := new(big.Int).ModSqrt(new(big.Int).Mod(new(big.Int).Neg(z.a0), p), p)
+		if r == nil {
+			return nil, false
+		}
+		return &fp2{a0: big.NewInt(0), a1: r}, true
+	}
+
+	norm := new(big.Int).Add(new(big.Int).Mul(z.a0, z.a0), new(big.Int).Mul(z.a1, z.a1))
+	lambda := new(big.Int).ModSqrt(norm.Mod(norm, p), p)
+	if lambda == nil {
+		return nil, false
+	}
+
+	half := new(big.Int).ModInverse(big.NewInt(2), p)
+	delta := new(big.Int).Mul(new(big.Int).Add(z.a0, lambda), half)
+	x0 := new(big.Int).ModSqrt(delta.Mod(delta, p), p)
+	if x0 == nil {
+		delta = new(big.Int).Mul(new(big.Int).Sub(z.a0, lambda), half)
+		x0 = new(big.Int).ModSqrt(delta.Mod(delta, p), p)
+		if x0 == nil {
+			return nil, false
+		}
+	}
+
+	// x1 = a1 / (2 * x0)
+	x1 := new(big.Int).ModInverse(new(big.Int).Lsh(x0, 1), p)
+	x1.Mul(x1, z.a1)
+	return &fp2{a0: x0, a1: x1.Mod(x1, p)}, true
+}
@@ -0,0 +1,148 @@
+package privacy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+)
+
+func gnarkG1(p *bn256.G1, compressed bool) []byte {
+	raw := p.Marshal()
+	if !compressed {
+		return raw
+	}
+
+	out := append([]byte{}, raw[:32]...)
+	out[0] |= gnarkCompressedSmallest
+	if new(big.Int).SetBytes(raw[32:]).Cmp(bn254FieldHalf) > 0 {
+		out[0] |= gnarkCompressedLargest
+	}
+	return out
+}
+
+func gnarkG2(p *bn256.G2, compressed bool) []byte {
+	raw := p.Marshal()
+	if !compressed {
+		return raw
+	}
+
+	y := &fp2{a0: new(big.Int).SetBytes(raw[96:]), a1: new(big.Int).SetBytes(raw[64:96])}
+	out := append([]byte{}, raw[:64]...)
+	out[0] |= gnarkCompressedSmallest
+	if y.lexicographicallyLargest() {
+		out[0] |= gnarkCompressedLargest
+	}
+	return out
+}
+
+func gnarkUint32(n uint32) []byte {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, n)
+	return buf
+}
+
+// gnarkGroth16Fixture encodes a verifying key and proof satisfying the groth16 verification
+// equation for the given public input, as encoded by gnark v0.9 and later
+func gnarkGroth16Fixture(input int64, compressed bool) ([]byte, []byte) {
+	order := bn254ScalarFieldOrder
+	alpha, beta, gamma, delta := big.NewInt(3), big.NewInt(5), big.NewInt(7), big.NewInt(11)
+	k0, k1 := big.NewInt(13), big.NewInt(17)
+	a, b := big.NewInt(19), big.NewInt(23)
+
+	x := new(big.Int).Add(k0, new(big.Int).Mul(big.NewInt(input), k1))
+	c := new(big.Int).Mul(a, b)
+	c.Sub(c, new(big.Int).Mul(alpha, beta))
+	c.Sub(c, new(big.Int).Mul(x, gamma))
+	c.Mul(c, new(big.Int).ModInverse(delta, order))
+	c.Mod(c, order)
+
+	g1 := func(k *big.Int) []byte { return gnarkG1(new(bn256.G1).ScalarBaseMult(k), compressed) }
+	g2 := func(k *big.Int) []byte { return gnarkG2(new(bn256.G2).ScalarBaseMult(k), compressed) }
+
+	vk := bytes.Join([][]byte{
+		g1(alpha), g1(beta), g2(beta), g2(gamma), g1(delta), g2(delta),
+		gnarkUint32(2), g1(k0), g1(k1),
+		gnarkUint32(0), // public and commitment committed
+		gnarkUint32(0), // commitment keys
+	}, nil)
+
+	proof := bytes.Join([][]byte{
+		g1(a), g2(b), g1(c),
+		gnarkUint32(0), // commitments
+		g1(big.NewInt(0)),
+	}, nil)
+
+	return vk, proof
+}
+
+func TestGnarkDecodePoints(t *testing.T) {
+	for i := int64(1); i <= 32; i++ {
+		k := new(big.Int).Exp(big.NewInt(i), big.NewInt(31), bn254ScalarFieldOrder)
+		p1 := new(bn256.G1).ScalarBaseMult(k)
+		p2 := new(bn256.G2).ScalarBaseMult(k)
+
+		dec := &gnarkDecoder{buf: append(gnarkG1(p1, true), gnarkG2(p2, true)...)}
+		d1, err := dec.g1()
+		if err != nil || !bytes.Equal(d1.Marshal(), p1.Marshal()) {
+			t.Fatalf("failed to decompress G1 point %d; %v", i, err)
+		}
+		d2, err := dec.g2()
+		if err != nil || !bytes.Equal(d2.Marshal(), p2.Marshal()) {
+			t.Fatalf("failed to decompress G2 point %d; %v", i, err)
+		}
+	}
+
+	dec := &gnarkDecoder{buf: []byte{gnarkCompressedSmallest | 0x3f, 0xff}}
+	if _, err := dec.g1(); err == nil {
+		t.Error("expected error decoding truncated G1 point")
+	}
+}
+
+func TestVerifyGnarkGroth16(t *testing.T) {
+	for _, compressed := range []bool{true, false} {
+		vk, proof := gnarkGroth16Fixture(42, compressed)
+
+		verified, err := VerifyGnarkGroth16(vk, proof, []string{"42"})
+		if err != nil || !verified {
+			t.Errorf("expected gnark groth16 proof to verify (compressed: %v); %v", compressed, err)
+		}
+
+		verified, err = VerifyGnarkGroth16(vk, proof, []string{"43"})
+		if err != nil || verified {
+			t.Errorf("expected gnark groth16 proof not to verify for a different public input (compressed: %v); %v", compressed, err)
+		}
+	}
+
+	vk, proof := gnarkGroth16Fixture(42, true)
+	committed := append(append([]byte{}, proof[:len(proof)-36]...), gnarkUint32(1)...)
+	_, err := VerifyGnarkGroth16(vk, committed, []string{"42"})
+	if !errors.Is(err, ErrUnsupportedProvingScheme) {
+		t.Errorf("expected proof with commitments to be unsupported; got %v", err)
+	}
+}
+
+func TestVerifyLocally(t *testing.T) {
+	vk, proof := gnarkGroth16Fixture(42, true)
+	artifacts := &CircuitArtifacts{
+		Provider:      "gnark",
+		ProvingScheme: ProvingSchemeGroth16,
+		Curve:         "BN254",
+		VerifyingKey:  vk,
+	}
+
+	verified, err := VerifyLocally(artifacts, hex.EncodeToString(proof), []string{"42"})
+	if err != nil || !verified {
+		t.Errorf("expected hex-encoded gnark proof to verify; %v", err)
+	}
+
+	artifacts.ProvingScheme = ProvingSchemePlonk
+	_, err = VerifyLocally(artifacts, hex.EncodeToString(proof), []string{"42"})
+	if !errors.Is(err, ErrUnsupportedProvingScheme) {
+		t.Errorf("expected plonk to be unsupported; got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package privacy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// MerkleHashSHA256 is the sha256 merkle tree hash
+const MerkleHashSHA256 = "sha256"
+
+// MerkleHashKeccak256 is the keccak256 merkle tree hash
+const MerkleHashKeccak256 = "keccak256"
+
+// noteMembershipMaxAttempts is the number of times the root and merkle proof are fetched when
+// the note store root changes between the requests
+const noteMembershipMaxAttempts = 3
+
+// MerkleHashFunc hashes the left and right children of a merkle tree node
+type MerkleHashFunc func(left, right []byte) []byte
+
+// MerkleHashFuncs are the supported merkle tree hashes, keyed by name; additional hashes
+// (i.e., mimc) may be registered by callers
+var MerkleHashFuncs = map[string]MerkleHashFunc{
+	MerkleHashSHA256: func(left, right []byte) []byte {
+		digest := sha256.Sum256(append(append([]byte{}, left...), right...))
+		return digest[:]
+	},
+	MerkleHashKeccak256: func(left, right []byte) []byte {
+		return ethcrypto.Keccak256(left, right)
+	},
+}
+
+// MerkleProof is the path from a leaf in the note store to the root; siblings are ordered
+// from the leaf to the root and all values are hex-encoded
+type MerkleProof struct {
+	Index    uint64   `json:"index"`
+	Leaf     *string  `json:"leaf"`
+	Root     *string  `json:"root"`
+	Siblings []string `json:"siblings"`
+	Hash     *string  `json:"hash,omitempty"` // i.e., sha256; defaults to sha256
+}
+
+// Verify verifies the membership of the leaf in the tree with the given root, independently of
+// the privacy API; an error is returned if the proof is malformed or its hash unsupported
+func (p *MerkleProof) Verify(root string) (bool, error) {
+	if p.Leaf == nil {
+		return false, fmt.Errorf("failed to verify merkle proof; no leaf")
+	}
+
+	hash := MerkleHashSHA256
+	if p.Hash != nil && *p.Hash != "" {
+		hash = strings.ToLower(*p.Hash)
+	}
+
+	hashFunc, ok := MerkleHashFuncs[hash]
+	if !ok {
+		return false, fmt.Errorf("failed to verify merkle proof; unsupported hash: %s", hash)
+	}
+
+	return VerifyMerkleProof(hashFunc, *p.Leaf, p.Index, p.Siblings, root)
+}
+
+// VerifyMerkleProof verifies the membership of the given hex-encoded leaf at the given index in
+// the tree with the given root using the given siblings, ordered from the leaf to the root
+func VerifyMerkleProof(hashFunc MerkleHashFunc, leaf string, index uint64, siblings []string, root string) (bool, error) {
+	node, err := decodeMerkleNode(leaf)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify merkle proof; invalid leaf; %s", err.Error())
+	}
+
+	expected, err := decodeMerkleNode(root)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify merkle proof; invalid root; %s", err.Error())
+	}
+
+	if len(siblings) < 64 && index>>uint(len(siblings)) != 0 {
+		return false, fmt.Errorf("failed to verify merkle proof; index %d out of range for tree of depth %d", index, len(siblings))
+	}
+
+	for i, sibling := range siblings {
+		sib, err := decodeMerkleNode(sibling)
+		if err != nil {
+			return false, fmt.Errorf("failed to verify merkle proof; invalid sibling at depth %d; %s", i, err.Error())
+		}
+
+		if (index>>uint(i))&1 == 0 {
+			node = hashFunc(node, sib)
+		} else {
+			node = hashFunc(sib, node)
+		}
+	}
+
+	return bytes.Equal(node, expected), nil
+}
+
+// GetNoteStoreRoot fetches the current root of the note store of the given circuit
+func GetNoteStoreRoot(token, circuitID string) (string, error) {
+	uri := fmt.Sprintf("circuits/%s/notes/root", circuitID)
+	status, resp, err := InitPrivacyService(token).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return "", err
+	}
+
+	if status != 200 {
+		return "", fmt.Errorf("failed to fetch note store root; status: %v", status)
+	}
+
+	val := &StoreValueResponse{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &val)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch note store root; status: %v; %s", status, err.Error())
+	}
+
+	if val.Root == nil {
+		return "", fmt.Errorf("failed to fetch note store root; no root returned")
+	}
+
+	return *val.Root, nil
+}
+
+// GetNoteMerkleProof fetches the path from the leaf at the given index of the note store of the
+// given circuit to the root, i.e., to build a witness for a workflow proof
+func GetNoteMerkleProof(token, circuitID string, index uint64) (*MerkleProof, error) {
+	uri := fmt.Sprintf("circuits/%s/notes/%d/path", circuitID, index)
+	status, resp, err := InitPrivacyService(token).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch note merkle proof; status: %v", status)
+	}
+
+	proof := &MerkleProof{}
+	raw, _ := json.Marshal(resp)
+	err = json.Unmarshal(raw, &proof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch note merkle proof; status: %v; %s", status, err.Error())
+	}
+
+	return proof, nil
+}
+
+// VerifyNoteMembership fetches the current note store root and the path for the leaf at the
+// given index and verifies the membership of the leaf locally; if the root of the returned
+// proof differs from the current root (i.e., notes were inserted between the requests), both
+// are fetched again, and an error is returned if the roots never agree
+func VerifyNoteMembership(token, circuitID string, index uint64) (bool, error) {
+	for attempt := 1; attempt <= noteMembershipMaxAttempts; attempt++ {
+		root, err := GetNoteStoreRoot(token, circuitID)
+		if err != nil {
+			return false, err
+		}
+
+		proof, err := GetNoteMerkleProof(token, circuitID, index)
+		if err != nil {
+			return false, err
+		}
+
+		if proof.Index != index {
+			return false, fmt.Errorf("failed to verify note membership; proof returned for index %d", proof.Index)
+		}
+
+		if proof.Root == nil || strings.EqualFold(strings.TrimPrefix(*proof.Root, "0x"), strings.TrimPrefix(root, "0x")) {
+			return proof.Verify(root)
+		}
+
+		common.Log.Debugf("note store root changed while fetching merkle proof for circuit %s (attempt %d)", circuitID, attempt)
+	}
+
+	return false, fmt.Errorf("failed to verify note membership; note store root of circuit %s changed while fetching merkle proof", circuitID)
+}
+
+func decodeMerkleNode(val string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(val, "0x"))
+}
@@ -0,0 +1,98 @@
+package privacy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestVerifyMerkleProof(t *testing.T) {
+	hashFunc := MerkleHashFuncs[MerkleHashSHA256]
+
+	leaves := make([][]byte, 4)
+	for i := range leaves {
+		leaves[i] = hashFunc([]byte{byte(i)}, nil)
+	}
+
+	left := hashFunc(leaves[0], leaves[1])
+	right := hashFunc(leaves[2], leaves[3])
+	root := hex.EncodeToString(hashFunc(left, right))
+
+	leaf := hex.EncodeToString(leaves[2])
+	proof := &MerkleProof{
+		Index:    2,
+		Leaf:     &leaf,
+		Siblings: []string{hex.EncodeToString(leaves[3]), "0x" + hex.EncodeToString(left)},
+	}
+
+	verified, err := proof.Verify(root)
+	if err != nil {
+		t.Fatalf("failed to verify merkle proof; %s", err.Error())
+	}
+	if !verified {
+		t.Error("expected merkle proof to verify")
+	}
+
+	proof.Index = 3
+	verified, _ = proof.Verify(root)
+	if verified {
+		t.Error("expected merkle proof for wrong index not to verify")
+	}
+
+	proof.Index = 4
+	if _, err := proof.Verify(root); err == nil {
+		t.Error("expected error for out of range index")
+	}
+}
+
+func TestVerifyNoteMembershipRootChanged(t *testing.T) {
+	hashFunc := MerkleHashFuncs[MerkleHashSHA256]
+
+	leaves := [][]byte{hashFunc([]byte{0}, nil), hashFunc([]byte{1}, nil)}
+	root := hex.EncodeToString(hashFunc(leaves[0], leaves[1]))
+	staleRoot := hex.EncodeToString(hashFunc(leaves[0], nil))
+
+	var rootRequests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/circuits/circuit/notes/root":
+			// the first root is fetched before the second note was inserted
+			if atomic.AddInt32(&rootRequests, 1) == 1 {
+				json.NewEncoder(w).Encode(map[string]interface{}{"root": staleRoot})
+			} else {
+				json.NewEncoder(w).Encode(map[string]interface{}{"root": root})
+			}
+		case "/api/v1/circuits/circuit/notes/0/path":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"index":    0,
+				"leaf":     hex.EncodeToString(leaves[0]),
+				"root":     root,
+				"siblings": []string{hex.EncodeToString(leaves[1])},
+			})
+		default:
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("PRIVACY_API_HOST", srvURL.Host)
+	t.Setenv("PRIVACY_API_SCHEME", srvURL.Scheme)
+
+	verified, err := VerifyNoteMembership("token", "circuit", 0)
+	if err != nil {
+		t.Fatalf("failed to verify note membership; %s", err.Error())
+	}
+	if !verified {
+		t.Error("expected note membership to verify")
+	}
+	if atomic.LoadInt32(&rootRequests) != 2 {
+		t.Errorf("expected root to be fetched twice; got %d", rootRequests)
+	}
+}
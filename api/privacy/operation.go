@@ -0,0 +1,53 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// CircuitStatusProvisioned is the status of a circuit which has been compiled and set up
+const CircuitStatusProvisioned = "provisioned"
+
+// CircuitStatusFailed is the status of a circuit which failed to compile or set up
+const CircuitStatusFailed = "failed"
+
+// ErrCircuitFailed is returned when an awaited circuit fails to be provisioned
+var ErrCircuitFailed = errors.New("circuit provisioning failed")
+
+// CreateCircuitOperation creates a new circuit in the registry and returns an Operation which
+// completes once the circuit has been compiled and set up; see api.Operation. The operation
+// fails with ErrCircuitFailed if the circuit could not be provisioned; network errors, rate
+// limiting and server errors are retried, while any other error fails the operation
+func CreateCircuitOperation(token string, params map[string]interface{}) (*api.Operation[Circuit], error) {
+	status, resp, err := InitPrivacyService(token).Post("circuits", params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 && status != 202 {
+		return nil, fmt.Errorf("failed to create circuit; status: %v", status)
+	}
+
+	return api.NewOperation(resp, func(ctx context.Context, id string) (*Circuit, bool, error) {
+		status, circuit, err := getCircuitDetails(token, id)
+		if err != nil {
+			if !api.TransientStatus(status) {
+				return nil, false, err
+			}
+			common.Log.Debugf("failed to resolve awaited circuit %s; %s", id, err.Error())
+			return nil, false, nil
+		}
+
+		switch common.Deref(circuit.Status) {
+		case CircuitStatusProvisioned:
+			return circuit, true, nil
+		case CircuitStatusFailed:
+			return circuit, true, ErrCircuitFailed
+		}
+		return circuit, false, nil
+	})
+}
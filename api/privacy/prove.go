@@ -0,0 +1,197 @@
+package privacy
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// ArtifactBinary is the circuit artifact containing the compiled constraint system (i.e., R1CS)
+const ArtifactBinary = "binary"
+
+// ArtifactProvingKey is the circuit artifact containing the proving key
+const ArtifactProvingKey = "proving_key"
+
+// ArtifactVerifyingKey is the circuit artifact containing the verifying key
+const ArtifactVerifyingKey = "verifying_key"
+
+// ErrLocalProvingUnavailable is returned when a proof must be generated locally but no local
+// prover or circuit artifacts are available; this package does not include a prover, so LocalOnly
+// proving always requires a Prover supplied by the caller
+var ErrLocalProvingUnavailable = errors.New("local proving unavailable")
+
+// CircuitArtifacts are the decoded artifacts required to generate proofs for a circuit locally
+type CircuitArtifacts struct {
+	CircuitID     string
+	Provider      string // i.e., gnark
+	ProvingScheme string // i.e., groth16
+	Curve         string // i.e., BN254
+
+	ConstraintSystem []byte // the compiled constraint system (i.e., R1CS)
+	ProvingKey       []byte
+	VerifyingKey     []byte
+}
+
+// Prover generates proofs locally (i.e., using gnark) so witnesses never leave the environment
+// of the caller; the proof is returned in the encoding returned by the privacy API. A prover
+// returns an error wrapping ErrUnsupportedProvingScheme for circuits it cannot prove.
+//
+// Only the hook is provided: this module does not depend on gnark and ships no Prover, so callers
+// proving locally must implement Prover, i.e., by deserializing the constraint system and proving
+// key of the CircuitArtifacts with gnark and encoding the resulting proof.
+type Prover interface {
+	Prove(artifacts *CircuitArtifacts, witness map[string]interface{}) (string, error)
+}
+
+// ProveOptions configure where proofs are generated
+type ProveOptions struct {
+	// Prover generates proofs locally; when nil, proofs are generated using the privacy API, as
+	// this package provides no default Prover
+	Prover Prover
+
+	// Artifacts are the circuit artifacts used by the local prover; downloaded when nil
+	Artifacts *CircuitArtifacts
+
+	// LocalOnly prevents falling back to the privacy API when the proof cannot be generated
+	// locally, i.e., when the witness must not leave the environment of the caller
+	LocalOnly bool
+}
+
+// DecodeArtifacts decodes the circuit artifacts required to generate proofs locally; artifacts may be
+// hex-, base64- or JSON-encoded
+func (c *Circuit) DecodeArtifacts() (*CircuitArtifacts, error) {
+	artifacts := &CircuitArtifacts{
+		Provider:      common.Deref(c.Provider),
+		ProvingScheme: common.Deref(c.ProvingScheme),
+		Curve:         common.Deref(c.Curve),
+	}
+	if c.Model != nil {
+		artifacts.CircuitID = c.ID.String()
+	}
+
+	var err error
+	artifacts.ConstraintSystem, err = decodeArtifact(c.Artifacts, ArtifactBinary)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts.ProvingKey, err = decodeArtifact(c.Artifacts, ArtifactProvingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts.VerifyingKey, err = decodeArtifact(c.Artifacts, ArtifactVerifyingKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifacts, nil
+}
+
+// DownloadCircuitArtifacts downloads and decodes the artifacts required to generate proofs for
+// the given circuit locally
+func DownloadCircuitArtifacts(token, circuitID string) (*CircuitArtifacts, error) {
+	circuit, err := GetCircuitDetails(token, circuitID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download circuit artifacts; %s", err.Error())
+	}
+
+	artifacts, err := circuit.DecodeArtifacts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download circuit artifacts; %s", err.Error())
+	}
+
+	if len(artifacts.ConstraintSystem) == 0 || len(artifacts.ProvingKey) == 0 {
+		return nil, fmt.Errorf("failed to download circuit artifacts; circuit %s has no compiled constraint system or proving key", circuitID)
+	}
+
+	return artifacts, nil
+}
+
+// ProveWithOptions generates a proof for the given circuit using the given witness; the proof is
+// generated locally when a prover is configured, falling back to the privacy API when the proof
+// cannot be generated locally unless LocalOnly is set
+func ProveWithOptions(token, circuitID string, witness map[string]interface{}, opts *ProveOptions) (*ProveResponse, error) {
+	if opts == nil {
+		opts = &ProveOptions{}
+	}
+
+	if opts.Prover != nil {
+		resp, err := proveLocally(token, circuitID, witness, opts)
+		if err == nil {
+			return resp, nil
+		}
+
+		if opts.LocalOnly {
+			return nil, err
+		}
+		common.Log.Debugf("falling back to remote proving for circuit %s; %s", circuitID, err.Error())
+	} else if opts.LocalOnly {
+		return nil, fmt.Errorf("failed to generate proof; %w; no prover configured, and none is provided by this package", ErrLocalProvingUnavailable)
+	}
+
+	return Prove(token, circuitID, map[string]interface{}{
+		"witness": witness,
+	})
+}
+
+func proveLocally(token, circuitID string, witness map[string]interface{}, opts *ProveOptions) (*ProveResponse, error) {
+	artifacts := opts.Artifacts
+	if artifacts == nil {
+		var err error
+		artifacts, err = DownloadCircuitArtifacts(token, circuitID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate proof; %w; %s", ErrLocalProvingUnavailable, err.Error())
+		}
+	}
+
+	proof, err := opts.Prover.Prove(artifacts, witness)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate proof locally; %w", err)
+	}
+
+	return &ProveResponse{
+		Proof: &proof,
+	}, nil
+}
+
+// decodeArtifact returns the decoded artifact with the given name, or nil if it does not exist
+func decodeArtifact(artifacts map[string]interface{}, name string) ([]byte, error) {
+	val, ok := artifacts[name]
+	if !ok || val == nil {
+		return nil, nil
+	}
+
+	str, ok := val.(string)
+	if !ok {
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s artifact; %s", name, err.Error())
+		}
+		return raw, nil
+	}
+
+	return decodeEncoded(name, str)
+}
+
+// decodeEncoded decodes the given hex-, base64- or JSON-encoded artifact or proof
+func decodeEncoded(name, str string) ([]byte, error) {
+	if json.Valid([]byte(str)) && (strings.HasPrefix(str, "{") || strings.HasPrefix(str, "[")) {
+		return []byte(str), nil
+	}
+
+	if raw, err := hex.DecodeString(strings.TrimPrefix(str, "0x")); err == nil {
+		return raw, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s; not hex- or base64-encoded", name)
+	}
+	return raw, nil
+}
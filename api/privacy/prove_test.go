@@ -0,0 +1,76 @@
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type staticProver struct {
+	proof string
+	err   error
+}
+
+func (p *staticProver) Prove(artifacts *CircuitArtifacts, witness map[string]interface{}) (string, error) {
+	return p.proof, p.err
+}
+
+func TestDecodeArtifacts(t *testing.T) {
+	circuit := &Circuit{
+		Artifacts: map[string]interface{}{
+			ArtifactBinary:       "0xdeadbeef",
+			ArtifactProvingKey:   "3q2+7w==",
+			ArtifactVerifyingKey: map[string]interface{}{"alpha": "1"},
+		},
+	}
+
+	artifacts, err := circuit.DecodeArtifacts()
+	if err != nil {
+		t.Fatalf("failed to decode artifacts; %s", err.Error())
+	}
+
+	if fmt.Sprintf("%x", artifacts.ConstraintSystem) != "deadbeef" {
+		t.Errorf("expected hex-encoded constraint system; got %x", artifacts.ConstraintSystem)
+	}
+
+	if fmt.Sprintf("%x", artifacts.ProvingKey) != "deadbeef" {
+		t.Errorf("expected base64-encoded proving key; got %x", artifacts.ProvingKey)
+	}
+
+	if string(artifacts.VerifyingKey) != `{"alpha":"1"}` {
+		t.Errorf("expected JSON verifying key; got %s", artifacts.VerifyingKey)
+	}
+}
+
+func TestProveWithOptionsLocal(t *testing.T) {
+	resp, err := ProveWithOptions("token", "circuit", map[string]interface{}{}, &ProveOptions{
+		Prover:    &staticProver{proof: "proof"},
+		Artifacts: &CircuitArtifacts{},
+		LocalOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to prove locally; %s", err.Error())
+	}
+
+	if resp.Proof == nil || *resp.Proof != "proof" {
+		t.Errorf("expected local proof; got %v", resp.Proof)
+	}
+}
+
+func TestProveWithOptionsLocalOnly(t *testing.T) {
+	_, err := ProveWithOptions("token", "circuit", map[string]interface{}{}, &ProveOptions{
+		LocalOnly: true,
+	})
+	if !errors.Is(err, ErrLocalProvingUnavailable) {
+		t.Errorf("expected local proving unavailable; got %v", err)
+	}
+
+	_, err = ProveWithOptions("token", "circuit", map[string]interface{}{}, &ProveOptions{
+		Prover:    &staticProver{err: ErrUnsupportedProvingScheme},
+		Artifacts: &CircuitArtifacts{},
+		LocalOnly: true,
+	})
+	if !errors.Is(err, ErrUnsupportedProvingScheme) {
+		t.Errorf("expected unsupported proving scheme; got %v", err)
+	}
+}
@@ -56,12 +56,9 @@ func ListCircuits(token string, params map[string]interface{}) ([]*Circuit, erro
 		return nil, fmt.Errorf("failed to list circuits; status: %v", status)
 	}
 
-	circuits := make([]*Circuit, 0)
-	for _, item := range resp.([]interface{}) {
-		circuit := &Circuit{}
-		raw, _ := json.Marshal(item)
-		json.Unmarshal(raw, &circuit)
-		circuits = append(circuits, circuit)
+	circuits, err := api.DecodeList[Circuit](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list circuits; status: %v; %s", status, err.Error())
 	}
 
 	return circuits, nil
@@ -69,21 +66,27 @@ func ListCircuits(token string, params map[string]interface{}) ([]*Circuit, erro
 
 // GetCircuitDetails fetches details for the given circuit
 func GetCircuitDetails(token, circuitID string) (*Circuit, error) {
+	_, circuit, err := getCircuitDetails(token, circuitID)
+	return circuit, err
+}
+
+// getCircuitDetails fetches the given circuit along with the response status
+func getCircuitDetails(token, circuitID string) (int, *Circuit, error) {
 	uri := fmt.Sprintf("circuits/%s", circuitID)
 	status, resp, err := InitPrivacyService(token).Get(uri, map[string]interface{}{})
 	if err != nil {
-		return nil, err
+		return status, nil, err
 	}
 
 	if status != 200 {
-		return nil, fmt.Errorf("failed to fetch circuit; status: %v", status)
+		return status, nil, fmt.Errorf("failed to fetch circuit; status: %v", status)
 	}
 
 	circuit := &Circuit{}
 	raw, _ := json.Marshal(resp)
 	json.Unmarshal(raw, &circuit)
 
-	return circuit, nil
+	return status, circuit, nil
 }
 
 // CreateCircuit creates a new circuit in the registry
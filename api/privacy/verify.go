@@ -0,0 +1,283 @@
+package privacy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+)
+
+// ProvingSchemeGroth16 is the groth16 proving scheme
+const ProvingSchemeGroth16 = "groth16"
+
+// ProvingSchemePlonk is the plonk proving scheme; plonk proofs are not verified locally and must be
+// verified using Verify
+const ProvingSchemePlonk = "plonk"
+
+// ErrUnsupportedProvingScheme is returned when local proving or verification is not supported for a proving scheme or curve
+var ErrUnsupportedProvingScheme = errors.New("local verification not supported for proving scheme")
+
+// bn254ScalarFieldOrder is the order of the BN254 (alt_bn128) scalar field
+var bn254ScalarFieldOrder, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// SnarkJSGroth16VerifyingKey is a BN254 groth16 verifying key in the snarkjs JSON encoding;
+// points are encoded as decimal or 0x-prefixed hex affine coordinates, with G2 coordinates
+// ordered [real, imaginary]. Verifying keys generated by gnark (i.e., by the privacy API) use
+// gnark's binary encoding; verify those proofs using VerifyGnarkGroth16 or VerifyLocally
+type SnarkJSGroth16VerifyingKey struct {
+	Protocol *string    `json:"protocol,omitempty"`
+	Curve    *string    `json:"curve,omitempty"`
+	Alpha    []string   `json:"vk_alpha_1"`
+	Beta     [][]string `json:"vk_beta_2"`
+	Gamma    [][]string `json:"vk_gamma_2"`
+	Delta    [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+}
+
+// SnarkJSGroth16Proof is a BN254 groth16 proof in the snarkjs JSON encoding
+type SnarkJSGroth16Proof struct {
+	A []string   `json:"pi_a"`
+	B [][]string `json:"pi_b"`
+	C []string   `json:"pi_c"`
+}
+
+// VerifyLocally verifies the given proof against the public inputs locally using the verifying key
+// of the given circuit artifacts (i.e., as returned by Circuit.DecodeArtifacts), so the proof never
+// leaves the environment of the caller. BN254 groth16 verifying keys in the gnark encoding used by
+// the privacy API and in the snarkjs JSON encoding are supported; an error wrapping
+// ErrUnsupportedProvingScheme is returned for other proving schemes (i.e., plonk) and curves.
+func VerifyLocally(artifacts *CircuitArtifacts, proof string, publicInputs []string) (bool, error) {
+	if artifacts == nil || len(artifacts.VerifyingKey) == 0 {
+		return false, errors.New("failed to verify proof; no verifying key provided")
+	}
+
+	if artifacts.Curve != "" && !isBN254(artifacts.Curve) {
+		return false, fmt.Errorf("%w; curve: %s", ErrUnsupportedProvingScheme, artifacts.Curve)
+	}
+
+	switch strings.ToLower(artifacts.ProvingScheme) {
+	case "", ProvingSchemeGroth16:
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedProvingScheme, artifacts.ProvingScheme)
+	}
+
+	prf, err := decodeEncoded("proof", proof)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify proof; %s", err.Error())
+	}
+
+	if json.Valid(artifacts.VerifyingKey) {
+		return VerifySnarkJSGroth16JSON(artifacts.VerifyingKey, prf, publicInputs)
+	}
+	return VerifyGnarkGroth16(artifacts.VerifyingKey, prf, publicInputs)
+}
+
+// VerifySnarkJSGroth16JSON verifies the given snarkjs-encoded groth16 proof against the public
+// inputs locally using the given snarkjs-encoded verifying key
+func VerifySnarkJSGroth16JSON(verifyingKey, proof json.RawMessage, publicInputs []string) (bool, error) {
+	vk := &SnarkJSGroth16VerifyingKey{}
+	err := json.Unmarshal(verifyingKey, &vk)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse groth16 verifying key; %s", err.Error())
+	}
+
+	if vk.Protocol != nil && !strings.EqualFold(*vk.Protocol, ProvingSchemeGroth16) {
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedProvingScheme, *vk.Protocol)
+	}
+
+	prf := &SnarkJSGroth16Proof{}
+	err = json.Unmarshal(proof, &prf)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse groth16 proof; %s", err.Error())
+	}
+
+	return VerifySnarkJSGroth16(vk, prf, publicInputs)
+}
+
+// VerifySnarkJSGroth16 verifies the given BN254 groth16 proof against the public inputs using the
+// given verifying key, by checking e(A, B) = e(alpha, beta) * e(vk_x, gamma) * e(C, delta)
+func VerifySnarkJSGroth16(vk *SnarkJSGroth16VerifyingKey, proof *SnarkJSGroth16Proof, publicInputs []string) (bool, error) {
+	if vk.Curve != nil && !isBN254(*vk.Curve) {
+		return false, fmt.Errorf("%w; curve: %s", ErrUnsupportedProvingScheme, *vk.Curve)
+	}
+
+	var err error
+	key := &groth16VerifyingKey{}
+	key.alpha, err = parseG1(vk.Alpha)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse verifying key alpha; %s", err.Error())
+	}
+	key.beta, err = parseG2(vk.Beta)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse verifying key beta; %s", err.Error())
+	}
+	key.gamma, err = parseG2(vk.Gamma)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse verifying key gamma; %s", err.Error())
+	}
+	key.delta, err = parseG2(vk.Delta)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse verifying key delta; %s", err.Error())
+	}
+	for _, coords := range vk.IC {
+		ic, err := parseG1(coords)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse verifying key IC; %s", err.Error())
+		}
+		key.ic = append(key.ic, ic)
+	}
+
+	a, err := parseG1(proof.A)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proof A; %s", err.Error())
+	}
+	b, err := parseG2(proof.B)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proof B; %s", err.Error())
+	}
+	c, err := parseG1(proof.C)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse proof C; %s", err.Error())
+	}
+
+	return key.verify(a, b, c, publicInputs)
+}
+
+// groth16VerifyingKey is a decoded BN254 groth16 verifying key; ic[0] is the constant term
+// and ic[i] the term of public input i
+type groth16VerifyingKey struct {
+	alpha *bn256.G1
+	beta  *bn256.G2
+	gamma *bn256.G2
+	delta *bn256.G2
+	ic    []*bn256.G1
+}
+
+// verify checks e(A, B) = e(alpha, beta) * e(vk_x, gamma) * e(C, delta), where vk_x is the
+// linear combination of the IC terms and the given public inputs
+func (vk *groth16VerifyingKey) verify(a *bn256.G1, b *bn256.G2, c *bn256.G1, publicInputs []string) (bool, error) {
+	if len(vk.ic) != len(publicInputs)+1 {
+		return false, fmt.Errorf("failed to verify groth16 proof; expected %d public inputs, got %d", len(vk.ic)-1, len(publicInputs))
+	}
+
+	if !inG2Subgroup(b) {
+		return false, errors.New("failed to verify groth16 proof; proof B is not in the G2 subgroup")
+	}
+
+	vkx := vk.ic[0]
+	for i, input := range publicInputs {
+		scalar, err := parseFieldElement(input)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse public input %d; %s", i, err.Error())
+		}
+		if scalar.Cmp(bn254ScalarFieldOrder) >= 0 {
+			return false, fmt.Errorf("failed to verify groth16 proof; public input %d exceeds scalar field", i)
+		}
+		vkx = new(bn256.G1).Add(vkx, new(bn256.G1).ScalarMult(vk.ic[i+1], scalar))
+	}
+
+	return bn256.PairingCheck(
+		[]*bn256.G1{new(bn256.G1).Neg(a), vk.alpha, vkx, c},
+		[]*bn256.G2{b, vk.beta, vk.gamma, vk.delta},
+	), nil
+}
+
+// inG2Subgroup returns true if the given point is in the prime order subgroup of the twist
+func inG2Subgroup(p *bn256.G2) bool {
+	return isZero(new(bn256.G2).ScalarMult(p, bn254ScalarFieldOrder).Marshal())
+}
+
+func isBN254(curve string) bool {
+	switch strings.ToLower(curve) {
+	case "bn254", "bn256", "bn128", "alt_bn128":
+		return true
+	}
+	return false
+}
+
+func parseFieldElement(val string) (*big.Int, error) {
+	i := new(big.Int)
+	var ok bool
+	if strings.HasPrefix(val, "0x") {
+		_, ok = i.SetString(val[2:], 16)
+	} else {
+		_, ok = i.SetString(val, 10)
+	}
+	if !ok || i.Sign() < 0 {
+		return nil, fmt.Errorf("invalid field element: %s", val)
+	}
+	return i, nil
+}
+
+// parseG1 parses affine G1 coordinates; a trailing projective z coordinate of 1 is ignored
+func parseG1(coords []string) (*bn256.G1, error) {
+	if len(coords) < 2 {
+		return nil, errors.New("invalid G1 point")
+	}
+
+	buf := make([]byte, 0, 64)
+	for _, coord := range coords[:2] {
+		el, err := parseFieldElement(coord)
+		if err != nil {
+			return nil, err
+		}
+		padded, err := padFieldElement(el)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, padded...)
+	}
+
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseG2 parses affine G2 coordinates ordered [[x.real, x.imag], [y.real, y.imag]];
+// a trailing projective z coordinate of [1, 0] is ignored
+func parseG2(coords [][]string) (*bn256.G2, error) {
+	if len(coords) < 2 {
+		return nil, errors.New("invalid G2 point")
+	}
+
+	buf := make([]byte, 0, 128)
+	for _, coord := range coords[:2] {
+		if len(coord) != 2 {
+			return nil, errors.New("invalid G2 point")
+		}
+		// the marshaled encoding orders each coordinate [imag, real]
+		for _, c := range []string{coord[1], coord[0]} {
+			el, err := parseFieldElement(c)
+			if err != nil {
+				return nil, err
+			}
+			padded, err := padFieldElement(el)
+			if err != nil {
+				return nil, err
+			}
+			buf = append(buf, padded...)
+		}
+	}
+
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// padFieldElement returns the given field element as 32 big-endian bytes
+func padFieldElement(el *big.Int) ([]byte, error) {
+	b := el.Bytes()
+	if len(b) > 32 {
+		return nil, fmt.Errorf("invalid field element: %s exceeds 32 bytes", el.String())
+	}
+	buf := make([]byte, 32)
+	copy(buf[32-len(b):], b)
+	return buf, nil
+}
@@ -0,0 +1,109 @@
+package privacy
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+)
+
+func g1Coords(p *bn256.G1) []string {
+	raw := p.Marshal()
+	return []string{
+		new(big.Int).SetBytes(raw[:32]).String(),
+		new(big.Int).SetBytes(raw[32:64]).String(),
+		"1",
+	}
+}
+
+func g2Coords(p *bn256.G2) [][]string {
+	raw := p.Marshal()
+	el := func(i int) string { return new(big.Int).SetBytes(raw[i*32 : (i+1)*32]).String() }
+	return [][]string{{el(1), el(0)}, {el(3), el(2)}, {"1", "0"}}
+}
+
+// groth16Fixture constructs a verifying key and proof which satisfy the groth16
+// verification equation for the given public input using known discrete logs
+func groth16Fixture(input int64) (*SnarkJSGroth16VerifyingKey, *SnarkJSGroth16Proof) {
+	order := bn254ScalarFieldOrder
+	alpha, beta, gamma, delta := big.NewInt(3), big.NewInt(5), big.NewInt(7), big.NewInt(11)
+	k0, k1 := big.NewInt(13), big.NewInt(17)
+	a, b := big.NewInt(19), big.NewInt(23)
+
+	// c = (ab - alpha*beta - x*gamma) / delta, where x = k0 + input*k1
+	x := new(big.Int).Add(k0, new(big.Int).Mul(big.NewInt(input), k1))
+	c := new(big.Int).Mul(a, b)
+	c.Sub(c, new(big.Int).Mul(alpha, beta))
+	c.Sub(c, new(big.Int).Mul(x, gamma))
+	c.Mul(c, new(big.Int).ModInverse(delta, order))
+	c.Mod(c, order)
+
+	curve := "bn128"
+	vk := &SnarkJSGroth16VerifyingKey{
+		Curve: &curve,
+		Alpha: g1Coords(new(bn256.G1).ScalarBaseMult(alpha)),
+		Beta:  g2Coords(new(bn256.G2).ScalarBaseMult(beta)),
+		Gamma: g2Coords(new(bn256.G2).ScalarBaseMult(gamma)),
+		Delta: g2Coords(new(bn256.G2).ScalarBaseMult(delta)),
+		IC: [][]string{
+			g1Coords(new(bn256.G1).ScalarBaseMult(k0)),
+			g1Coords(new(bn256.G1).ScalarBaseMult(k1)),
+		},
+	}
+
+	proof := &SnarkJSGroth16Proof{
+		A: g1Coords(new(bn256.G1).ScalarBaseMult(a)),
+		B: g2Coords(new(bn256.G2).ScalarBaseMult(b)),
+		C: g1Coords(new(bn256.G1).ScalarBaseMult(c)),
+	}
+
+	return vk, proof
+}
+
+func TestVerifySnarkJSGroth16(t *testing.T) {
+	vk, proof := groth16Fixture(42)
+
+	verified, err := VerifySnarkJSGroth16(vk, proof, []string{"42"})
+	if err != nil {
+		t.Fatalf("failed to verify groth16 proof; %s", err.Error())
+	}
+	if !verified {
+		t.Errorf("expected groth16 proof to verify")
+	}
+
+	verified, err = VerifySnarkJSGroth16(vk, proof, []string{"0x2b"})
+	if err != nil {
+		t.Fatalf("failed to verify groth16 proof; %s", err.Error())
+	}
+	if verified {
+		t.Errorf("expected groth16 proof not to verify for a different public input")
+	}
+
+	if _, err := VerifySnarkJSGroth16(vk, proof, []string{}); err == nil {
+		t.Errorf("expected error verifying groth16 proof with missing public inputs")
+	}
+}
+
+func TestVerifySnarkJSGroth16JSON(t *testing.T) {
+	vk, proof := groth16Fixture(42)
+	vkraw, _ := json.Marshal(vk)
+	proofraw, _ := json.Marshal(proof)
+
+	verified, err := VerifySnarkJSGroth16JSON(vkraw, proofraw, []string{"42"})
+	if err != nil || !verified {
+		t.Errorf("expected snarkjs groth16 proof to verify; %v", err)
+	}
+
+	_, err = VerifySnarkJSGroth16JSON([]byte(`{"protocol":"plonk"}`), proofraw, []string{"42"})
+	if !errors.Is(err, ErrUnsupportedProvingScheme) {
+		t.Errorf("expected unsupported proving scheme; got %v", err)
+	}
+}
+
+func TestPadFieldElementOverflow(t *testing.T) {
+	if _, err := padFieldElement(new(big.Int).Lsh(big.NewInt(1), 256)); err == nil {
+		t.Errorf("expected error padding field element exceeding 32 bytes")
+	}
+}
@@ -0,0 +1,313 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultStreamBufferSize = 64
+const defaultStreamInitialBackoff = time.Millisecond * 500
+const defaultStreamMaxBackoff = time.Second * 30
+const defaultStreamMaxLineSize = 1024 * 1024
+
+const streamContentTypeEventStream = "text/event-stream"
+
+// StreamEvent is a single server-sent event; when consuming a chunked, line-delimited
+// stream (i.e., a log tail), each non-empty line is delivered as the Data of an event
+type StreamEvent struct {
+	ID    *string
+	Event *string
+	Data  []byte
+	Retry *time.Duration
+}
+
+// StreamOptions configure a Stream
+type StreamOptions struct {
+	// InitialBackoff is the delay before the first reconnection attempt; it doubles after each
+	// consecutive failed attempt up to MaxBackoff, and is reset upon successful reconnection.
+	// A reconnection delay provided by the server using the retry field takes precedence
+	// whenever it is longer than the current backoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Stream is a long-lived subscription to a server-sent event or chunked streaming endpoint
+type Stream struct {
+	// Events delivers events in the order they are received; it is closed when the stream
+	// is terminated, either by cancelation of the context or an unrecoverable error
+	Events <-chan *StreamEvent
+
+	client         *Client
+	err            error
+	events         chan *StreamEvent
+	initialBackoff time.Duration
+	lastEventID    *string
+	maxBackoff     time.Duration
+	mutex          *sync.Mutex
+	retry          *time.Duration // reconnection delay provided by the server
+	uri            string
+	params         map[string]interface{}
+}
+
+// Err returns the error which terminated the stream, if any
+func (s *Stream) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
+}
+
+// Stream constructs and sends a GET request to a server-sent event or chunked streaming
+// endpoint; events are delivered asynchronously via the returned Stream until the given
+// context is canceled. Dropped connections are reestablished using exponential backoff
+// and, when the server provides event ids, resumed using the Last-Event-ID header.
+func (c *Client) Stream(ctx context.Context, uri string, params map[string]interface{}) (*Stream, error) {
+	return c.StreamWithOptions(ctx, uri, params, nil)
+}
+
+// StreamWithOptions is equivalent to Stream, using the given options to configure the
+// reconnection backoff
+func (c *Client) StreamWithOptions(ctx context.Context, uri string, params map[string]interface{}, opts *StreamOptions) (*Stream, error) {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+
+	events := make(chan *StreamEvent, defaultStreamBufferSize)
+	stream := &Stream{
+		Events:         events,
+		client:         c,
+		events:         events,
+		initialBackoff: opts.InitialBackoff,
+		maxBackoff:     opts.MaxBackoff,
+		mutex:          &sync.Mutex{},
+		params:         params,
+		uri:            uri,
+	}
+	if stream.initialBackoff <= 0 {
+		stream.initialBackoff = defaultStreamInitialBackoff
+	}
+	if stream.maxBackoff <= 0 {
+		stream.maxBackoff = defaultStreamMaxBackoff
+	}
+	if stream.initialBackoff > stream.maxBackoff {
+		stream.initialBackoff = stream.maxBackoff
+	}
+
+	resp, err := stream.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	go stream.run(ctx, resp)
+	return stream, nil
+}
+
+func (s *Stream) connect(ctx context.Context) (*http.Response, error) {
+	reqURL, err := url.Parse(s.client.buildURL(s.uri))
+	if err != nil {
+		return nil, err
+	}
+
+	if s.params != nil {
+		q := reqURL.Query()
+		for name := range s.params {
+			if val, valOk := s.params[name].(string); valOk {
+				q.Set(name, val)
+			}
+		}
+		reqURL.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", reqURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header = httpHeader(s.client.requestHeaders())
+	req.Header.Set("Accept", fmt.Sprintf("%s, %s", streamContentTypeEventStream, defaultContentType))
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Del("Accept-Encoding") // streams are consumed incrementally; avoid compression framing
+
+	s.mutex.Lock()
+	if s.lastEventID != nil {
+		req.Header.Set("Last-Event-ID", *s.lastEventID)
+	}
+	s.mutex.Unlock()
+
+	// no client timeout is configured, as the response body is intentionally long-lived
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: false,
+			},
+		},
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		resp.Body.Close()
+		return resp, fmt.Errorf("failed to establish stream: %s; status: %d", reqURL.String(), resp.StatusCode)
+	}
+
+	common.Log.Debugf("established %s stream: %s", resp.Header.Get("Content-Type"), reqURL.String())
+	return resp, nil
+}
+
+func (s *Stream) run(ctx context.Context, resp *http.Response) {
+	defer close(s.events)
+
+	backoff := s.initialBackoff
+	for {
+		if resp != nil {
+			err := s.consume(ctx, resp)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				common.Log.Debugf("stream %s interrupted; %s", s.uri, err.Error())
+			}
+			backoff = s.initialBackoff
+		}
+
+		delay := backoff
+		s.mutex.Lock()
+		if s.retry != nil && *s.retry > delay {
+			delay = *s.retry
+		}
+		s.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		var err error
+		resp, err = s.connect(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if resp != nil && resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
+				// client errors (i.e., revoked authorization) are not recoverable by reconnecting
+				s.mutex.Lock()
+				s.err = err
+				s.mutex.Unlock()
+				return
+			}
+
+			common.Log.Debugf("failed to reconnect stream %s; retrying in %v; %s", s.uri, backoff, err.Error())
+			resp = nil
+			backoff *= 2
+			if backoff > s.maxBackoff {
+				backoff = s.maxBackoff
+			}
+		}
+	}
+}
+
+func (s *Stream) consume(ctx context.Context, resp *http.Response) error {
+	defer resp.Body.Close()
+
+	eventStream := strings.HasPrefix(strings.ToLower(resp.Header.Get("Content-Type")), streamContentTypeEventStream)
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 4096), defaultStreamMaxLineSize)
+
+	evt := &StreamEvent{}
+	var data [][]byte
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		if !eventStream {
+			if len(bytes.TrimSpace(line)) > 0 {
+				if !s.emit(ctx, &StreamEvent{Data: append([]byte{}, line...)}) {
+					return nil
+				}
+			}
+			continue
+		}
+
+		if len(line) == 0 {
+			// a blank line dispatches the buffered event
+			if len(data) > 0 {
+				evt.Data = bytes.Join(data, []byte("\n"))
+				if evt.ID != nil {
+					s.mutex.Lock()
+					s.lastEventID = evt.ID
+					s.mutex.Unlock()
+				}
+				if !s.emit(ctx, evt) {
+					return nil
+				}
+			}
+			evt = &StreamEvent{}
+			data = nil
+			continue
+		}
+
+		if line[0] == ':' {
+			continue // comment; commonly used as a keepalive
+		}
+
+		field, value := string(line), ""
+		if i := bytes.IndexByte(line, ':'); i != -1 {
+			field = string(line[:i])
+			value = strings.TrimPrefix(string(line[i+1:]), " ")
+		}
+
+		switch field {
+		case "data":
+			data = append(data, []byte(value))
+		case "event":
+			evt.Event = common.StringOrNil(value)
+		case "id":
+			evt.ID = common.StringOrNil(value)
+		case "retry":
+			if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+				retry := time.Duration(millis) * time.Millisecond
+				evt.Retry = &retry
+				s.mutex.Lock()
+				s.retry = &retry
+				s.mutex.Unlock()
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (s *Stream) emit(ctx context.Context, evt *StreamEvent) bool {
+	select {
+	case s.events <- evt:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func httpHeader(headers map[string][]string) http.Header {
+	hdr := http.Header{}
+	for name, vals := range headers {
+		for _, val := range vals {
+			hdr.Add(name, val)
+		}
+	}
+	return hdr
+}
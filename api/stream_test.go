@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStreamWithOptionsReconnect(t *testing.T) {
+	var connections int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connections, 1)
+		if n > 1 && r.Header.Get("Last-Event-ID") != fmt.Sprintf("%d", n-1) {
+			t.Errorf("expected Last-Event-ID %d; got %s", n-1, r.Header.Get("Last-Event-ID"))
+		}
+
+		w.Header().Set("Content-Type", streamContentTypeEventStream)
+		w.WriteHeader(200)
+		fmt.Fprintf(w, "id: %d\nevent: tick\ndata: %d\n\n", n, n)
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	client := &Client{
+		Host:   srvURL.Host,
+		Path:   "api/v1",
+		Scheme: srvURL.Scheme,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	stream, err := client.StreamWithOptions(ctx, "events", nil, &StreamOptions{
+		InitialBackoff: time.Millisecond * 10,
+		MaxBackoff:     time.Millisecond * 20,
+	})
+	if err != nil {
+		t.Fatalf("failed to establish stream; %s", err.Error())
+	}
+
+	for i := 1; i <= 3; i++ {
+		select {
+		case evt := <-stream.Events:
+			if evt == nil {
+				t.Fatalf("stream closed; %v", stream.Err())
+			}
+			if string(evt.Data) != fmt.Sprintf("%d", i) {
+				t.Errorf("expected event data %d; got %s", i, string(evt.Data))
+			}
+		case <-ctx.Done():
+			t.Fatalf("timed out awaiting event %d", i)
+		}
+	}
+}
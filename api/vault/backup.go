@@ -0,0 +1,244 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// BackupStatusPending is the status of a backup or restore which has not started
+const BackupStatusPending = "pending"
+
+// BackupStatusRunning is the status of a backup or restore in progress
+const BackupStatusRunning = "running"
+
+// BackupStatusComplete is the status of a completed backup or restore
+const BackupStatusComplete = "complete"
+
+// BackupStatusFailed is the status of a failed backup or restore
+const BackupStatusFailed = "failed"
+
+// ErrBackupFailed is returned when an awaited backup or restore fails
+var ErrBackupFailed = errors.New("vault backup failed")
+
+// backupPollInterval is the interval at which backups and restores are polled by AwaitBackup and AwaitRestore
+var backupPollInterval = time.Second
+
+// BackupProgress reports the progress of a backup or restore
+type BackupProgress struct {
+	Stage     *string `json:"stage,omitempty"` // i.e., keys, secrets
+	Total     int     `json:"total"`
+	Completed int     `json:"completed"`
+}
+
+// Percent returns the completed percentage
+func (p *BackupProgress) Percent() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Completed) / float64(p.Total) * 100
+}
+
+// Backup is an encrypted export of the keys and secrets of a vault; the archive is encrypted
+// using the passphrase given upon export and is required to restore the backup
+type Backup struct {
+	api.Model
+	VaultID     *common.ID      `json:"vault_id"`
+	Status      *string         `json:"status"`
+	Progress    *BackupProgress `json:"progress,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Data        *string         `json:"data,omitempty"`     // base64-encoded, encrypted archive; present upon completion
+	Checksum    *string         `json:"checksum,omitempty"` // sha256 digest of the encrypted archive
+}
+
+// Restore is the restoration of a backup into a vault
+type Restore struct {
+	api.Model
+	VaultID  *common.ID      `json:"vault_id"`
+	Status   *string         `json:"status"`
+	Progress *BackupProgress `json:"progress,omitempty"`
+}
+
+// ExportBackup initiates an encrypted backup of the keys and secrets of the given vault; the
+// archive is encrypted using the given passphrase. The backup completes asynchronously; see AwaitBackup
+func ExportBackup(token, vaultID, passphrase string, params map[string]interface{}) (*Backup, error) {
+	uri := fmt.Sprintf("vaults/%s/backups", vaultID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, backupParams(params, passphrase))
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 && status != 202 {
+		return nil, fmt.Errorf("failed to export vault backup; status: %v; %s", status, resp)
+	}
+
+	backup := &Backup{}
+	err = api.DecodeModel(resp, backup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export vault backup; status: %v; %s", status, err.Error())
+	}
+
+	return backup, nil
+}
+
+// FetchBackup fetches the given backup of the given vault
+func FetchBackup(token, vaultID, backupID string) (*Backup, error) {
+	_, backup, err := fetchBackup(token, vaultID, backupID)
+	return backup, err
+}
+
+// fetchBackup fetches the given backup of the given vault along with the response status
+func fetchBackup(token, vaultID, backupID string) (int, *Backup, error) {
+	uri := fmt.Sprintf("vaults/%s/backups/%s", vaultID, backupID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return status, nil, err
+	}
+
+	if status != 200 {
+		return status, nil, fmt.Errorf("failed to fetch vault backup; status: %v; %s", status, resp)
+	}
+
+	backup := &Backup{}
+	err = api.DecodeModel(resp, backup)
+	if err != nil {
+		return status, nil, fmt.Errorf("failed to fetch vault backup; status: %v; %s", status, err.Error())
+	}
+
+	return status, backup, nil
+}
+
+// RestoreBackup initiates the restoration of the given backup into the given vault, which is
+// typically a newly-created vault; the restore completes asynchronously; see AwaitRestore
+func RestoreBackup(token, vaultID string, backup *Backup, passphrase string) (*Restore, error) {
+	if backup == nil || backup.Data == nil {
+		return nil, fmt.Errorf("failed to restore vault backup; no backup archive provided")
+	}
+
+	params := map[string]interface{}{
+		"data": *backup.Data,
+	}
+	if backup.Checksum != nil {
+		params["checksum"] = *backup.Checksum
+	}
+
+	uri := fmt.Sprintf("vaults/%s/restores", vaultID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, backupParams(params, passphrase))
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 && status != 202 {
+		return nil, fmt.Errorf("failed to restore vault backup; status: %v; %s", status, resp)
+	}
+
+	restore := &Restore{}
+	err = api.DecodeModel(resp, restore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore vault backup; status: %v; %s", status, err.Error())
+	}
+
+	return restore, nil
+}
+
+// FetchRestore fetches the given restore of the given vault
+func FetchRestore(token, vaultID, restoreID string) (*Restore, error) {
+	_, restore, err := fetchRestore(token, vaultID, restoreID)
+	return restore, err
+}
+
+// fetchRestore fetches the given restore of the given vault along with the response status
+func fetchRestore(token, vaultID, restoreID string) (int, *Restore, error) {
+	uri := fmt.Sprintf("vaults/%s/restores/%s", vaultID, restoreID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return status, nil, err
+	}
+
+	if status != 200 {
+		return status, nil, fmt.Errorf("failed to fetch vault restore; status: %v; %s", status, resp)
+	}
+
+	restore := &Restore{}
+	err = api.DecodeModel(resp, restore)
+	if err != nil {
+		return status, nil, fmt.Errorf("failed to fetch vault restore; status: %v; %s", status, err.Error())
+	}
+
+	return status, restore, nil
+}
+
+// AwaitBackup polls the given backup until it completes or fails, or the context is done; the
+// optional progress func is invoked with the progress reported by each poll. The backup fails
+// with ErrBackupFailed if it could not be completed; network errors, rate limiting and server
+// errors are retried, while any other error fails the backup. See api.Operation.
+func AwaitBackup(ctx context.Context, token, vaultID, backupID string, progress func(*BackupProgress)) (*Backup, error) {
+	op := api.NewOperationWithID(backupID, func(ctx context.Context, id string) (*Backup, bool, error) {
+		status, backup, err := fetchBackup(token, vaultID, id)
+		if err != nil {
+			if !api.TransientStatus(status) {
+				return nil, false, err
+			}
+			common.Log.Debugf("failed to resolve awaited vault backup %s; %s", id, err.Error())
+			return nil, false, nil
+		}
+
+		done, err := backupStatus(backup.Status, backup.Progress, progress)
+		return backup, done, err
+	})
+	op.PollInterval = backupPollInterval
+
+	return op.Wait(ctx)
+}
+
+// AwaitRestore polls the given restore until it completes or fails, or the context is done; the
+// optional progress func is invoked with the progress reported by each poll. The restore fails
+// with ErrBackupFailed if it could not be completed; network errors, rate limiting and server
+// errors are retried, while any other error fails the restore. See api.Operation.
+func AwaitRestore(ctx context.Context, token, vaultID, restoreID string, progress func(*BackupProgress)) (*Restore, error) {
+	op := api.NewOperationWithID(restoreID, func(ctx context.Context, id string) (*Restore, bool, error) {
+		status, restore, err := fetchRestore(token, vaultID, id)
+		if err != nil {
+			if !api.TransientStatus(status) {
+				return nil, false, err
+			}
+			common.Log.Debugf("failed to resolve awaited vault restore %s; %s", id, err.Error())
+			return nil, false, nil
+		}
+
+		done, err := backupStatus(restore.Status, restore.Progress, progress)
+		return restore, done, err
+	})
+	op.PollInterval = backupPollInterval
+
+	return op.Wait(ctx)
+}
+
+// backupStatus reports the given progress and returns true once the given backup or restore
+// status is terminal, along with ErrBackupFailed if it failed
+func backupStatus(status *string, p *BackupProgress, progress func(*BackupProgress)) (bool, error) {
+	if progress != nil && p != nil {
+		progress(p)
+	}
+
+	switch common.Deref(status) {
+	case BackupStatusComplete:
+		return true, nil
+	case BackupStatusFailed:
+		return true, ErrBackupFailed
+	}
+	return false, nil
+}
+
+func backupParams(params map[string]interface{}, passphrase string) map[string]interface{} {
+	_params := map[string]interface{}{}
+	for key, val := range params {
+		_params[key] = val
+	}
+	_params["passphrase"] = passphrase
+	return _params
+}
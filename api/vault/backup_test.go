@@ -0,0 +1,62 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestAwaitBackupRetriesTransientErrors(t *testing.T) {
+	backupPollInterval = time.Millisecond * 10
+	defer func() { backupPollInterval = time.Second }()
+
+	responses := []struct {
+		status int
+		body   map[string]interface{}
+	}{
+		{503, nil},
+		{429, nil},
+		{200, map[string]interface{}{"status": BackupStatusRunning, "progress": map[string]interface{}{"total": 2, "completed": 1}}},
+		{200, map[string]interface{}{"status": BackupStatusComplete, "data": "archive"}},
+		{404, nil},
+	}
+
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := responses[polls]
+		polls++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.status)
+		json.NewEncoder(w).Encode(resp.body)
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("VAULT_API_HOST", srvURL.Host)
+	t.Setenv("VAULT_API_SCHEME", srvURL.Scheme)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	reported := 0
+	backup, err := AwaitBackup(ctx, "token", "vault", "backup", func(p *BackupProgress) {
+		reported++
+	})
+	if err != nil {
+		t.Fatalf("failed to await backup; %s", err.Error())
+	}
+	if backup.Data == nil || *backup.Data != "archive" || reported != 1 {
+		t.Errorf("expected completed backup after %d progress reports; got %v", reported, backup)
+	}
+
+	_, err = AwaitRestore(ctx, "token", "vault", "restore", nil)
+	if err == nil || errors.Is(err, ErrBackupFailed) {
+		t.Errorf("expected unknown restore to fail the await; got %v", err)
+	}
+}
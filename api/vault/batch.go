@@ -0,0 +1,99 @@
+package vault
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// SignManyResult is the outcome of signing a single message using SignMany
+type SignManyResult struct {
+	SignResponse
+
+	Index int     `json:"index"` // the index of the message in the messages given to SignMany
+	Error *string `json:"error,omitempty"`
+
+	// Err is set when the message was not signed
+	Err error `json:"-"`
+}
+
+// SignMany signs each of the given messages with the given key in a single request, amortizing
+// the request overhead for high-throughput signers; messages are hex-encoded as with SignMessage.
+// A result is returned for every message, in the order given; an error is returned only when the
+// request fails outright. When the batch endpoint is not supported by vault (i.e., it responds
+// 405 or 501), the messages are signed using consecutive SignMessage requests; a 404 indicates
+// the vault or key does not exist and is returned as an error.
+func SignMany(token, vaultID, keyID string, msgs [][]byte, opts map[string]interface{}) ([]*SignManyResult, error) {
+	messages := make([]string, len(msgs))
+	for i, msg := range msgs {
+		messages[i] = hex.EncodeToString(msg)
+	}
+
+	uri := fmt.Sprintf("vaults/%s/keys/%s/sign/batch", vaultID, keyID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+		"messages": messages,
+		"options":  opts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status == 405 || status == 501 {
+		return signEach(token, vaultID, keyID, messages, opts), nil
+	}
+
+	if status != 200 && status != 201 && status != 207 {
+		return nil, fmt.Errorf("failed to sign messages with key; status: %v; %s", status, resp)
+	}
+
+	items, ok := resp.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to sign messages with key; unexpected response; status: %v", status)
+	}
+
+	results := make([]*SignManyResult, len(msgs))
+	for _, item := range items {
+		r := &SignManyResult{}
+		raw, _ := json.Marshal(item)
+		err := json.Unmarshal(raw, &r)
+		if err != nil || r.Index < 0 || r.Index >= len(msgs) {
+			continue
+		}
+
+		if r.Error != nil {
+			r.Err = fmt.Errorf("failed to sign message with key; %s", *r.Error)
+		} else if r.Signature == nil {
+			r.Err = errors.New("failed to sign message with key; vault returned no signature")
+		}
+		results[r.Index] = r
+	}
+
+	for i := range results {
+		if results[i] == nil {
+			results[i] = &SignManyResult{
+				Index: i,
+				Err:   fmt.Errorf("failed to sign message with key; no result returned for message at index %d", i),
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// signEach signs the given hex-encoded messages using consecutive SignMessage requests
+func signEach(token, vaultID, keyID string, messages []string, opts map[string]interface{}) []*SignManyResult {
+	results := make([]*SignManyResult, len(messages))
+	for i, msg := range messages {
+		results[i] = &SignManyResult{Index: i}
+		resp, err := SignMessage(token, vaultID, keyID, msg, opts)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].SignResponse = *resp
+	}
+	return results
+}
@@ -0,0 +1,98 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSignManyPerItemErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/sign/batch") {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(207)
+		json.NewEncoder(w).Encode([]interface{}{
+			map[string]interface{}{"index": 1, "error": "message too long"},
+			map[string]interface{}{"index": 0, "signature": "deadbeef"},
+		})
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	t.Setenv("VAULT_API_HOST", srvURL.Host)
+	t.Setenv("VAULT_API_SCHEME", srvURL.Scheme)
+
+	results, err := SignMany("token", "vault", "key", [][]byte{[]byte("a"), []byte("b"), []byte("c")}, nil)
+	if err != nil {
+		t.Fatalf("failed to sign messages; %s", err.Error())
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results; got %d", len(results))
+	}
+
+	if results[0].Err != nil || results[0].Signature == nil || *results[0].Signature != "deadbeef" {
+		t.Errorf("expected signature for message 0; got %v", results[0].Err)
+	}
+
+	if results[1].Err == nil {
+		t.Error("expected error for message 1")
+	}
+
+	if results[2].Err == nil || results[2].Index != 2 {
+		t.Error("expected missing result error for message 2")
+	}
+}
+
+func TestSignManyFallback(t *testing.T) {
+	for _, c := range []struct {
+		status   int
+		fallback bool
+	}{
+		{404, false},
+		{405, true},
+		{501, true},
+	} {
+		requests := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("Content-Type", "application/json")
+			if strings.HasSuffix(r.URL.Path, "/sign/batch") {
+				w.WriteHeader(c.status)
+				return
+			}
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"signature": "deadbeef"})
+		}))
+
+		srvURL, _ := url.Parse(srv.URL)
+		t.Setenv("VAULT_API_HOST", srvURL.Host)
+		t.Setenv("VAULT_API_SCHEME", srvURL.Scheme)
+
+		results, err := SignMany("token", "vault", "key", [][]byte{[]byte("a"), []byte("b")}, nil)
+		srv.Close()
+
+		if !c.fallback {
+			if err == nil || requests != 1 {
+				t.Errorf("expected status %d to be returned as an error without fallback", c.status)
+			}
+			continue
+		}
+
+		if err != nil || len(results) != 2 || requests != 3 {
+			t.Errorf("expected status %d to fall back to signing each message; %v", c.status, err)
+			continue
+		}
+		for i, result := range results {
+			if result.Err != nil || result.Signature == nil {
+				t.Errorf("expected signature for message %d after status %d fallback; %v", i, c.status, result.Err)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+// AggregateBLSSignatures aggregates the given hex-encoded BLS12-381 signatures into a single
+// hex-encoded signature without a round trip to vault; see AggregateSignatures
+func AggregateBLSSignatures(sigs []string) (string, error) {
+	_sigs, err := decodeHexValues(sigs)
+	if err != nil {
+		return "", fmt.Errorf("failed to aggregate BLS signatures; %s", err.Error())
+	}
+
+	agg, err := crypto.BLSAggregateSignatures(_sigs)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(agg), nil
+}
+
+// VerifyAggregateBLSSignature verifies the given hex-encoded aggregate BLS12-381 signature without
+// a round trip to vault; when a single message is given, it is expected to have been signed by each
+// of the given hex-encoded public keys, otherwise each message is expected to have been signed by
+// the public key at the same index
+func VerifyAggregateBLSSignature(publicKeys []string, msgs [][]byte, sig string) (bool, error) {
+	_publicKeys, err := decodeHexValues(publicKeys)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify aggregate BLS signature; %s", err.Error())
+	}
+
+	_sig, err := decodeHex(sig)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify aggregate BLS signature; %s", err.Error())
+	}
+
+	if len(msgs) == 1 {
+		return crypto.BLSFastAggregateVerify(_publicKeys, msgs[0], _sig)
+	}
+	return crypto.BLSAggregateVerify(_publicKeys, msgs, _sig)
+}
+
+func decodeHexValues(vals []string) ([][]byte, error) {
+	decoded := make([][]byte, len(vals))
+	for i, val := range vals {
+		raw, err := decodeHex(val)
+		if err != nil {
+			return nil, err
+		}
+		decoded[i] = raw
+	}
+	return decoded, nil
+}
@@ -0,0 +1,164 @@
+package vault
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+// DeriveSymmetricKey derives a new ChaCha20 key from the given ChaCha20 key using the given nonce and context
+func DeriveSymmetricKey(token, vaultID, keyID string, nonce int, context, name, description string) (*Key, error) {
+	return DeriveKey(token, vaultID, keyID, map[string]interface{}{
+		"nonce":       nonce,
+		"context":     context,
+		"name":        name,
+		"description": description,
+	})
+}
+
+// DeriveHDKey derives the key at the given BIP-32 path (i.e., m/44'/60'/0'/0/1) from the given BIP39 key
+func DeriveHDKey(token, vaultID, keyID, hdDerivationPath string) (*Key, error) {
+	if _, err := parseHDDerivationPath(hdDerivationPath); err != nil {
+		return nil, err
+	}
+
+	return DeriveKey(token, vaultID, keyID, map[string]interface{}{
+		"hd_derivation_path": hdDerivationPath,
+	})
+}
+
+// NextHDDerivationPath returns the given BIP-32 path with its final index incremented;
+// an error is returned if the final index is hardened or would exceed MaxHDIteration
+func NextHDDerivationPath(hdDerivationPath string) (string, error) {
+	segments, err := parseHDDerivationPath(hdDerivationPath)
+	if err != nil {
+		return "", err
+	}
+
+	last := segments[len(segments)-1]
+	if strings.HasSuffix(last, "'") {
+		return "", fmt.Errorf("failed to iterate HD derivation path %s; hardened index", hdDerivationPath)
+	}
+
+	index, _ := strconv.ParseUint(last, 10, 64)
+	if index >= MaxHDIteration {
+		return "", fmt.Errorf("failed to iterate HD derivation path %s; max iteration reached", hdDerivationPath)
+	}
+
+	segments[len(segments)-1] = strconv.FormatUint(index+1, 10)
+	return strings.Join(segments, "/"), nil
+}
+
+func parseHDDerivationPath(hdDerivationPath string) ([]string, error) {
+	segments := strings.Split(hdDerivationPath, "/")
+	if len(segments) < 2 || segments[0] != "m" {
+		return nil, fmt.Errorf("invalid HD derivation path: %s", hdDerivationPath)
+	}
+
+	for _, segment := range segments[1:] {
+		index, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 64)
+		if err != nil || index > MaxHDIteration {
+			return nil, fmt.Errorf("invalid HD derivation path: %s", hdDerivationPath)
+		}
+	}
+
+	return segments, nil
+}
+
+// RotateKey creates a new key with the same spec, name and description as the given key;
+// the previous key is deleted if deletePrevious is true, otherwise it remains usable for
+// verification and decryption of existing material
+func RotateKey(token, vaultID, keyID string, deletePrevious bool) (*Key, error) {
+	key, err := FetchKey(token, vaultID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.Spec == nil {
+		return nil, fmt.Errorf("failed to rotate key %s; spec not resolved", keyID)
+	}
+
+	params := map[string]interface{}{
+		"spec": *key.Spec,
+	}
+	if key.Type != nil {
+		params["type"] = *key.Type
+	}
+	if key.Usage != nil {
+		params["usage"] = *key.Usage
+	}
+	if key.Name != nil {
+		params["name"] = *key.Name
+	}
+	if key.Description != nil {
+		params["description"] = *key.Description
+	}
+
+	rotated, err := CreateKey(token, vaultID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if deletePrevious {
+		err = DeleteKey(token, vaultID, keyID)
+		if err != nil {
+			return rotated, fmt.Errorf("failed to delete key %s after rotation; %s", keyID, err.Error())
+		}
+	}
+
+	return rotated, nil
+}
+
+// PublicKeyBytes returns the decoded public key material of the key
+func (k *Key) PublicKeyBytes() ([]byte, error) {
+	if k.PublicKey == nil {
+		return nil, errors.New("key has no public key material")
+	}
+	return decodeHex(*k.PublicKey)
+}
+
+// Verify verifies the given hex-encoded signature of the given message without a round trip
+// to vault; secp256k1 messages are expected to be the 32-byte digest which was signed.
+// Verification is supported for BLS12-381, Ed25519 and secp256k1 keys.
+func (k *Key) Verify(msg []byte, sig string) (bool, error) {
+	if k.Spec == nil {
+		return false, errors.New("failed to verify signature; key spec not resolved")
+	}
+
+	publicKey, err := k.PublicKeyBytes()
+	if err != nil {
+		return false, fmt.Errorf("failed to verify signature; %s", err.Error())
+	}
+
+	sigBytes, err := decodeHex(sig)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify signature; %s", err.Error())
+	}
+
+	switch *k.Spec {
+	case KeySpecECCBLS12381:
+		return crypto.BLSVerify(publicKey, msg, sigBytes)
+	case KeySpecECCEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return false, fmt.Errorf("failed to verify signature; invalid Ed25519 public key length: %d", len(publicKey))
+		}
+		return ed25519.Verify(ed25519.PublicKey(publicKey), msg, sigBytes), nil
+	case KeySpecECCSecp256k1:
+		if len(sigBytes) == 65 {
+			sigBytes = sigBytes[:64] // drop the recovery id
+		}
+		return ethcrypto.VerifySignature(publicKey, msg, sigBytes), nil
+	}
+
+	return false, fmt.Errorf("failed to verify signature; client-side verification not supported for key spec: %s", *k.Spec)
+}
+
+func decodeHex(val string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(val, "0x"))
+}
@@ -0,0 +1,56 @@
+package vault
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+func TestNextHDDerivationPath(t *testing.T) {
+	next, err := NextHDDerivationPath("m/44'/60'/0'/0/9")
+	if err != nil {
+		t.Fatalf("failed to iterate HD derivation path; %s", err.Error())
+	}
+	if next != "m/44'/60'/0'/0/10" {
+		t.Errorf("unexpected HD derivation path: %s", next)
+	}
+
+	if _, err := NextHDDerivationPath("m/44'/60'/0'"); err == nil {
+		t.Errorf("expected error iterating hardened index")
+	}
+
+	if _, err := NextHDDerivationPath("m/44'/60'/0'/0/4294967295"); err == nil {
+		t.Errorf("expected error iterating beyond max HD iteration")
+	}
+
+	if _, err := NextHDDerivationPath("44/60"); err == nil {
+		t.Errorf("expected error iterating invalid HD derivation path")
+	}
+}
+
+func TestKeyVerifyEd25519(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	msg := []byte("hello world")
+	sig := hex.EncodeToString(ed25519.Sign(privateKey, msg))
+
+	key := &Key{
+		Spec:      common.StringOrNil(KeySpecECCEd25519),
+		PublicKey: common.StringOrNil("0x" + hex.EncodeToString(publicKey)),
+	}
+
+	verified, err := key.Verify(msg, sig)
+	if err != nil {
+		t.Fatalf("failed to verify signature; %s", err.Error())
+	}
+	if !verified {
+		t.Errorf("expected signature to verify")
+	}
+
+	verified, _ = key.Verify([]byte("tampered"), sig)
+	if verified {
+		t.Errorf("expected signature of tampered message not to verify")
+	}
+}
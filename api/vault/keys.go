@@ -0,0 +1,80 @@
+package vault
+
+import (
+	"fmt"
+)
+
+// keySpecs maps each supported key spec to its key type and usage
+var keySpecs = map[string][2]string{
+	KeySpecAES256GCM:     {KeyTypeSymmetric, KeyUsageEncryptDecrypt},
+	KeySpecChaCha20:      {KeyTypeSymmetric, KeyUsageEncryptDecrypt},
+	KeySpecECCBabyJubJub: {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecECCBIP39:      {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecECCBLS12381:   {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecECCC25519:     {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecECCEd25519:    {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecECCSecp256k1:  {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecRSA2048:       {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecRSA3072:       {KeyTypeAsymmetric, KeyUsageSignVerify},
+	KeySpecRSA4096:       {KeyTypeAsymmetric, KeyUsageSignVerify},
+}
+
+// CreateKeyWithSpec creates a new vault key using the given spec; the key type and usage
+// are resolved from the spec
+func CreateKeyWithSpec(token, vaultID, spec, name, description string) (*Key, error) {
+	typeAndUsage, ok := keySpecs[spec]
+	if !ok {
+		return nil, fmt.Errorf("failed to create vault key; unsupported key spec: %s", spec)
+	}
+
+	return CreateKey(token, vaultID, map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"spec":        spec,
+		"type":        typeAndUsage[0],
+		"usage":       typeAndUsage[1],
+	})
+}
+
+// CreateAES256GCMKey creates a new AES-256-GCM encrypt/decrypt key
+func CreateAES256GCMKey(token, vaultID, name, description string) (*Key, error) {
+	return CreateKeyWithSpec(token, vaultID, KeySpecAES256GCM, name, description)
+}
+
+// CreateBabyJubJubKey creates a new babyJubJub sign/verify key
+func CreateBabyJubJubKey(token, vaultID, name, description string) (*Key, error) {
+	return CreateKeyWithSpec(token, vaultID, KeySpecECCBabyJubJub, name, description)
+}
+
+// CreateBLS12381Key creates a new BLS12-381 sign/verify key; signatures of BLS keys may be
+// aggregated, i.e., using AggregateBLSSignatures
+func CreateBLS12381Key(token, vaultID, name, description string) (*Key, error) {
+	return CreateKeyWithSpec(token, vaultID, KeySpecECCBLS12381, name, description)
+}
+
+// CreateEd25519Key creates a new Ed25519 sign/verify key
+func CreateEd25519Key(token, vaultID, name, description string) (*Key, error) {
+	return CreateKeyWithSpec(token, vaultID, KeySpecECCEd25519, name, description)
+}
+
+// CreateSecp256k1Key creates a new secp256k1 sign/verify key
+func CreateSecp256k1Key(token, vaultID, name, description string) (*Key, error) {
+	return CreateKeyWithSpec(token, vaultID, KeySpecECCSecp256k1, name, description)
+}
+
+// CreateRSAKey creates a new RSA sign/verify key of the given bit length
+func CreateRSAKey(token, vaultID, name, description string, bits int) (*Key, error) {
+	var spec string
+	switch bits {
+	case KeyBits2048:
+		spec = KeySpecRSA2048
+	case KeyBits3072:
+		spec = KeySpecRSA3072
+	case KeyBits4096:
+		spec = KeySpecRSA4096
+	default:
+		return nil, fmt.Errorf("failed to create vault key; unsupported RSA key length: %d", bits)
+	}
+
+	return CreateKeyWithSpec(token, vaultID, spec, name, description)
+}
@@ -1,6 +1,8 @@
 package vault
 
 import (
+	"time"
+
 	uuid "github.com/kthomas/go.uuid"
 	"github.com/provideplatform/provide-go/api"
 )
@@ -32,6 +34,9 @@ const KeySpecECCBabyJubJub = "babyJubJub"
 // KeySpecECCBIP39 BIP39 key spec
 const KeySpecECCBIP39 = "BIP39"
 
+// KeySpecECCBLS12381 BLS12-381 key spec
+const KeySpecECCBLS12381 = "BLS12-381"
+
 // KeySpecECCC25519 C25519 key spec
 const KeySpecECCC25519 = "C25519"
 
@@ -97,6 +102,23 @@ type Secret struct {
 	Name        *string    `json:"name"`
 	Description *string    `json:"description"`
 	Value       *string    `json:"value,omitempty"`
+	Version     *int       `json:"version,omitempty"` // the current version of the secret
+}
+
+// SecretVersion is a single version of a secret; following rotation, the previous version
+// remains readable until it expires at the end of the rotation grace period
+type SecretVersion struct {
+	api.Model
+	SecretID  *uuid.UUID `json:"secret_id"`
+	Version   int        `json:"version"`
+	Value     *string    `json:"value,omitempty"`
+	Current   bool       `json:"current"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired returns true if the secret version has expired as of the given time
+func (v *SecretVersion) Expired(now time.Time) bool {
+	return v.ExpiresAt != nil && !now.Before(*v.ExpiresAt)
 }
 
 // EncryptDecryptRequestResponse contains the data (i.e., encrypted or decrypted) and an optional nonce
@@ -139,3 +161,9 @@ type SealUnsealRequestResponse struct {
 	UnsealerKey    *string `json:"key,omitempty"`
 	ValidationHash *string `json:"validation_hash,omitempty"`
 }
+
+// SealStatus indicates whether or not the vault is sealed
+type SealStatus struct {
+	Sealed         bool    `json:"sealed"`
+	ValidationHash *string `json:"validation_hash,omitempty"`
+}
@@ -0,0 +1,96 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// UnsealKeySource resolves the unsealer key used to unseal a vault (i.e., from env, a file or a KMS)
+type UnsealKeySource func() (string, error)
+
+// UnsealKeyFromEnv returns an UnsealKeySource which reads the unsealer key from the given
+// environment variable; VAULT_SEAL_UNSEAL_KEY is used if no variable name is provided
+func UnsealKeyFromEnv(name string) UnsealKeySource {
+	if name == "" {
+		name = "VAULT_SEAL_UNSEAL_KEY"
+	}
+
+	return func() (string, error) {
+		key := os.Getenv(name)
+		if key == "" {
+			return "", fmt.Errorf("failed to resolve vault unsealer key; %s not set", name)
+		}
+		return key, nil
+	}
+}
+
+// UnsealWithKey unseals the vault using the given unsealer key
+func UnsealWithKey(token *string, key string) error {
+	_, err := Unseal(token, map[string]interface{}{
+		"key": key,
+	})
+	return err
+}
+
+// GetSealStatus returns the seal status of the vault
+func GetSealStatus(token *string) (*SealStatus, error) {
+	status, resp, err := InitVaultService(token).Get("seal", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch vault seal status; status: %v; %s", status, resp)
+	}
+
+	r := &SealStatus{}
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vault seal status; status: %v; %s", status, err.Error())
+	}
+	err = json.Unmarshal(raw, &r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vault seal status; status: %v; %s", status, err.Error())
+	}
+
+	return r, nil
+}
+
+// AutoUnseal unseals the vault using the unsealer key resolved from the given source, if the
+// vault is sealed; it is a no-op if the vault is already unsealed. The unsealer key is read
+// from VAULT_SEAL_UNSEAL_KEY if no source is provided.
+func AutoUnseal(token *string, source UnsealKeySource) error {
+	sealStatus, err := GetSealStatus(token)
+	if err != nil {
+		return err
+	}
+
+	if !sealStatus.Sealed {
+		common.Log.Debugf("vault is unsealed; skipping auto-unseal")
+		return nil
+	}
+
+	if source == nil {
+		source = UnsealKeyFromEnv("")
+	}
+
+	key, err := source()
+	if err != nil {
+		return err
+	}
+	if key == "" {
+		return errors.New("failed to auto-unseal vault; empty unsealer key")
+	}
+
+	err = UnsealWithKey(token, key)
+	if err != nil {
+		return err
+	}
+
+	common.Log.Debugf("auto-unsealed vault")
+	return nil
+}
@@ -0,0 +1,97 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/provideplatform/provide-go/api"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// CreateSecretVersion stores a new version of the given secret; the new version becomes current
+// and previous versions are retained
+func CreateSecretVersion(token, vaultID, secretID, value string) (*SecretVersion, error) {
+	uri := fmt.Sprintf("vaults/%s/secrets/%s/versions", vaultID, secretID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+		"value": value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to create secret version; status: %v; %s", status, resp)
+	}
+
+	return decodeSecretVersion(status, resp, "failed to create secret version")
+}
+
+// ListSecretVersions retrieves a paginated list of the versions of the given secret
+func ListSecretVersions(token, vaultID, secretID string, params map[string]interface{}) ([]*SecretVersion, error) {
+	uri := fmt.Sprintf("vaults/%s/secrets/%s/versions", vaultID, secretID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch secret versions; status: %v; %s", status, resp)
+	}
+
+	versions, err := api.DecodeList[SecretVersion](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secret versions; status: %v; %s", status, err.Error())
+	}
+
+	return versions, nil
+}
+
+// FetchSecretVersion fetches the given version of a secret from the given vault
+func FetchSecretVersion(token, vaultID, secretID string, version int) (*SecretVersion, error) {
+	uri := fmt.Sprintf("vaults/%s/secrets/%s/versions/%d", vaultID, secretID, version)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch secret version; status: %v; %s", status, resp)
+	}
+
+	return decodeSecretVersion(status, resp, "failed to fetch secret version")
+}
+
+// RotateSecret stores the given value as the current version of the secret; the previous version
+// remains readable for the given grace period, allowing consumers to converge on the new value
+func RotateSecret(token, vaultID, secretID, value string, gracePeriod time.Duration) (*SecretVersion, error) {
+	uri := fmt.Sprintf("vaults/%s/secrets/%s/rotate", vaultID, secretID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Post(uri, map[string]interface{}{
+		"value":        value,
+		"grace_period": int64(gracePeriod / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 201 {
+		return nil, fmt.Errorf("failed to rotate secret; status: %v; %s", status, resp)
+	}
+
+	return decodeSecretVersion(status, resp, "failed to rotate secret")
+}
+
+func decodeSecretVersion(status int, resp interface{}, msg string) (*SecretVersion, error) {
+	version := &SecretVersion{}
+	versionraw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%s; status: %v; %s", msg, status, err.Error())
+	}
+
+	err = json.Unmarshal(versionraw, &version)
+	if err != nil {
+		return nil, fmt.Errorf("%s; status: %v; %s", msg, status, err.Error())
+	}
+
+	return version, nil
+}
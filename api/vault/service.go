@@ -81,23 +81,69 @@ func ListVaults(token string, params map[string]interface{}) ([]*Vault, error) {
 		return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, resp)
 	}
 
-	vaults := make([]*Vault, 0)
-	for _, item := range resp.([]interface{}) {
-		vlt := &Vault{}
-		vltraw, err := json.Marshal(item)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, err.Error())
-		}
-		err = json.Unmarshal(vltraw, &vlt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, err.Error())
-		}
-		vaults = append(vaults, vlt)
+	vaults, err := api.DecodeList[Vault](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vaults; status: %v; %s", status, err.Error())
 	}
 
 	return vaults, nil
 }
 
+// FetchVault fetches the given vault
+func FetchVault(token, vaultID string) (*Vault, error) {
+	uri := fmt.Sprintf("vaults/%s", vaultID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Get(uri, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, fmt.Errorf("failed to fetch vault; status: %v; %s", status, resp)
+	}
+
+	vlt := &Vault{}
+	vltraw, err := json.Marshal(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vault; status: %v; %s", status, err.Error())
+	}
+	err = json.Unmarshal(vltraw, &vlt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vault; status: %v; %s", status, err.Error())
+	}
+
+	return vlt, nil
+}
+
+// UpdateVault updates the name and/or description of the given vault
+func UpdateVault(token, vaultID string, params map[string]interface{}) error {
+	uri := fmt.Sprintf("vaults/%s", vaultID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Put(uri, params)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to update vault; status: %v; %s", status, resp)
+	}
+
+	return nil
+}
+
+// DeleteVault deletes the given vault, including all of its keys and secrets
+func DeleteVault(token, vaultID string) error {
+	uri := fmt.Sprintf("vaults/%s", vaultID)
+	status, resp, err := InitVaultService(common.StringOrNil(token)).Delete(uri)
+	if err != nil {
+		return err
+	}
+
+	if status != 204 {
+		return fmt.Errorf("failed to delete vault; status: %v; %s", status, resp)
+	}
+
+	return nil
+}
+
 // ListKeys retrieves a paginated list of vault keys
 func ListKeys(token, vaultID string, params map[string]interface{}) ([]*Key, error) {
 	uri := fmt.Sprintf("vaults/%s/keys", vaultID)
@@ -110,18 +156,9 @@ func ListKeys(token, vaultID string, params map[string]interface{}) ([]*Key, err
 		return nil, fmt.Errorf("failed to fetch keys; status: %v; %s", status, resp)
 	}
 
-	keys := make([]*Key, 0)
-	for _, item := range resp.([]interface{}) {
-		key := &Key{}
-		keyraw, err := json.Marshal(item)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list vault keys; status: %v; %s", status, err.Error())
-		}
-		err = json.Unmarshal(keyraw, &key)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list vault keys; status: %v; %s", status, err.Error())
-		}
-		keys = append(keys, key)
+	keys, err := api.DecodeList[Key](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vault keys; status: %v; %s", status, err.Error())
 	}
 
 	return keys, nil
@@ -289,18 +326,9 @@ func ListSecrets(token, vaultID string, params map[string]interface{}) ([]*Secre
 		return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, resp)
 	}
 
-	secrets := make([]*Secret, 0)
-	for _, item := range resp.([]interface{}) {
-		secret := &Secret{}
-		secretraw, err := json.Marshal(item)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, resp)
-		}
-		err = json.Unmarshal(secretraw, &secret)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, resp)
-		}
-		secrets = append(secrets, secret)
+	secrets, err := api.DecodeList[Secret](resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch secrets; status: %v; %s", status, err.Error())
 	}
 
 	return secrets, nil
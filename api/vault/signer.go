@@ -0,0 +1,152 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// EVMSigner signs EVM transactions using a secp256k1 key custodied by vault; the private
+// key never leaves the vault
+type EVMSigner struct {
+	Address ethcommon.Address
+
+	token            string
+	vaultID          string
+	keyID            string
+	hdDerivationPath *string
+}
+
+// NewEVMSigner initializes an EVMSigner for the given secp256k1 (or BIP39, when an HD derivation
+// path is provided) vault key; the signing address is resolved from the key
+func NewEVMSigner(token, vaultID, keyID string, hdDerivationPath *string) (*EVMSigner, error) {
+	key, err := FetchKey(token, vaultID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &EVMSigner{
+		token:            token,
+		vaultID:          vaultID,
+		keyID:            keyID,
+		hdDerivationPath: hdDerivationPath,
+	}
+
+	if hdDerivationPath != nil {
+		derived, err := DeriveHDKey(token, vaultID, keyID, *hdDerivationPath)
+		if err != nil {
+			return nil, err
+		}
+		key = derived
+	}
+
+	if key.Address != nil && ethcommon.IsHexAddress(*key.Address) {
+		signer.Address = ethcommon.HexToAddress(*key.Address)
+		return signer, nil
+	}
+
+	publicKey, err := key.PublicKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve address of vault key %s; %s", keyID, err.Error())
+	}
+
+	pubkey, err := ethcrypto.UnmarshalPubkey(publicKey)
+	if err != nil {
+		pubkey, err = ethcrypto.DecompressPubkey(publicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve address of vault key %s; %s", keyID, err.Error())
+	}
+
+	signer.Address = ethcrypto.PubkeyToAddress(*pubkey)
+	return signer, nil
+}
+
+// SignHash signs the given 32-byte digest, returning the signature in the [R || S || V]
+// format expected by go-ethereum, where V is 0 or 1
+func (s *EVMSigner) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("failed to sign hash; invalid digest length: %d", len(hash))
+	}
+
+	opts := map[string]interface{}{}
+	if s.hdDerivationPath != nil {
+		opts["hd_derivation_path"] = *s.hdDerivationPath
+	}
+
+	resp, err := SignMessage(s.token, s.vaultID, s.keyID, hex.EncodeToString(hash), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Signature == nil {
+		return nil, errors.New("failed to sign hash; vault returned no signature")
+	}
+
+	sig, err := decodeHex(*resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash; %s", err.Error())
+	}
+
+	return s.normalizeSignature(hash, sig)
+}
+
+// normalizeSignature returns the given signature with a recovery id of 0 or 1, recovering
+// the id if vault returned a 64-byte signature
+func (s *EVMSigner) normalizeSignature(hash, sig []byte) ([]byte, error) {
+	switch len(sig) {
+	case 65:
+		if sig[64] >= 27 {
+			sig[64] -= 27
+		}
+		return sig, nil
+	case 64:
+		for v := byte(0); v < 2; v++ {
+			candidate := append(append([]byte{}, sig...), v)
+			pubkey, err := ethcrypto.Ecrecover(hash, candidate)
+			if err != nil {
+				continue
+			}
+			if bytes.Equal(ethcrypto.Keccak256(pubkey[1:])[12:], s.Address.Bytes()) {
+				return candidate, nil
+			}
+		}
+		return nil, errors.New("failed to recover signature recovery id")
+	}
+
+	return nil, fmt.Errorf("failed to sign hash; invalid signature length: %d", len(sig))
+}
+
+// SignTx signs the given transaction using the given signer (i.e., as returned by crypto.EVMTxFactory)
+func (s *EVMSigner) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	sig, err := s.SignHash(signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.WithSignature(signer, sig)
+}
+
+// SignerFn returns a bind.SignerFn suitable for use with go-ethereum contract bindings
+func (s *EVMSigner) SignerFn() bind.SignerFn {
+	return func(signer types.Signer, address ethcommon.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != s.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		return s.SignTx(signer, tx)
+	}
+}
+
+// TransactOpts returns bind.TransactOpts which sign transactions using the vault key
+func (s *EVMSigner) TransactOpts() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:   s.Address,
+		Signer: s.SignerFn(),
+	}
+}
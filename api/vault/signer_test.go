@@ -0,0 +1,34 @@
+package vault
+
+import (
+	"bytes"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestEVMSignerNormalizeSignature(t *testing.T) {
+	privateKey, _ := ethcrypto.GenerateKey()
+	signer := &EVMSigner{Address: ethcrypto.PubkeyToAddress(privateKey.PublicKey)}
+
+	hash := ethcrypto.Keccak256([]byte("hello world"))
+	sig, _ := ethcrypto.Sign(hash, privateKey)
+
+	normalized, err := signer.normalizeSignature(hash, append([]byte{}, sig[:64]...))
+	if err != nil {
+		t.Fatalf("failed to recover signature recovery id; %s", err.Error())
+	}
+	if !bytes.Equal(normalized, sig) {
+		t.Errorf("expected recovered signature to match")
+	}
+
+	legacy := append([]byte{}, sig...)
+	legacy[64] += 27
+	normalized, err = signer.normalizeSignature(hash, legacy)
+	if err != nil {
+		t.Fatalf("failed to normalize signature; %s", err.Error())
+	}
+	if !bytes.Equal(normalized, sig) {
+		t.Errorf("expected normalized signature to match")
+	}
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultBulkFetchConcurrency = 8
+const defaultBulkFetchMaxRetries = 5
+const defaultBulkFetchInitialBackoff = time.Millisecond * 500
+const defaultBulkFetchMaxBackoff = time.Second * 30
+
+// BulkFetchOptions configure a BulkFetch
+type BulkFetchOptions struct {
+	// Concurrency is the number of workers; defaults to 8
+	Concurrency int
+
+	// RateLimited reports whether the given error indicates the remote is rate limiting requests;
+	// rate-limited fetches pause all workers for an exponentially increasing backoff and are retried
+	RateLimited func(err error) bool
+
+	// MaxRetries is the maximum number of retries of a rate-limited fetch; defaults to 5
+	MaxRetries int
+
+	// InitialBackoff is the pause following the first rate-limited fetch; it doubles after each
+	// consecutive rate-limited fetch up to MaxBackoff, and is reset upon a successful fetch
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// BulkResult is the outcome of fetching a single key
+type BulkResult[K any, V any] struct {
+	Key      K
+	Value    V
+	Err      error
+	Attempts int
+}
+
+// BulkFetchError is returned when some fetches failed; the results of the successful fetches are
+// still returned
+type BulkFetchError struct {
+	Failed int
+	Total  int
+}
+
+func (e *BulkFetchError) Error() string {
+	return fmt.Sprintf("failed to fetch %d of %d key(s)", e.Failed, e.Total)
+}
+
+// bulkThrottle pauses all workers while the remote is rate limiting requests
+type bulkThrottle struct {
+	mutex      sync.Mutex
+	backoff    time.Duration
+	pauseUntil time.Time
+	initial    time.Duration
+	max        time.Duration
+}
+
+func (t *bulkThrottle) wait(ctx context.Context) error {
+	t.mutex.Lock()
+	delay := time.Until(t.pauseUntil)
+	t.mutex.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *bulkThrottle) throttle() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if time.Now().Before(t.pauseUntil) {
+		return // another worker already paused the pool
+	}
+	if t.backoff == 0 {
+		t.backoff = t.initial
+	} else if t.backoff *= 2; t.backoff > t.max {
+		t.backoff = t.max
+	}
+	t.pauseUntil = time.Now().Add(t.backoff)
+	Log.Debugf("bulk fetch rate limited; pausing for %v", t.backoff)
+}
+
+func (t *bulkThrottle) reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.backoff = 0
+}
+
+// BulkFetch fetches each of the given keys using a pool of workers, returning results in the
+// order of the given keys; a *BulkFetchError is returned alongside the results when any fetch
+// failed, and the context error when the context is canceled before all keys are fetched
+func BulkFetch[K any, V any](ctx context.Context, keys []K, fetch func(ctx context.Context, key K) (V, error), opts *BulkFetchOptions) ([]*BulkResult[K, V], error) {
+	if opts == nil {
+		opts = &BulkFetchOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkFetchConcurrency
+	}
+	maxRetries := opts.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultBulkFetchMaxRetries
+	}
+
+	throttle := &bulkThrottle{
+		initial: opts.InitialBackoff,
+		max:     opts.MaxBackoff,
+	}
+	if throttle.initial <= 0 {
+		throttle.initial = defaultBulkFetchInitialBackoff
+	}
+	if throttle.max <= 0 {
+		throttle.max = defaultBulkFetchMaxBackoff
+	}
+
+	results := make([]*BulkResult[K, V], len(keys))
+	indices := make(chan int)
+	wg := &sync.WaitGroup{}
+
+	for w := 0; w < concurrency && w < len(keys); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				result := &BulkResult[K, V]{Key: keys[i]}
+				for {
+					if err := throttle.wait(ctx); err != nil {
+						result.Err = err
+						break
+					}
+					result.Attempts++
+					result.Value, result.Err = fetch(ctx, keys[i])
+					if result.Err == nil {
+						throttle.reset()
+						break
+					}
+					if opts.RateLimited == nil || !opts.RateLimited(result.Err) || result.Attempts > maxRetries {
+						break
+					}
+					throttle.throttle()
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	canceled := false
+	for i := range keys {
+		select {
+		case <-ctx.Done():
+			canceled = true
+		case indices <- i:
+		}
+		if canceled {
+			break
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	failed := 0
+	for i := range results {
+		if results[i] == nil {
+			results[i] = &BulkResult[K, V]{Key: keys[i], Err: ctx.Err()}
+		}
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+
+	if canceled {
+		return results, ctx.Err()
+	}
+	if failed > 0 {
+		return results, &BulkFetchError{Failed: failed, Total: len(keys)}
+	}
+	return results, nil
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errTestRateLimited = errors.New("429 too many requests")
+
+func TestBulkFetchOrderingAndPartialFailure(t *testing.T) {
+	keys := make([]int, 100)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	results, err := BulkFetch(context.TODO(), keys, func(ctx context.Context, key int) (int, error) {
+		time.Sleep(time.Duration(key%7) * time.Millisecond)
+		if key%10 == 0 {
+			return 0, errors.New("not found")
+		}
+		return key * 2, nil
+	}, &BulkFetchOptions{Concurrency: 16})
+
+	var bulkErr *BulkFetchError
+	if !errors.As(err, &bulkErr) || bulkErr.Failed != 10 || bulkErr.Total != 100 {
+		t.Fatalf("expected partial failure of 10 of 100 keys; got %v", err)
+	}
+
+	for i, result := range results {
+		if result.Key != i {
+			t.Fatalf("expected result %d to be for key %d; got %d", i, i, result.Key)
+		}
+		if i%10 != 0 && result.Value != i*2 {
+			t.Errorf("unexpected value %d for key %d", result.Value, i)
+		}
+	}
+}
+
+func TestBulkFetchRetriesRateLimited(t *testing.T) {
+	var calls int32
+	results, err := BulkFetch(context.TODO(), []string{"a", "b"}, func(ctx context.Context, key string) (string, error) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			return "", errTestRateLimited
+		}
+		return key, nil
+	}, &BulkFetchOptions{
+		Concurrency:    2,
+		InitialBackoff: time.Millisecond,
+		RateLimited: func(err error) bool {
+			return err == errTestRateLimited
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected rate-limited fetches to be retried; %s", err.Error())
+	}
+	if results[0].Value != "a" || results[1].Value != "b" || results[0].Attempts < 2 {
+		t.Errorf("unexpected results %+v %+v", results[0], results[1])
+	}
+}
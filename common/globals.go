@@ -59,8 +59,5 @@ func ResolvePublicIP() (*string, error) {
 
 // StringOrNil returns a pointer to the string, or nil if the given string is empty
 func StringOrNil(str string) *string {
-	if str == "" {
-		return nil
-	}
-	return &str
+	return PtrOrNil(str)
 }
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	uuid "github.com/kthomas/go.uuid"
+)
+
+// NilID is the zero ID
+var NilID = ID(uuid.Nil)
+
+// ID is a uuid identifying a model; unlike uuid.UUID, the zero ID is serialized as JSON null
+// and unmarshals from null or an empty string, so unset IDs are never sent as all-zero uuids.
+// As ID is a value type, omitempty has no effect; optional fields should be declared as *ID,
+// or tagged omitzero so the zero ID is omitted
+type ID uuid.UUID
+
+// NewID returns a new random (v4) ID
+func NewID() (ID, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return NilID, err
+	}
+	return ID(id), nil
+}
+
+// ParseID parses the given string as an ID
+func ParseID(str string) (ID, error) {
+	id, err := uuid.FromString(str)
+	if err != nil {
+		return NilID, fmt.Errorf("invalid id: %s; %s", str, err.Error())
+	}
+	return ID(id), nil
+}
+
+// MustParseID parses the given string as an ID, panicking if it is invalid
+func MustParseID(str string) ID {
+	id, err := ParseID(str)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// IDOrNil parses the given string as an ID, returning nil if it is empty, invalid or the zero ID
+func IDOrNil(str string) *ID {
+	id, err := ParseID(str)
+	if err != nil || id.IsZero() {
+		return nil
+	}
+	return &id
+}
+
+// UUID returns the ID as a uuid.UUID
+func (id ID) UUID() uuid.UUID {
+	return uuid.UUID(id)
+}
+
+// IsZero returns true if the ID is the zero ID
+func (id ID) IsZero() bool {
+	return id == NilID
+}
+
+// String returns the canonical string representation of the ID
+func (id ID) String() string {
+	return uuid.UUID(id).String()
+}
+
+// Validate returns an error if the ID is the zero ID or is not a valid RFC 4122 uuid
+func (id ID) Validate() error {
+	if id.IsZero() {
+		return errors.New("id is required")
+	}
+	if uuid.UUID(id).Variant() != uuid.VariantRFC4122 {
+		return fmt.Errorf("invalid id: %s; unsupported variant", id.String())
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler; the zero ID is marshaled as an empty string
+func (id ID) MarshalText() ([]byte, error) {
+	if id.IsZero() {
+		return []byte{}, nil
+	}
+	return uuid.UUID(id).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; an empty string is unmarshaled as the zero ID
+func (id *ID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = NilID
+		return nil
+	}
+
+	var u uuid.UUID
+	if err := u.UnmarshalText(text); err != nil {
+		return fmt.Errorf("invalid id: %s; %s", string(text), err.Error())
+	}
+	*id = ID(u)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler; the zero ID is marshaled as null
+func (id ID) MarshalJSON() ([]byte, error) {
+	if id.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + id.String() + `"`), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler; null and empty strings are unmarshaled as the zero ID
+func (id *ID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*id = NilID
+		return nil
+	}
+
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("invalid id: %s", string(data))
+	}
+	return id.UnmarshalText(data[1 : len(data)-1])
+}
+
+// Value implements driver.Valuer; the zero ID is stored as NULL
+func (id ID) Value() (driver.Value, error) {
+	if id.IsZero() {
+		return nil, nil
+	}
+	return id.String(), nil
+}
+
+// Scan implements sql.Scanner
+func (id *ID) Scan(src interface{}) error {
+	if src == nil {
+		*id = NilID
+		return nil
+	}
+
+	var u uuid.UUID
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+	*id = ID(u)
+	return nil
+}
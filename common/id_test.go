@@ -0,0 +1,58 @@
+package common
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIDJSON(t *testing.T) {
+	type model struct {
+		ID      ID  `json:"id"`
+		OwnerID *ID `json:"owner_id,omitempty"`
+	}
+
+	raw, err := json.Marshal(&model{})
+	if err != nil {
+		t.Fatalf("failed to marshal model; %s", err.Error())
+	}
+	if string(raw) != `{"id":null}` {
+		t.Errorf("expected zero id to marshal as null; got %s", raw)
+	}
+
+	id := MustParseID("0b1bcf4d-5c3e-4fd4-8b3a-1f7e0a7f5a2e")
+	raw, _ = json.Marshal(&model{ID: id, OwnerID: &id})
+	if string(raw) != `{"id":"0b1bcf4d-5c3e-4fd4-8b3a-1f7e0a7f5a2e","owner_id":"0b1bcf4d-5c3e-4fd4-8b3a-1f7e0a7f5a2e"}` {
+		t.Errorf("unexpected marshaled model; got %s", raw)
+	}
+
+	m := &model{ID: id}
+	err = json.Unmarshal([]byte(`{"id":"","owner_id":null}`), &m)
+	if err != nil {
+		t.Fatalf("failed to unmarshal model; %s", err.Error())
+	}
+	if !m.ID.IsZero() || m.OwnerID != nil {
+		t.Errorf("expected zero ids; got %s, %v", m.ID, m.OwnerID)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"not-a-uuid"}`), &m); err == nil {
+		t.Error("expected error unmarshaling invalid id")
+	}
+}
+
+func TestIDValidate(t *testing.T) {
+	if err := NilID.Validate(); err == nil {
+		t.Error("expected error validating zero id")
+	}
+
+	id, err := NewID()
+	if err != nil {
+		t.Fatalf("failed to generate id; %s", err.Error())
+	}
+	if err := id.Validate(); err != nil {
+		t.Errorf("expected valid id; %s", err.Error())
+	}
+
+	if IDOrNil("") != nil || IDOrNil("00000000-0000-0000-0000-000000000000") != nil {
+		t.Error("expected nil for empty and zero ids")
+	}
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// defaultMaxResponseSize is the default maximum size of a decoded response body
+const defaultMaxResponseSize = int64(64 * 1024 * 1024)
+
+// ErrResponseTooLarge is returned when a response body exceeds the maximum response size
+var ErrResponseTooLarge = errors.New("response exceeds maximum size")
+
+// MaxResponseSize returns the default maximum size, in bytes, of a response body read by the
+// REST and JSON-RPC clients; it is configurable using the MAX_RESPONSE_SIZE environment variable,
+// and a negative value disables the limit
+func MaxResponseSize() int64 {
+	if envMaxResponseSize := os.Getenv("MAX_RESPONSE_SIZE"); envMaxResponseSize != "" {
+		size, err := strconv.ParseInt(envMaxResponseSize, 10, 64)
+		if err == nil {
+			return size
+		}
+		Log.Debugf("error parsing custom max response size; using default of %d bytes; %s", defaultMaxResponseSize, err.Error())
+	}
+	return defaultMaxResponseSize
+}
+
+// LimitReader returns a reader which reads from r until n bytes have been read, after which
+// ErrResponseTooLarge is returned if r has not been exhausted; unlike io.LimitReader, an
+// oversized stream is reported instead of silently truncated
+func LimitReader(r io.Reader, n int64) io.Reader {
+	return &limitedReader{r: r, remaining: n}
+}
+
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if l.remaining <= 0 {
+		// probe for a single byte beyond the limit
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[0:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
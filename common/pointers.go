@@ -0,0 +1,66 @@
+package common
+
+import (
+	uuid "github.com/kthomas/go.uuid"
+)
+
+// Ptr returns a pointer to the given value
+func Ptr[T any](val T) *T {
+	return &val
+}
+
+// Deref returns the value referenced by the given pointer, or the zero value if it is nil
+func Deref[T any](ptr *T) T {
+	if ptr == nil {
+		var zero T
+		return zero
+	}
+	return *ptr
+}
+
+// DerefOr returns the value referenced by the given pointer, or the given default if it is nil
+func DerefOr[T any](ptr *T, dflt T) T {
+	if ptr == nil {
+		return dflt
+	}
+	return *ptr
+}
+
+// PtrOrNil returns a pointer to the given value, or nil if it is the zero value
+func PtrOrNil[T comparable](val T) *T {
+	var zero T
+	if val == zero {
+		return nil
+	}
+	return &val
+}
+
+// BoolOrNil returns a pointer to the bool, or nil if the given bool is false
+func BoolOrNil(val bool) *bool {
+	return PtrOrNil(val)
+}
+
+// UUIDOrNil parses the given string as a uuid, returning nil if it is empty or invalid
+func UUIDOrNil(str string) *uuid.UUID {
+	if str == "" {
+		return nil
+	}
+	id, err := uuid.FromString(str)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// ParseUUIDs parses the given strings as uuids, returning an error if any is invalid
+func ParseUUIDs(strs ...string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(strs))
+	for _, str := range strs {
+		id, err := uuid.FromString(str)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestPtrAndDeref(t *testing.T) {
+	if *Ptr(42) != 42 {
+		t.Errorf("expected pointer to 42")
+	}
+
+	var nilptr *string
+	if Deref(nilptr) != "" {
+		t.Errorf("expected zero value dereferencing nil pointer")
+	}
+	if DerefOr(nilptr, "default") != "default" {
+		t.Errorf("expected default value dereferencing nil pointer")
+	}
+	if Deref(Ptr("value")) != "value" {
+		t.Errorf("expected value dereferencing pointer")
+	}
+}
+
+func TestOrNil(t *testing.T) {
+	if StringOrNil("") != nil || *StringOrNil("a") != "a" {
+		t.Errorf("unexpected StringOrNil result")
+	}
+	if BoolOrNil(false) != nil || !*BoolOrNil(true) {
+		t.Errorf("unexpected BoolOrNil result")
+	}
+	if UUIDOrNil("not-a-uuid") != nil {
+		t.Errorf("expected nil parsing invalid uuid")
+	}
+	if UUIDOrNil("6ba7b810-9dad-11d1-80b4-00c04fd430c8") == nil {
+		t.Errorf("expected uuid parsing valid uuid")
+	}
+	if _, err := ParseUUIDs("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "invalid"); err == nil {
+		t.Errorf("expected error parsing invalid uuid")
+	}
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
@@ -51,8 +52,8 @@ type UsageDelegate interface {
 func RunAPIUsageDaemon(bufferSize int, flushIntervalMillis uint, delegate UsageDelegate) error {
 	if daemon != nil {
 		msg := "attempted to run API usage daemon after singleton instance started"
-		Log.Warningf(msg)
-		return fmt.Errorf(msg)
+		Log.Warning(msg)
+		return errors.New(msg)
 	}
 
 	daemon = new(usageDaemon)
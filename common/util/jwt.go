@@ -258,7 +258,7 @@ func ParseBearerAuthorizationHeader(c *gin.Context, keyfunc *func(_jwtToken *jwt
 			} else {
 				msg = fmt.Sprintf("%s; no default verification key configured", msg)
 			}
-			return nil, fmt.Errorf(msg)
+			return nil, errors.New(msg)
 		}
 
 		return publicKey, nil
@@ -0,0 +1,326 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// DeliveryHeader is the header containing the unique id of a webhook delivery
+const DeliveryHeader = "X-Provide-Delivery"
+
+// EventHeader is the header containing the webhook event type
+const EventHeader = "X-Provide-Event"
+
+// SignatureHeader is the header containing the HMAC signature of a webhook payload,
+// formatted as `t=<unix timestamp>,v1=<hex-encoded HMAC-SHA256>`
+const SignatureHeader = "X-Provide-Signature"
+
+// TokenHeader is the header containing a signed JWT attesting to a webhook payload
+const TokenHeader = "X-Provide-Webhook-Token"
+
+const defaultTimestampTolerance = time.Minute * 5
+const signatureSchemeV1 = "v1"
+const tokenPayloadHashClaim = "sha256"
+
+var (
+	// ErrReplayedDelivery is returned when a webhook delivery has already been processed
+	ErrReplayedDelivery = errors.New("webhook delivery replayed")
+
+	// ErrTimestampOutsideTolerance is returned when a webhook timestamp is too far from the current time
+	ErrTimestampOutsideTolerance = errors.New("webhook timestamp outside of tolerance")
+
+	registry      = map[string]func() interface{}{}
+	registryMutex = &sync.RWMutex{}
+)
+
+// Event is a webhook event delivered by a Provide service
+type Event struct {
+	ID        *string         `json:"id,omitempty"`
+	Type      *string         `json:"type"`
+	CreatedAt *time.Time      `json:"created_at,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+
+	// Payload is the typed representation of Data, when the event type has been registered
+	Payload interface{} `json:"-"`
+}
+
+// Signature is a parsed webhook signature header
+type Signature struct {
+	Timestamp  time.Time
+	Signatures [][]byte
+}
+
+// Verifier verifies HMAC or JWT-signed webhook payloads; when a Secret is configured, the
+// SignatureHeader is required, otherwise the TokenHeader is verified using the Keyfunc
+type Verifier struct {
+	Secret    []byte
+	Keyfunc   jwt.Keyfunc
+	Tolerance time.Duration
+	Replay    ReplayCache
+}
+
+// ReplayCache tracks webhook deliveries which have already been processed
+type ReplayCache interface {
+	// Seen returns true if the given delivery id was previously seen; otherwise
+	// the delivery id is recorded until the given expiration
+	Seen(id string, expiresAt time.Time) bool
+}
+
+type memoryReplayCache struct {
+	deliveries map[string]time.Time
+	mutex      *sync.Mutex
+}
+
+// NewMemoryReplayCache initializes an in-memory ReplayCache suitable for single-instance receivers
+func NewMemoryReplayCache() ReplayCache {
+	return &memoryReplayCache{
+		deliveries: map[string]time.Time{},
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// Seen implements ReplayCache
+func (c *memoryReplayCache) Seen(id string, expiresAt time.Time) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for deliveryID, expiry := range c.deliveries {
+		if expiry.Before(now) {
+			delete(c.deliveries, deliveryID)
+		}
+	}
+
+	if _, seen := c.deliveries[id]; seen {
+		return true
+	}
+
+	c.deliveries[id] = expiresAt
+	return false
+}
+
+// RegisterEventType registers a factory returning a pointer to the typed payload for the given event type
+func RegisterEventType(eventType string, factory func() interface{}) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	registry[eventType] = factory
+}
+
+// ParseSignatureHeader parses the given SignatureHeader value
+func ParseSignatureHeader(hdr string) (*Signature, error) {
+	sig := &Signature{
+		Signatures: make([][]byte, 0),
+	}
+
+	var timestamp *int64
+	for _, part := range strings.Split(hdr, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed webhook signature header: %s", hdr)
+		}
+
+		switch kv[0] {
+		case "t":
+			ts, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse webhook signature timestamp; %s", err.Error())
+			}
+			timestamp = &ts
+		case signatureSchemeV1:
+			val, err := hex.DecodeString(kv[1])
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode webhook signature; %s", err.Error())
+			}
+			sig.Signatures = append(sig.Signatures, val)
+		default:
+			// unsupported schemes are ignored to allow for forward-compatible rotation
+		}
+	}
+
+	if timestamp == nil {
+		return nil, fmt.Errorf("no timestamp present in webhook signature header: %s", hdr)
+	}
+
+	if len(sig.Signatures) == 0 {
+		return nil, fmt.Errorf("no %s signature present in webhook signature header", signatureSchemeV1)
+	}
+
+	sig.Timestamp = time.Unix(*timestamp, 0)
+	return sig, nil
+}
+
+// Sign returns the SignatureHeader value for the given payload and timestamp
+func Sign(secret, payload []byte, timestamp time.Time) string {
+	return fmt.Sprintf("t=%d,%s=%s", timestamp.Unix(), signatureSchemeV1, hex.EncodeToString(computeSignature(secret, payload, timestamp)))
+}
+
+func computeSignature(secret, payload []byte, timestamp time.Time) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp.Unix())))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (v *Verifier) tolerance() time.Duration {
+	if v.Tolerance > 0 {
+		return v.Tolerance
+	}
+	return defaultTimestampTolerance
+}
+
+func (v *Verifier) checkTimestamp(timestamp time.Time) error {
+	skew := time.Since(timestamp)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > v.tolerance() {
+		return ErrTimestampOutsideTolerance
+	}
+	return nil
+}
+
+// Verify the given webhook headers and payload
+func (v *Verifier) Verify(header http.Header, payload []byte) error {
+	var timestamp time.Time
+	deliveryID := header.Get(DeliveryHeader)
+
+	if v.Secret != nil {
+		sig, err := ParseSignatureHeader(header.Get(SignatureHeader))
+		if err != nil {
+			return err
+		}
+
+		err = v.checkTimestamp(sig.Timestamp)
+		if err != nil {
+			return err
+		}
+
+		expected := computeSignature(v.Secret, payload, sig.Timestamp)
+		verified := false
+		for _, signature := range sig.Signatures {
+			if hmac.Equal(expected, signature) {
+				verified = true
+				break
+			}
+		}
+
+		if !verified {
+			return errors.New("failed to verify webhook HMAC signature")
+		}
+
+		timestamp = sig.Timestamp
+	} else if v.Keyfunc != nil {
+		tokenStr := header.Get(TokenHeader)
+		if tokenStr == "" {
+			return fmt.Errorf("no %s header provided", TokenHeader)
+		}
+
+		token, err := jwt.Parse(tokenStr, v.Keyfunc)
+		if err != nil {
+			return fmt.Errorf("failed to verify webhook JWT; %s", err.Error())
+		}
+
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			return errors.New("failed to parse webhook JWT claims")
+		}
+
+		digest := sha256.Sum256(payload)
+		if hash, ok := claims[tokenPayloadHashClaim].(string); !ok || !hmac.Equal([]byte(hash), []byte(hex.EncodeToString(digest[:]))) {
+			return errors.New("webhook JWT payload hash mismatch")
+		}
+
+		iat, ok := claims["iat"].(float64)
+		if !ok {
+			return errors.New("no iat claim present in webhook JWT")
+		}
+
+		timestamp = time.Unix(int64(iat), 0)
+		err = v.checkTimestamp(timestamp)
+		if err != nil {
+			return err
+		}
+
+		if jti, ok := claims["jti"].(string); ok && deliveryID == "" {
+			deliveryID = jti
+		}
+	} else {
+		return errors.New("webhook verifier requires a secret or keyfunc")
+	}
+
+	if v.Replay != nil {
+		if deliveryID == "" {
+			return errors.New("replay protection requires a webhook delivery id")
+		}
+
+		if v.Replay.Seen(deliveryID, timestamp.Add(v.tolerance())) {
+			common.Log.Debugf("rejecting replayed webhook delivery: %s", deliveryID)
+			return ErrReplayedDelivery
+		}
+	}
+
+	return nil
+}
+
+// ParseEvent verifies and unmarshals the given webhook headers and payload; when the
+// event type has been registered, the typed payload is available via Payload
+func (v *Verifier) ParseEvent(header http.Header, payload []byte) (*Event, error) {
+	err := v.Verify(header, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	evt := &Event{}
+	err = json.Unmarshal(payload, &evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook event; %s", err.Error())
+	}
+
+	if evt.Type == nil {
+		evt.Type = common.StringOrNil(header.Get(EventHeader))
+	}
+
+	if evt.Type != nil {
+		registryMutex.RLock()
+		factory, ok := registry[*evt.Type]
+		registryMutex.RUnlock()
+
+		if ok && len(evt.Data) > 0 {
+			evt.Payload = factory()
+			err = json.Unmarshal(evt.Data, evt.Payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal %s webhook event payload; %s", *evt.Type, err.Error())
+			}
+		}
+	}
+
+	return evt, nil
+}
+
+// ParseRequest reads, verifies and unmarshals the webhook event from the given request
+func (v *Verifier) ParseRequest(r *http.Request) (*Event, error) {
+	if r.Body == nil {
+		return nil, errors.New("no webhook payload provided")
+	}
+	defer r.Body.Close()
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook payload; %s", err.Error())
+	}
+
+	return v.ParseEvent(r.Header, payload)
+}
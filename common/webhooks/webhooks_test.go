@@ -0,0 +1,59 @@
+package webhooks
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testPayload struct {
+	Name string `json:"name"`
+}
+
+func TestVerifyHMACSignedEvent(t *testing.T) {
+	RegisterEventType("test.created", func() interface{} { return &testPayload{} })
+
+	secret := []byte("s3cr3t")
+	payload := []byte(`{"id":"1","type":"test.created","data":{"name":"hello"}}`)
+
+	header := http.Header{}
+	header.Set(DeliveryHeader, "delivery-1")
+	header.Set(SignatureHeader, Sign(secret, payload, time.Now()))
+
+	verifier := &Verifier{
+		Secret: secret,
+		Replay: NewMemoryReplayCache(),
+	}
+
+	evt, err := verifier.ParseEvent(header, payload)
+	if err != nil {
+		t.Errorf("failed to verify signed webhook; %s", err.Error())
+		return
+	}
+
+	if p, ok := evt.Payload.(*testPayload); !ok || p.Name != "hello" {
+		t.Errorf("failed to resolve typed webhook payload; got %v", evt.Payload)
+	}
+
+	_, err = verifier.ParseEvent(header, payload)
+	if err != ErrReplayedDelivery {
+		t.Errorf("expected replayed delivery to be rejected; got %v", err)
+	}
+}
+
+func TestVerifyRejectsTamperedAndStaleEvents(t *testing.T) {
+	secret := []byte("s3cr3t")
+	payload := []byte(`{"type":"test.created"}`)
+	verifier := &Verifier{Secret: secret}
+
+	header := http.Header{}
+	header.Set(SignatureHeader, Sign(secret, payload, time.Now()))
+	if err := verifier.Verify(header, []byte(`{"type":"test.deleted"}`)); err == nil {
+		t.Error("expected tampered webhook payload to fail verification")
+	}
+
+	header.Set(SignatureHeader, Sign(secret, payload, time.Now().Add(-time.Hour)))
+	if err := verifier.Verify(header, payload); err != ErrTimestampOutsideTolerance {
+		t.Errorf("expected stale webhook to be rejected; got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DecodedArg is a single decoded function or event argument
+type DecodedArg struct {
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Indexed bool        `json:"indexed,omitempty"`
+	Value   interface{} `json:"value"`
+}
+
+// DecodedCall is human-readable calldata or a human-readable log
+type DecodedCall struct {
+	*Signature
+	Args []*DecodedArg `json:"args"`
+}
+
+// String renders the decoded call, i.e. `transfer(to=0x..., amount=100)`
+func (d *DecodedCall) String() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(d.Name)
+	buf.WriteString("(")
+	for i, arg := range d.Args {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(fmt.Sprintf("%s=%v", arg.Name, arg.Value))
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// DecodeCalldata decodes the given calldata using the candidate signatures for its selector;
+// when several candidates unpack, the one which round-trips to the exact calldata is preferred
+func (r *Registry) DecodeCalldata(data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("failed to decode calldata; %d byte(s) is shorter than a selector", len(data))
+	}
+
+	selector := common.Bytes2Hex(data[0:4])
+	candidates := r.LookupSelector(selector)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to decode calldata; unknown selector 0x%s", selector)
+	}
+
+	var decoded *DecodedCall
+	for _, sig := range candidates {
+		values, err := sig.Inputs.UnpackValues(data[4:])
+		if err != nil {
+			continue
+		}
+
+		call := &DecodedCall{Signature: sig, Args: decodedArgs(sig.Inputs, values)}
+		packed, err := sig.Inputs.PackValues(values)
+		if err == nil && bytes.Equal(packed, data[4:]) {
+			return call, nil
+		}
+		if decoded == nil {
+			decoded = call
+		}
+	}
+
+	if decoded == nil {
+		return nil, fmt.Errorf("failed to decode calldata for selector 0x%s; no candidate signature matched", selector)
+	}
+	return decoded, nil
+}
+
+// DecodeCalldataHex decodes the given hex-encoded calldata
+func (r *Registry) DecodeCalldataHex(data string) (*DecodedCall, error) {
+	raw, err := decodeHex(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode calldata; %s", err.Error())
+	}
+	return r.DecodeCalldata(raw)
+}
+
+// DecodeLog decodes the given log topics and data using the candidate event signatures for topic[0]
+func (r *Registry) DecodeLog(topics []common.Hash, data []byte) (*DecodedCall, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("failed to decode anonymous log")
+	}
+
+	candidates := r.LookupEvent(topics[0].Hex())
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to decode log; unknown topic %s", topics[0].Hex())
+	}
+
+	for _, sig := range candidates {
+		if indexedCount(sig.Inputs) != len(topics)-1 {
+			continue
+		}
+
+		values, err := sig.Inputs.NonIndexed().UnpackValues(data)
+		if err != nil {
+			continue
+		}
+
+		args := make([]*DecodedArg, 0, len(sig.Inputs))
+		topicIdx := 1
+		valueIdx := 0
+		for i, input := range sig.Inputs {
+			arg := &DecodedArg{Name: argName(input, i), Type: input.Type.String(), Indexed: input.Indexed}
+			if input.Indexed {
+				arg.Value = decodeTopic(input.Type, topics[topicIdx])
+				topicIdx++
+			} else {
+				arg.Value = values[valueIdx]
+				valueIdx++
+			}
+			args = append(args, arg)
+		}
+
+		return &DecodedCall{Signature: sig, Args: args}, nil
+	}
+
+	return nil, fmt.Errorf("failed to decode log for topic %s; no candidate signature matched", topics[0].Hex())
+}
+
+// DecodeCalldata decodes the given calldata using the default registry
+func DecodeCalldata(data []byte) (*DecodedCall, error) {
+	return DefaultRegistry.DecodeCalldata(data)
+}
+
+// DecodeCalldataHex decodes the given hex-encoded calldata using the default registry
+func DecodeCalldataHex(data string) (*DecodedCall, error) {
+	return DefaultRegistry.DecodeCalldataHex(data)
+}
+
+// DecodeLog decodes the given log topics and data using the default registry
+func DecodeLog(topics []common.Hash, data []byte) (*DecodedCall, error) {
+	return DefaultRegistry.DecodeLog(topics, data)
+}
+
+func decodedArgs(inputs ethabi.Arguments, values []interface{}) []*DecodedArg {
+	args := make([]*DecodedArg, 0, len(inputs))
+	for i, input := range inputs {
+		args = append(args, &DecodedArg{
+			Name:  argName(input, i),
+			Type:  input.Type.String(),
+			Value: values[i],
+		})
+	}
+	return args
+}
+
+// decodeTopic decodes a static indexed value; dynamic indexed values are only available as their hash
+func decodeTopic(typ ethabi.Type, topic common.Hash) interface{} {
+	switch typ.T {
+	case ethabi.StringTy, ethabi.BytesTy, ethabi.SliceTy, ethabi.ArrayTy, ethabi.TupleTy:
+		return topic
+	}
+
+	values, err := ethabi.Arguments{{Type: typ}}.UnpackValues(topic.Bytes())
+	if err != nil || len(values) == 0 {
+		return topic
+	}
+	return values[0]
+}
+
+func argName(arg ethabi.Argument, i int) string {
+	if arg.Name != "" {
+		return arg.Name
+	}
+	return fmt.Sprintf("arg%d", i)
+}
@@ -0,0 +1,213 @@
+// Package abi maintains a registry of known function selectors and event topics
+// so calldata, logs and traces can be rendered human-readable.
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// SignatureTypeEvent is the signature type for events
+	SignatureTypeEvent = "event"
+
+	// SignatureTypeFunction is the signature type for functions
+	SignatureTypeFunction = "function"
+)
+
+// Signature is a function or event known to the registry
+type Signature struct {
+	Name      string           `json:"name"`
+	Signature string           `json:"signature"`
+	Type      string           `json:"type"`
+	Inputs    ethabi.Arguments `json:"-"`
+	Source    string           `json:"source,omitempty"`
+}
+
+// Selector returns the 4-byte function selector, or the 32-byte topic for events, as hex
+func (s *Signature) Selector() string {
+	hash := ethcrypto.Keccak256([]byte(s.Signature))
+	if s.Type == SignatureTypeEvent {
+		return common.Bytes2Hex(hash)
+	}
+	return common.Bytes2Hex(hash[0:4])
+}
+
+// Registry is a concurrency-safe registry of function selectors and event topics
+type Registry struct {
+	mutex     sync.RWMutex
+	functions map[string][]*Signature // mapping of 4-byte selector to candidate signatures
+	events    map[string][]*Signature // mapping of topic hash to candidate signatures
+}
+
+// DefaultRegistry is seeded with the ERC-20, ERC-721 and ERC-1155 standards
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	for source, abiJSON := range standardABIs {
+		err := DefaultRegistry.RegisterABI(source, abiJSON)
+		if err != nil {
+			panic(fmt.Sprintf("failed to register %s ABI; %s", source, err.Error()))
+		}
+	}
+}
+
+// NewRegistry initializes an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		functions: map[string][]*Signature{},
+		events:    map[string][]*Signature{},
+	}
+}
+
+// RegisterABI registers all functions and events from the given ABI JSON under the given source name
+func (r *Registry) RegisterABI(source, abiJSON string) error {
+	contractABI, err := ethabi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI for %s; %s", source, err.Error())
+	}
+	r.RegisterContractABI(source, &contractABI)
+	return nil
+}
+
+// RegisterContractABI registers all functions and events from the given parsed ABI
+func (r *Registry) RegisterContractABI(source string, contractABI *ethabi.ABI) {
+	for _, method := range contractABI.Methods {
+		r.register(&Signature{
+			Name:      method.RawName,
+			Signature: method.Sig,
+			Type:      SignatureTypeFunction,
+			Inputs:    method.Inputs,
+			Source:    source,
+		})
+	}
+	for _, event := range contractABI.Events {
+		r.register(&Signature{
+			Name:      event.RawName,
+			Signature: event.Sig,
+			Type:      SignatureTypeEvent,
+			Inputs:    event.Inputs,
+			Source:    source,
+		})
+	}
+}
+
+// RegisterSignature registers a canonical text function signature, i.e. `transfer(address,uint256)`;
+// tuple parameters are not supported in text form and should be registered via RegisterABI
+func (r *Registry) RegisterSignature(sig string) error {
+	name, inputs, err := parseTextSignature(sig)
+	if err != nil {
+		return err
+	}
+	r.register(&Signature{
+		Name:      name,
+		Signature: sig,
+		Type:      SignatureTypeFunction,
+		Inputs:    inputs,
+	})
+	return nil
+}
+
+// LookupSelector returns the candidate function signatures for the given 4-byte selector
+func (r *Registry) LookupSelector(selector string) []*Signature {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]*Signature{}, r.functions[normalizeHex(selector)]...)
+}
+
+// LookupEvent returns the candidate event signatures for the given topic hash
+func (r *Registry) LookupEvent(topic string) []*Signature {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]*Signature{}, r.events[normalizeHex(topic)]...)
+}
+
+func (r *Registry) register(sig *Signature) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	index := r.functions
+	if sig.Type == SignatureTypeEvent {
+		index = r.events
+	}
+
+	key := sig.Selector()
+	for _, existing := range index[key] {
+		if existing.Signature == sig.Signature && indexedCount(existing.Inputs) == indexedCount(sig.Inputs) {
+			return
+		}
+	}
+	index[key] = append(index[key], sig)
+}
+
+// RegisterABI registers all functions and events from the given ABI JSON in the default registry
+func RegisterABI(source, abiJSON string) error {
+	return DefaultRegistry.RegisterABI(source, abiJSON)
+}
+
+// RegisterSignature registers a canonical text function signature in the default registry
+func RegisterSignature(sig string) error {
+	return DefaultRegistry.RegisterSignature(sig)
+}
+
+// LookupSelector returns the candidate function signatures for the given 4-byte selector
+// from the default registry
+func LookupSelector(selector string) []*Signature {
+	return DefaultRegistry.LookupSelector(selector)
+}
+
+// LookupEvent returns the candidate event signatures for the given topic hash from the default registry
+func LookupEvent(topic string) []*Signature {
+	return DefaultRegistry.LookupEvent(topic)
+}
+
+func indexedCount(args ethabi.Arguments) int {
+	n := 0
+	for _, arg := range args {
+		if arg.Indexed {
+			n++
+		}
+	}
+	return n
+}
+
+func normalizeHex(val string) string {
+	return strings.ToLower(strings.TrimPrefix(val, "0x"))
+}
+
+func decodeHex(val string) ([]byte, error) {
+	return hex.DecodeString(normalizeHex(val))
+}
+
+func parseTextSignature(sig string) (string, ethabi.Arguments, error) {
+	open := strings.Index(sig, "(")
+	if open < 1 || !strings.HasSuffix(sig, ")") {
+		return "", nil, fmt.Errorf("failed to parse signature %s", sig)
+	}
+	if strings.Contains(sig[open+1:], "(") {
+		return "", nil, fmt.Errorf("failed to parse signature %s; tuple parameters are not supported", sig)
+	}
+
+	name := sig[0:open]
+	params := sig[open+1 : len(sig)-1]
+	inputs := ethabi.Arguments{}
+	if params == "" {
+		return name, inputs, nil
+	}
+
+	for _, param := range strings.Split(params, ",") {
+		typ, err := ethabi.NewType(param, "", nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse signature %s; %s", sig, err.Error())
+		}
+		inputs = append(inputs, ethabi.Argument{Type: typ})
+	}
+
+	return name, inputs, nil
+}
@@ -0,0 +1,88 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLookupSelectorERC20Transfer(t *testing.T) {
+	sigs := LookupSelector("0xa9059cbb")
+	if len(sigs) != 1 {
+		t.Fatalf("expected 1 candidate for transfer selector; got %d", len(sigs))
+	}
+	if sigs[0].Signature != "transfer(address,uint256)" {
+		t.Errorf("unexpected signature %s", sigs[0].Signature)
+	}
+}
+
+func TestDecodeCalldata(t *testing.T) {
+	calldata := "0xa9059cbb" +
+		"0000000000000000000000001111111111111111111111111111111111111111" +
+		"0000000000000000000000000000000000000000000000000000000000000064"
+
+	call, err := DecodeCalldataHex(calldata)
+	if err != nil {
+		t.Fatalf("failed to decode calldata; %s", err.Error())
+	}
+	if call.Name != "transfer" || len(call.Args) != 2 {
+		t.Fatalf("unexpected decoded call %s", call.String())
+	}
+	if call.Args[0].Value.(common.Address) != common.HexToAddress("0x1111111111111111111111111111111111111111") {
+		t.Errorf("unexpected recipient %v", call.Args[0].Value)
+	}
+	if call.Args[1].Value.(*big.Int).Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("unexpected amount %v", call.Args[1].Value)
+	}
+}
+
+func TestDecodeCalldataUnknownSelector(t *testing.T) {
+	_, err := DecodeCalldataHex("0xdeadbeef")
+	if err == nil {
+		t.Error("expected error decoding unknown selector")
+	}
+}
+
+func TestRegisterSignature(t *testing.T) {
+	registry := NewRegistry()
+	err := registry.RegisterSignature("setValue(uint256,bool)")
+	if err != nil {
+		t.Fatalf("failed to register signature; %s", err.Error())
+	}
+
+	sig := &Signature{Signature: "setValue(uint256,bool)", Type: SignatureTypeFunction}
+	if len(registry.LookupSelector(sig.Selector())) != 1 {
+		t.Error("expected registered signature to resolve by selector")
+	}
+
+	if registry.RegisterSignature("bad(") == nil {
+		t.Error("expected error registering malformed signature")
+	}
+}
+
+func TestDecodeLogDisambiguatesTransfer(t *testing.T) {
+	transferTopic := ethcrypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	transfer := LookupEvent(transferTopic.Hex())
+	if len(transfer) != 2 {
+		t.Fatalf("expected ERC20 and ERC721 Transfer candidates; got %d", len(transfer))
+	}
+
+	topics := []common.Hash{
+		transferTopic,
+		common.HexToHash("0x01"),
+		common.HexToHash("0x02"),
+		common.HexToHash("0x2a"),
+	}
+	log, err := DecodeLog(topics, nil)
+	if err != nil {
+		t.Fatalf("failed to decode log; %s", err.Error())
+	}
+	if log.Source != "ERC721" {
+		t.Errorf("expected ERC721 Transfer; got %s", log.Source)
+	}
+	if log.Args[2].Value.(*big.Int).Int64() != 42 {
+		t.Errorf("unexpected token id %v", log.Args[2].Value)
+	}
+}
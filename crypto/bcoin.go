@@ -171,7 +171,11 @@ func BcoinInvokeJsonRpcClient(networkID, rpcURL, rpcAPIUser, rpcAPIKey, method s
 	}
 	defer resp.Body.Close()
 	buf := new(bytes.Buffer)
-	buf.ReadFrom(resp.Body)
+	_, err = buf.ReadFrom(rpcLimitReader(resp, 0))
+	if err != nil {
+		common.Log.Warningf("Failed to read JSON-RPC method %s response; %s", method, err.Error())
+		return err
+	}
 	err = json.Unmarshal(buf.Bytes(), response)
 	if err != nil {
 		return fmt.Errorf("Failed to unmarshal %s JSON-RPC response: %s; %s", method, buf.Bytes(), err.Error())
@@ -216,6 +220,29 @@ func BcoinResolveJsonRpcClient(networkID, rpcURL, rpcAPIUser, rpcAPIKey string)
 	return client, nil
 }
 
+// bcoinNetwork resolves network status using direct JSON-RPC
+type bcoinNetwork struct {
+	networkID  string
+	rpcURL     string
+	rpcAPIUser string
+	rpcAPIKey  string
+}
+
+// NewBcoinNetwork returns an api.NetworkStatusProvider which resolves status using direct JSON-RPC
+func NewBcoinNetwork(networkID, rpcURL, rpcAPIUser, rpcAPIKey string) api.NetworkStatusProvider {
+	return &bcoinNetwork{
+		networkID:  networkID,
+		rpcURL:     rpcURL,
+		rpcAPIUser: rpcAPIUser,
+		rpcAPIKey:  rpcAPIKey,
+	}
+}
+
+// Status implements api.NetworkStatusProvider
+func (n *bcoinNetwork) Status() (*api.NetworkStatus, error) {
+	return BcoinGetNetworkStatus(n.networkID, n.rpcURL, n.rpcAPIUser, n.rpcAPIKey)
+}
+
 // BcoinGetNetworkStatus retrieves current metadata from the JSON-RPC client;
 // returned struct includes block height, number of connected peers, protocol
 // version, and syncing state.
@@ -0,0 +1,244 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	api "github.com/provideplatform/provide-go/api/nchain"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// BeaconStateHead is the state id of the current head
+const BeaconStateHead = "head"
+
+// BeaconStateFinalized is the state id of the latest finalized state
+const BeaconStateFinalized = "finalized"
+
+// BeaconUint64 is a uint64 encoded as a quoted decimal string, per the beacon node API
+type BeaconUint64 uint64
+
+// UnmarshalJSON accepts both quoted and unquoted decimals
+func (u *BeaconUint64) UnmarshalJSON(raw []byte) error {
+	val, err := strconv.ParseUint(strings.Trim(string(raw), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse beacon uint64 %s; %s", raw, err.Error())
+	}
+	*u = BeaconUint64(val)
+	return nil
+}
+
+// MarshalJSON encodes the value as a quoted decimal
+func (u BeaconUint64) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`"%d"`, u)), nil
+}
+
+// BeaconCheckpoint is an epoch and block root
+type BeaconCheckpoint struct {
+	Epoch BeaconUint64 `json:"epoch"`
+	Root  string       `json:"root"`
+}
+
+// BeaconFinalityCheckpoints are the justified and finalized checkpoints of a state
+type BeaconFinalityCheckpoints struct {
+	PreviousJustified *BeaconCheckpoint `json:"previous_justified"`
+	CurrentJustified  *BeaconCheckpoint `json:"current_justified"`
+	Finalized         *BeaconCheckpoint `json:"finalized"`
+}
+
+// BeaconSyncStatus is the sync status of the beacon node
+type BeaconSyncStatus struct {
+	HeadSlot     BeaconUint64 `json:"head_slot"`
+	SyncDistance BeaconUint64 `json:"sync_distance"`
+	IsSyncing    bool         `json:"is_syncing"`
+	IsOptimistic bool         `json:"is_optimistic"`
+	ELOffline    bool         `json:"el_offline"`
+}
+
+// BeaconValidatorBalance is the balance of a validator, in gwei
+type BeaconValidatorBalance struct {
+	Index   BeaconUint64 `json:"index"`
+	Balance BeaconUint64 `json:"balance"`
+}
+
+// BeaconDuty is a proposer or attester duty; committee fields are only populated for attester duties
+type BeaconDuty struct {
+	Pubkey                  string        `json:"pubkey"`
+	ValidatorIndex          BeaconUint64  `json:"validator_index"`
+	Slot                    BeaconUint64  `json:"slot"`
+	CommitteeIndex          *BeaconUint64 `json:"committee_index,omitempty"`
+	CommitteeLength         *BeaconUint64 `json:"committee_length,omitempty"`
+	CommitteesAtSlot        *BeaconUint64 `json:"committees_at_slot,omitempty"`
+	ValidatorCommitteeIndex *BeaconUint64 `json:"validator_committee_index,omitempty"`
+}
+
+// BeaconGetFinalityCheckpoints returns the finality checkpoints for the given state id, i.e. head
+func BeaconGetFinalityCheckpoints(beaconURL, stateID string) (*BeaconFinalityCheckpoints, error) {
+	var checkpoints *BeaconFinalityCheckpoints
+	err := beaconInvoke(http.MethodGet, beaconURL, fmt.Sprintf("/eth/v1/beacon/states/%s/finality_checkpoints", stateID), nil, &checkpoints)
+	if err != nil {
+		return nil, err
+	}
+	return checkpoints, nil
+}
+
+// BeaconGetSyncStatus returns the sync status of the beacon node
+func BeaconGetSyncStatus(beaconURL string) (*BeaconSyncStatus, error) {
+	var status *BeaconSyncStatus
+	err := beaconInvoke(http.MethodGet, beaconURL, "/eth/v1/node/syncing", nil, &status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// BeaconGetValidatorBalances returns the balances of the given validators (by index or pubkey) at the given state id
+func BeaconGetValidatorBalances(beaconURL, stateID string, validatorIDs ...string) ([]*BeaconValidatorBalance, error) {
+	path := fmt.Sprintf("/eth/v1/beacon/states/%s/validator_balances", stateID)
+	if len(validatorIDs) > 0 {
+		path = fmt.Sprintf("%s?id=%s", path, strings.Join(validatorIDs, ","))
+	}
+
+	balances := make([]*BeaconValidatorBalance, 0)
+	err := beaconInvoke(http.MethodGet, beaconURL, path, nil, &balances)
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// BeaconGetProposerDuties returns the block proposer duties for the given epoch
+func BeaconGetProposerDuties(beaconURL string, epoch uint64) ([]*BeaconDuty, error) {
+	duties := make([]*BeaconDuty, 0)
+	err := beaconInvoke(http.MethodGet, beaconURL, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), nil, &duties)
+	if err != nil {
+		return nil, err
+	}
+	return duties, nil
+}
+
+// BeaconGetAttesterDuties returns the attester duties of the given validator indices for the given epoch
+func BeaconGetAttesterDuties(beaconURL string, epoch uint64, validatorIndices []uint64) ([]*BeaconDuty, error) {
+	indices := make([]string, 0, len(validatorIndices))
+	for _, idx := range validatorIndices {
+		indices = append(indices, strconv.FormatUint(idx, 10))
+	}
+
+	duties := make([]*BeaconDuty, 0)
+	err := beaconInvoke(http.MethodPost, beaconURL, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), indices, &duties)
+	if err != nil {
+		return nil, err
+	}
+	return duties, nil
+}
+
+// BeaconGetFinalizedBlockNumber returns the execution block number of the latest finalized beacon block
+func BeaconGetFinalizedBlockNumber(beaconURL string) (uint64, error) {
+	var block struct {
+		Message struct {
+			Body struct {
+				ExecutionPayload *struct {
+					BlockNumber BeaconUint64 `json:"block_number"`
+				} `json:"execution_payload"`
+			} `json:"body"`
+		} `json:"message"`
+	}
+	err := beaconInvoke(http.MethodGet, beaconURL, "/eth/v2/beacon/blocks/finalized", nil, &block)
+	if err != nil {
+		return 0, err
+	}
+	if block.Message.Body.ExecutionPayload == nil {
+		return 0, fmt.Errorf("failed to resolve finalized block number; finalized beacon block has no execution payload")
+	}
+	return uint64(block.Message.Body.ExecutionPayload.BlockNumber), nil
+}
+
+// EVMGetNetworkStatusWithBeacon resolves the network status from the execution client and enriches
+// it with the finalized block and consensus sync state reported by the given beacon node
+func EVMGetNetworkStatusWithBeacon(rpcClientKey, rpcURL, beaconURL string) (*api.NetworkStatus, error) {
+	status, err := EVMGetNetworkStatus(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	if status.Meta == nil {
+		status.Meta = map[string]interface{}{}
+	}
+
+	finalizedBlock, err := BeaconGetFinalizedBlockNumber(beaconURL)
+	if err != nil {
+		prvdcommon.Log.Warningf("failed to resolve finalized block from beacon node; %s", err.Error())
+		status.Meta["beacon_error"] = err.Error()
+		return status, nil
+	}
+	status.FinalizedBlock = &finalizedBlock
+
+	syncStatus, err := BeaconGetSyncStatus(beaconURL)
+	if err == nil {
+		status.Meta["beacon_sync_status"] = syncStatus
+		if syncStatus.IsSyncing {
+			status.Syncing = true
+		}
+	}
+
+	checkpoints, err := BeaconGetFinalityCheckpoints(beaconURL, BeaconStateHead)
+	if err == nil && checkpoints.Finalized != nil {
+		status.Meta["finalized_epoch"] = uint64(checkpoints.Finalized.Epoch)
+	}
+
+	return status, nil
+}
+
+// beaconInvoke invokes the beacon node REST API and unmarshals the `data` of the response
+func beaconInvoke(method, beaconURL, path string, params interface{}, result interface{}) error {
+	var body *bytes.Reader
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("failed to marshal beacon node request; %s", err.Error())
+		}
+		body = bytes.NewReader(raw)
+	} else {
+		body = bytes.NewReader([]byte{})
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", strings.TrimSuffix(beaconURL, "/"), path), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if params != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: rpcTimeout()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to invoke beacon node API %s; %s", path, err.Error())
+	}
+	defer resp.Body.Close()
+
+	buf := new(bytes.Buffer)
+	_, err = buf.ReadFrom(rpcLimitReader(resp, 0))
+	if err != nil {
+		return fmt.Errorf("failed to read beacon node API %s response; %s", path, err.Error())
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to invoke beacon node API %s; status: %v; %s", path, resp.StatusCode, buf.String())
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	err = json.Unmarshal(buf.Bytes(), &envelope)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal beacon node API %s response; %s", path, err.Error())
+	}
+	err = json.Unmarshal(envelope.Data, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal beacon node API %s data; %s", path, err.Error())
+	}
+	return nil
+}
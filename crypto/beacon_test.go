@@ -0,0 +1,36 @@
+package crypto
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBeaconGetFinalityCheckpoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/beacon/states/head/finality_checkpoints" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":404,"message":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"data":{
+			"previous_justified":{"epoch":"10","root":"0x01"},
+			"current_justified":{"epoch":"11","root":"0x02"},
+			"finalized":{"epoch":"9","root":"0x03"}
+		}}`))
+	}))
+	defer srv.Close()
+
+	checkpoints, err := BeaconGetFinalityCheckpoints(srv.URL, BeaconStateHead)
+	if err != nil {
+		t.Fatalf("failed to fetch finality checkpoints; %s", err.Error())
+	}
+	if checkpoints.Finalized.Epoch != 9 || checkpoints.CurrentJustified.Epoch != 11 {
+		t.Errorf("unexpected checkpoints %v", checkpoints)
+	}
+
+	_, err = BeaconGetSyncStatus(srv.URL)
+	if err == nil {
+		t.Error("expected error for failed beacon node request")
+	}
+}
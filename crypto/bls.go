@@ -0,0 +1,435 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+// BLSDomainSeparationTag is the hash-to-curve domain separation tag of the BLS12-381 minimal-pubkey-size
+// proof-of-possession signature scheme (i.e., as used by Ethereum consensus clients)
+const BLSDomainSeparationTag = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+// BLSPublicKeySize is the size of a compressed BLS12-381 public key (G1 point)
+const BLSPublicKeySize = 48
+
+// BLSSecretKeySize is the size of a BLS12-381 secret key
+const BLSSecretKeySize = 32
+
+// BLSSignatureSize is the size of a compressed BLS12-381 signature (G2 point)
+const BLSSignatureSize = 96
+
+const blsCompressionFlag = 0x80
+const blsInfinityFlag = 0x40
+const blsSignFlag = 0x20
+
+var (
+	blsFieldModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+	blsGroupOrder, _   = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	blsFieldHalf    = new(big.Int).Rsh(new(big.Int).Sub(blsFieldModulus, big.NewInt(1)), 1)
+	blsSqrtExponent = new(big.Int).Rsh(new(big.Int).Add(blsFieldModulus, big.NewInt(1)), 2) // p = 3 mod 4
+)
+
+// BLSGenerateKey generates a random BLS12-381 secret key, returning the secret key and its compressed public key
+func BLSGenerateKey() ([]byte, []byte, error) {
+	sk, err := rand.Int(rand.Reader, new(big.Int).Sub(blsGroupOrder, big.NewInt(1)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate BLS secret key; %s", err.Error())
+	}
+	sk.Add(sk, big.NewInt(1))
+
+	secretKey := make([]byte, BLSSecretKeySize)
+	sk.FillBytes(secretKey)
+
+	publicKey, err := BLSPublicKey(secretKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return secretKey, publicKey, nil
+}
+
+// BLSPublicKey returns the compressed public key of the given secret key
+func BLSPublicKey(secretKey []byte) ([]byte, error) {
+	sk, err := blsSecretKey(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	g1 := bls12381.NewG1()
+	pk := g1.New()
+	g1.MulScalar(pk, g1.One(), sk)
+	return blsCompressG1(g1, pk), nil
+}
+
+// BLSSign signs the given message using the given secret key, returning the compressed signature
+func BLSSign(secretKey, msg []byte) ([]byte, error) {
+	sk, err := blsSecretKey(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	g2 := bls12381.NewG2()
+	h, err := blsHashToG2(g2, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := g2.New()
+	g2.MulScalar(sig, h, sk)
+	return blsCompressG2(g2, sig), nil
+}
+
+// BLSVerify verifies the given compressed signature of the given message
+func BLSVerify(publicKey, msg, sig []byte) (bool, error) {
+	return BLSAggregateVerify([][]byte{publicKey}, [][]byte{msg}, sig)
+}
+
+// BLSAggregateSignatures aggregates the given compressed signatures into a single compressed signature
+func BLSAggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("failed to aggregate BLS signatures; no signatures provided")
+	}
+
+	g2 := bls12381.NewG2()
+	agg := g2.Zero()
+	for i, sig := range sigs {
+		p, err := blsDecompressG2(g2, sig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate BLS signatures; invalid signature at index %d; %s", i, err.Error())
+		}
+		g2.Add(agg, agg, p)
+	}
+	return blsCompressG2(g2, agg), nil
+}
+
+// BLSAggregatePublicKeys aggregates the given compressed public keys into a single compressed public key
+func BLSAggregatePublicKeys(publicKeys [][]byte) ([]byte, error) {
+	if len(publicKeys) == 0 {
+		return nil, errors.New("failed to aggregate BLS public keys; no public keys provided")
+	}
+
+	g1 := bls12381.NewG1()
+	agg := g1.Zero()
+	for i, publicKey := range publicKeys {
+		p, err := blsDecompressG1(g1, publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate BLS public keys; invalid public key at index %d; %s", i, err.Error())
+		}
+		g1.Add(agg, agg, p)
+	}
+	return blsCompressG1(g1, agg), nil
+}
+
+// BLSFastAggregateVerify verifies the given aggregate signature of a single message signed by each of
+// the given public keys; the public keys are expected to have proven possession of their secret keys
+func BLSFastAggregateVerify(publicKeys [][]byte, msg, sig []byte) (bool, error) {
+	publicKey, err := BLSAggregatePublicKeys(publicKeys)
+	if err != nil {
+		return false, err
+	}
+	return BLSVerify(publicKey, msg, sig)
+}
+
+// BLSAggregateVerify verifies the given aggregate signature of the given messages, where each message
+// was signed by the public key at the same index
+func BLSAggregateVerify(publicKeys, msgs [][]byte, sig []byte) (bool, error) {
+	if len(publicKeys) == 0 || len(publicKeys) != len(msgs) {
+		return false, errors.New("failed to verify BLS signature; public key and message counts must match")
+	}
+
+	g1 := bls12381.NewG1()
+	g2 := bls12381.NewG2()
+
+	s, err := blsDecompressG2(g2, sig)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify BLS signature; invalid signature; %s", err.Error())
+	}
+
+	engine := bls12381.NewPairingEngine()
+	for i := range publicKeys {
+		pk, err := blsDecompressG1(g1, publicKeys[i])
+		if err != nil {
+			return false, fmt.Errorf("failed to verify BLS signature; invalid public key at index %d; %s", i, err.Error())
+		}
+		if g1.IsZero(pk) {
+			return false, nil
+		}
+
+		h, err := blsHashToG2(g2, msgs[i])
+		if err != nil {
+			return false, err
+		}
+		engine.AddPair(pk, h)
+	}
+	engine.AddPairInv(g1.One(), s)
+
+	return engine.Check(), nil
+}
+
+func blsSecretKey(secretKey []byte) (*big.Int, error) {
+	if len(secretKey) != BLSSecretKeySize {
+		return nil, fmt.Errorf("invalid BLS secret key length: %d", len(secretKey))
+	}
+	sk := new(big.Int).SetBytes(secretKey)
+	if sk.Sign() == 0 || sk.Cmp(blsGroupOrder) >= 0 {
+		return nil, errors.New("invalid BLS secret key")
+	}
+	return sk, nil
+}
+
+// blsHashToG2 hashes the given message to a G2 point using expand_message_xmd with SHA-256 and the
+// simplified SWU map (hash_to_curve; see RFC 9380)
+func blsHashToG2(g2 *bls12381.G2, msg []byte) (*bls12381.PointG2, error) {
+	uniform, err := blsExpandMessageXMD(msg, []byte(BLSDomainSeparationTag), 256)
+	if err != nil {
+		return nil, err
+	}
+
+	q := g2.Zero()
+	for i := 0; i < 2; i++ {
+		c0 := blsFieldElement(uniform[i*128 : i*128+64])
+		c1 := blsFieldElement(uniform[i*128+64 : i*128+128])
+
+		p, err := g2.MapToCurve(append(c1, c0...)) // c1 || c0
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash message to curve; %s", err.Error())
+		}
+		g2.Add(q, q, p)
+	}
+	return q, nil
+}
+
+// blsExpandMessageXMD implements expand_message_xmd using SHA-256
+func blsExpandMessageXMD(msg, dst []byte, length int) ([]byte, error) {
+	ell := (length + sha256.Size - 1) / sha256.Size
+	if ell > 255 || len(dst) > 255 {
+		return nil, errors.New("failed to expand message; invalid length")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, sha256.BlockSize))
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	out := make([]byte, 0, ell*sha256.Size)
+	out = append(out, bi...)
+	for i := 2; i <= ell; i++ {
+		xored := make([]byte, sha256.Size)
+		for j := range xored {
+			xored[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(xored)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[0:length], nil
+}
+
+// blsFieldElement reduces the given bytes modulo the field modulus, returning the 48-byte encoding
+func blsFieldElement(in []byte) []byte {
+	e := new(big.Int).SetBytes(in)
+	e.Mod(e, blsFieldModulus)
+	out := make([]byte, 48)
+	e.FillBytes(out)
+	return out
+}
+
+func blsCompressG1(g1 *bls12381.G1, p *bls12381.PointG1) []byte {
+	out := make([]byte, BLSPublicKeySize)
+	if g1.IsZero(p) {
+		out[0] = blsCompressionFlag | blsInfinityFlag
+		return out
+	}
+
+	raw := g1.ToBytes(p)
+	copy(out, raw[0:48])
+	out[0] |= blsCompressionFlag
+	if new(big.Int).SetBytes(raw[48:96]).Cmp(blsFieldHalf) > 0 {
+		out[0] |= blsSignFlag
+	}
+	return out
+}
+
+func blsDecompressG1(g1 *bls12381.G1, in []byte) (*bls12381.PointG1, error) {
+	if len(in) != BLSPublicKeySize || in[0]&blsCompressionFlag == 0 {
+		return nil, errors.New("invalid compressed G1 point")
+	}
+	if in[0]&blsInfinityFlag != 0 {
+		return g1.Zero(), nil
+	}
+
+	xBytes := append([]byte{}, in...)
+	xBytes[0] &= 0x1f
+	x := new(big.Int).SetBytes(xBytes)
+	if x.Cmp(blsFieldModulus) >= 0 {
+		return nil, errors.New("invalid compressed G1 point; x is not a field element")
+	}
+
+	// y^2 = x^3 + 4
+	rhs := new(big.Int).Exp(x, big.NewInt(3), blsFieldModulus)
+	rhs.Add(rhs, big.NewInt(4)).Mod(rhs, blsFieldModulus)
+	y, ok := blsFpSqrt(rhs)
+	if !ok {
+		return nil, errors.New("invalid compressed G1 point; not on curve")
+	}
+	if (y.Cmp(blsFieldHalf) > 0) != (in[0]&blsSignFlag != 0) {
+		y.Sub(blsFieldModulus, y)
+	}
+
+	raw := make([]byte, 96)
+	x.FillBytes(raw[0:48])
+	y.FillBytes(raw[48:96])
+	p, err := g1.FromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !g1.InCorrectSubgroup(p) {
+		return nil, errors.New("invalid compressed G1 point; not in the correct subgroup")
+	}
+	return p, nil
+}
+
+func blsCompressG2(g2 *bls12381.G2, p *bls12381.PointG2) []byte {
+	out := make([]byte, BLSSignatureSize)
+	if g2.IsZero(p) {
+		out[0] = blsCompressionFlag | blsInfinityFlag
+		return out
+	}
+
+	raw := g2.ToBytes(p) // x.c1 || x.c0 || y.c1 || y.c0
+	copy(out, raw[0:96])
+	out[0] |= blsCompressionFlag
+
+	y1 := new(big.Int).SetBytes(raw[96:144])
+	y0 := new(big.Int).SetBytes(raw[144:192])
+	if blsFp2Lexicographic(y0, y1) {
+		out[0] |= blsSignFlag
+	}
+	return out
+}
+
+func blsDecompressG2(g2 *bls12381.G2, in []byte) (*bls12381.PointG2, error) {
+	if len(in) != BLSSignatureSize || in[0]&blsCompressionFlag == 0 {
+		return nil, errors.New("invalid compressed G2 point")
+	}
+	if in[0]&blsInfinityFlag != 0 {
+		return g2.Zero(), nil
+	}
+
+	xBytes := append([]byte{}, in...)
+	xBytes[0] &= 0x1f
+	x1 := new(big.Int).SetBytes(xBytes[0:48])
+	x0 := new(big.Int).SetBytes(xBytes[48:96])
+	if x0.Cmp(blsFieldModulus) >= 0 || x1.Cmp(blsFieldModulus) >= 0 {
+		return nil, errors.New("invalid compressed G2 point; x is not a field element")
+	}
+
+	// y^2 = x^3 + 4(1 + i)
+	r0, r1 := blsFp2Mul(x0, x1, x0, x1)
+	r0, r1 = blsFp2Mul(r0, r1, x0, x1)
+	r0.Add(r0, big.NewInt(4)).Mod(r0, blsFieldModulus)
+	r1.Add(r1, big.NewInt(4)).Mod(r1, blsFieldModulus)
+
+	y0, y1, ok := blsFp2Sqrt(r0, r1)
+	if !ok {
+		return nil, errors.New("invalid compressed G2 point; not on curve")
+	}
+	if blsFp2Lexicographic(y0, y1) != (in[0]&blsSignFlag != 0) {
+		y0.Sub(blsFieldModulus, y0).Mod(y0, blsFieldModulus)
+		y1.Sub(blsFieldModulus, y1).Mod(y1, blsFieldModulus)
+	}
+
+	raw := make([]byte, 192)
+	x1.FillBytes(raw[0:48])
+	x0.FillBytes(raw[48:96])
+	y1.FillBytes(raw[96:144])
+	y0.FillBytes(raw[144:192])
+	p, err := g2.FromBytes(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !g2.InCorrectSubgroup(p) {
+		return nil, errors.New("invalid compressed G2 point; not in the correct subgroup")
+	}
+	return p, nil
+}
+
+// blsFpSqrt returns the square root of the given field element, if one exists
+func blsFpSqrt(a *big.Int) (*big.Int, bool) {
+	s := new(big.Int).Exp(a, blsSqrtExponent, blsFieldModulus)
+	check := new(big.Int).Mul(s, s)
+	check.Mod(check, blsFieldModulus)
+	return s, check.Cmp(new(big.Int).Mod(a, blsFieldModulus)) == 0
+}
+
+// blsFp2Mul returns (a0 + a1*i) * (b0 + b1*i), where i^2 = -1
+func blsFp2Mul(a0, a1, b0, b1 *big.Int) (*big.Int, *big.Int) {
+	c0 := new(big.Int).Sub(new(big.Int).Mul(a0, b0), new(big.Int).Mul(a1, b1))
+	c1 := new(big.Int).Add(new(big.Int).Mul(a0, b1), new(big.Int).Mul(a1, b0))
+	return c0.Mod(c0, blsFieldModulus), c1.Mod(c1, blsFieldModulus)
+}
+
+// blsFp2Sqrt returns the square root of a0 + a1*i, if one exists
+func blsFp2Sqrt(a0, a1 *big.Int) (*big.Int, *big.Int, bool) {
+	if a1.Sign() == 0 {
+		if s, ok := blsFpSqrt(a0); ok {
+			return s, new(big.Int), true
+		}
+		if s, ok := blsFpSqrt(new(big.Int).Sub(blsFieldModulus, a0)); ok {
+			return new(big.Int), s, true
+		}
+		return nil, nil, false
+	}
+
+	norm := new(big.Int).Add(new(big.Int).Mul(a0, a0), new(big.Int).Mul(a1, a1))
+	gamma, ok := blsFpSqrt(norm.Mod(norm, blsFieldModulus))
+	if !ok {
+		return nil, nil, false
+	}
+
+	inv2 := new(big.Int).ModInverse(big.NewInt(2), blsFieldModulus)
+	delta := new(big.Int).Add(a0, gamma)
+	delta.Mul(delta, inv2).Mod(delta, blsFieldModulus)
+	x0, ok := blsFpSqrt(delta)
+	if !ok || x0.Sign() == 0 {
+		delta.Sub(a0, gamma).Mul(delta, inv2).Mod(delta, blsFieldModulus)
+		x0, ok = blsFpSqrt(delta)
+		if !ok || x0.Sign() == 0 {
+			return nil, nil, false
+		}
+	}
+
+	x1 := new(big.Int).ModInverse(new(big.Int).Lsh(x0, 1), blsFieldModulus)
+	x1.Mul(x1, a1).Mod(x1, blsFieldModulus)
+
+	c0, c1 := blsFp2Mul(x0, x1, x0, x1)
+	if c0.Cmp(new(big.Int).Mod(a0, blsFieldModulus)) != 0 || c1.Cmp(new(big.Int).Mod(a1, blsFieldModulus)) != 0 {
+		return nil, nil, false
+	}
+	return x0, x1, true
+}
+
+// blsFp2Lexicographic returns true if y0 + y1*i is lexicographically larger than its negation
+func blsFp2Lexicographic(y0, y1 *big.Int) bool {
+	if y1.Sign() != 0 {
+		return y1.Cmp(blsFieldHalf) > 0
+	}
+	return y0.Cmp(blsFieldHalf) > 0
+}
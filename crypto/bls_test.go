@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto/bls12381"
+)
+
+func TestBLSCompressGenerators(t *testing.T) {
+	g1 := bls12381.NewG1()
+	g2 := bls12381.NewG2()
+
+	g1Compressed := blsCompressG1(g1, g1.One())
+	if hex.EncodeToString(g1Compressed) != "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" {
+		t.Errorf("unexpected compressed G1 generator: %x", g1Compressed)
+	}
+
+	g2Compressed := blsCompressG2(g2, g2.One())
+	if hex.EncodeToString(g2Compressed) != "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8" {
+		t.Errorf("unexpected compressed G2 generator: %x", g2Compressed)
+	}
+
+	p1, err := blsDecompressG1(g1, g1Compressed)
+	if err != nil || !g1.Equal(p1, g1.One()) {
+		t.Errorf("failed to round-trip compressed G1 generator; %v", err)
+	}
+
+	p2, err := blsDecompressG2(g2, g2Compressed)
+	if err != nil || !g2.Equal(p2, g2.One()) {
+		t.Errorf("failed to round-trip compressed G2 generator; %v", err)
+	}
+}
+
+func TestBLSSignKnownVector(t *testing.T) {
+	secretKey, _ := hex.DecodeString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")
+	sig, err := BLSSign(secretKey, make([]byte, 32))
+	if err != nil {
+		t.Fatalf("failed to sign message; %s", err.Error())
+	}
+
+	expected := "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55"
+	if hex.EncodeToString(sig) != expected {
+		t.Errorf("unexpected signature: %x", sig)
+	}
+}
+
+func TestBLSAggregateVerify(t *testing.T) {
+	msg := []byte("attestation")
+	publicKeys := make([][]byte, 0)
+	sigs := make([][]byte, 0)
+	msgs := make([][]byte, 0)
+	distinctSigs := make([][]byte, 0)
+
+	for i := 0; i < 3; i++ {
+		secretKey, publicKey, err := BLSGenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key; %s", err.Error())
+		}
+		publicKeys = append(publicKeys, publicKey)
+
+		sig, _ := BLSSign(secretKey, msg)
+		sigs = append(sigs, sig)
+
+		distinct := []byte{byte(i)}
+		msgs = append(msgs, distinct)
+		distinctSig, _ := BLSSign(secretKey, distinct)
+		distinctSigs = append(distinctSigs, distinctSig)
+	}
+
+	verified, err := BLSVerify(publicKeys[0], msg, sigs[0])
+	if err != nil || !verified {
+		t.Errorf("failed to verify signature; %v", err)
+	}
+
+	agg, err := BLSAggregateSignatures(sigs)
+	if err != nil {
+		t.Fatalf("failed to aggregate signatures; %s", err.Error())
+	}
+
+	verified, err = BLSFastAggregateVerify(publicKeys, msg, agg)
+	if err != nil || !verified {
+		t.Errorf("failed to verify aggregate signature; %v", err)
+	}
+
+	verified, _ = BLSFastAggregateVerify(publicKeys[0:2], msg, agg)
+	if verified {
+		t.Error("expected aggregate signature verification to fail for a subset of signers")
+	}
+
+	distinctAgg, _ := BLSAggregateSignatures(distinctSigs)
+	verified, err = BLSAggregateVerify(publicKeys, msgs, distinctAgg)
+	if err != nil || !verified {
+		t.Errorf("failed to verify aggregate signature of distinct messages; %v", err)
+	}
+
+	if bytes.Equal(agg, distinctAgg) {
+		t.Error("expected distinct aggregate signatures")
+	}
+}
@@ -0,0 +1,272 @@
+package compile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	api "github.com/provideplatform/provide-go/api/nchain"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+const defaultSolcBinary = "solc"
+
+// solcVersionPattern matches the version reported by `solc --version`, i.e. 0.8.19+commit.7dd6d404
+var solcVersionPattern = regexp.MustCompile(`Version: (\d+\.\d+\.\d+(\+commit\.[0-9a-f]+)?)`)
+
+// defaultOutputSelection is the standard-json output required to produce compiled artifacts
+var defaultOutputSelection = map[string]map[string][]string{
+	"*": {
+		"*": {
+			"abi",
+			"evm.bytecode.object",
+			"evm.bytecode.opcodes",
+			"evm.deployedBytecode.object",
+			"metadata",
+		},
+	},
+}
+
+// Solc wraps a solc binary
+type Solc struct {
+	Path    string
+	Version string // i.e. 0.8.19+commit.7dd6d404
+}
+
+// Error is a standard-json compiler error or warning
+type Error struct {
+	Component        string `json:"component"`
+	Severity         string `json:"severity"`
+	Type             string `json:"type"`
+	Message          string `json:"message"`
+	FormattedMessage string `json:"formattedMessage"`
+}
+
+// Output is the parsed standard-json output of solc
+type Output struct {
+	Errors    []*Error                              `json:"errors,omitempty"`
+	Contracts map[string]map[string]json.RawMessage `json:"contracts"`
+}
+
+// NewSolc initializes a solc wrapper for the binary at the given path, resolving its version
+func NewSolc(path string) (*Solc, error) {
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve solc version of %s; %s", path, err.Error())
+	}
+	version, err := parseSolcVersion(string(out))
+	if err != nil {
+		return nil, err
+	}
+	return &Solc{
+		Path:    path,
+		Version: version,
+	}, nil
+}
+
+// FindSolc resolves a solc binary matching the given version (i.e. 0.8.19); the SOLC_PATH environment
+// variable, a versioned solc-<version> binary on the PATH, the solc-select artifacts directory and the
+// default solc binary are searched, in that order; an empty version matches any solc binary
+func FindSolc(version string) (*Solc, error) {
+	candidates := make([]string, 0)
+	if path := os.Getenv("SOLC_PATH"); path != "" {
+		candidates = append(candidates, path)
+	}
+	if version != "" {
+		versioned := fmt.Sprintf("solc-%s", strings.TrimPrefix(version, "v"))
+		candidates = append(candidates, versioned)
+		if home, err := os.UserHomeDir(); err == nil {
+			candidates = append(candidates, filepath.Join(home, ".solc-select", "artifacts", versioned, versioned))
+		}
+	}
+	candidates = append(candidates, defaultSolcBinary)
+
+	for _, candidate := range candidates {
+		path, err := exec.LookPath(candidate)
+		if err != nil {
+			continue
+		}
+		solc, err := NewSolc(path)
+		if err != nil {
+			prvdcommon.Log.Debugf("failed to resolve solc candidate %s; %s", path, err.Error())
+			continue
+		}
+		if solc.Matches(version) {
+			return solc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find solc binary matching version %s", version)
+}
+
+// Matches returns true if the solc version matches the given version, which may omit the commit
+func (s *Solc) Matches(version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	return version == "" || s.Version == version || strings.HasPrefix(s.Version, fmt.Sprintf("%s+", version))
+}
+
+// LongVersion returns the version in the form expected by verification APIs, i.e. v0.8.19+commit.7dd6d404
+func (s *Solc) LongVersion() string {
+	return fmt.Sprintf("v%s", s.Version)
+}
+
+// Compile compiles the given standard-json input; the output selection required to produce compiled
+// artifacts is used when none is given, and an error is returned if compilation reports any errors
+func (s *Solc) Compile(ctx context.Context, input *crypto.EVMStandardJSONInput) (*Output, error) {
+	if input.Settings == nil {
+		input.Settings = &crypto.EVMCompilerSettings{}
+	}
+	if input.Settings.OutputSelection == nil {
+		input.Settings.OutputSelection = defaultOutputSelection
+	}
+
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal standard-json input; %s", err.Error())
+	}
+
+	prvdcommon.Log.Debugf("compiling %d source(s) using solc %s", len(input.Sources), s.Version)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.Path, "--standard-json")
+	cmd.Stdin = bytes.NewReader(raw)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke solc %s; %s; %s", s.Version, err.Error(), stderr.String())
+	}
+
+	return parseOutput(stdout.Bytes())
+}
+
+// CompileSources compiles the given sources, keyed by path, using the given compiler settings
+func (s *Solc) CompileSources(ctx context.Context, sources map[string]string, settings *crypto.EVMCompilerSettings) (*Output, error) {
+	return s.Compile(ctx, crypto.NewEVMStandardJSONInput(sources, settings))
+}
+
+// Warnings returns the compiler warnings
+func (o *Output) Warnings() []*Error {
+	warnings := make([]*Error, 0)
+	for _, e := range o.Errors {
+		if e.Severity != "error" {
+			warnings = append(warnings, e)
+		}
+	}
+	return warnings
+}
+
+// Artifacts returns compiled artifacts for each of the compiled contracts, sorted by source path
+// and contract name; artifact names are fully-qualified, i.e. contracts/Token.sol:Token
+func (o *Output) Artifacts() ([]*api.CompiledArtifact, error) {
+	paths := make([]string, 0, len(o.Contracts))
+	for path := range o.Contracts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	artifacts := make([]*api.CompiledArtifact, 0)
+	for _, path := range paths {
+		names := make([]string, 0, len(o.Contracts[path]))
+		for name := range o.Contracts[path] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			artifact, err := newCompiledArtifact(path, name, o.Contracts[path][name])
+			if err != nil {
+				return nil, err
+			}
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts, nil
+}
+
+// Artifact returns the compiled artifact for the given contract name, which may be fully-qualified
+func (o *Output) Artifact(name string) (*api.CompiledArtifact, error) {
+	artifacts, err := o.Artifacts()
+	if err != nil {
+		return nil, err
+	}
+
+	var match *api.CompiledArtifact
+	for _, artifact := range artifacts {
+		if artifact.Name == name || strings.HasSuffix(artifact.Name, fmt.Sprintf(":%s", name)) {
+			if match != nil {
+				return nil, fmt.Errorf("failed to resolve artifact %s; name is ambiguous", name)
+			}
+			match = artifact
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("failed to resolve artifact %s", name)
+	}
+	return match, nil
+}
+
+func newCompiledArtifact(path, name string, raw json.RawMessage) (*api.CompiledArtifact, error) {
+	var contract struct {
+		ABI []interface{} `json:"abi"`
+		EVM struct {
+			Bytecode struct {
+				Object  string `json:"object"`
+				Opcodes string `json:"opcodes"`
+			} `json:"bytecode"`
+		} `json:"evm"`
+	}
+	err := json.Unmarshal(raw, &contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal compiled contract %s:%s; %s", path, name, err.Error())
+	}
+
+	source := path
+	artifact := &api.CompiledArtifact{
+		Name:    fmt.Sprintf("%s:%s", path, name),
+		ABI:     contract.ABI,
+		Opcodes: contract.EVM.Bytecode.Opcodes,
+		Raw:     raw,
+		Source:  &source,
+	}
+	if contract.EVM.Bytecode.Object != "" {
+		artifact.Bytecode = fmt.Sprintf("0x%s", strings.TrimPrefix(contract.EVM.Bytecode.Object, "0x"))
+	}
+	return artifact, nil
+}
+
+func parseOutput(raw []byte) (*Output, error) {
+	var output *Output
+	err := json.Unmarshal(raw, &output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal solc output; %s", err.Error())
+	}
+
+	errs := make([]string, 0)
+	for _, e := range output.Errors {
+		if e.Severity == "error" {
+			errs = append(errs, strings.TrimSpace(e.FormattedMessage))
+		}
+	}
+	if len(errs) > 0 {
+		return output, fmt.Errorf("failed to compile; %s", strings.Join(errs, "; "))
+	}
+
+	return output, nil
+}
+
+func parseSolcVersion(out string) (string, error) {
+	match := solcVersionPattern.FindStringSubmatch(out)
+	if match == nil {
+		return "", fmt.Errorf("failed to parse solc version from %s", strings.TrimSpace(out))
+	}
+	return match[1], nil
+}
@@ -0,0 +1,73 @@
+package compile
+
+import (
+	"testing"
+)
+
+const testSolcOutput = `{
+  "errors": [
+    {"severity": "warning", "type": "Warning", "formattedMessage": "Warning: SPDX license identifier not provided"}
+  ],
+  "contracts": {
+    "contracts/Token.sol": {
+      "Token": {
+        "abi": [{"type": "constructor", "inputs": [{"name": "supply", "type": "uint256"}]}],
+        "evm": {"bytecode": {"object": "6080604052", "opcodes": "PUSH1 0x80 PUSH1 0x40 MSTORE"}}
+      }
+    },
+    "contracts/Lib.sol": {
+      "Lib": {
+        "abi": [],
+        "evm": {"bytecode": {"object": "6001"}}
+      }
+    }
+  }
+}`
+
+func TestParseSolcVersion(t *testing.T) {
+	version, err := parseSolcVersion("solc, the solidity compiler commandline interface\nVersion: 0.8.19+commit.7dd6d404.Linux.g++\n")
+	if err != nil {
+		t.Fatalf("failed to parse solc version; %s", err.Error())
+	}
+	if version != "0.8.19+commit.7dd6d404" {
+		t.Errorf("unexpected solc version %s", version)
+	}
+
+	solc := &Solc{Version: version}
+	if !solc.Matches("0.8.19") || !solc.Matches("v0.8.19+commit.7dd6d404") || solc.Matches("0.8.1") {
+		t.Error("unexpected solc version match")
+	}
+}
+
+func TestOutputArtifacts(t *testing.T) {
+	output, err := parseOutput([]byte(testSolcOutput))
+	if err != nil {
+		t.Fatalf("failed to parse solc output; %s", err.Error())
+	}
+	if len(output.Warnings()) != 1 {
+		t.Errorf("expected 1 warning; got %d", len(output.Warnings()))
+	}
+
+	artifacts, err := output.Artifacts()
+	if err != nil {
+		t.Fatalf("failed to resolve artifacts; %s", err.Error())
+	}
+	if len(artifacts) != 2 || artifacts[0].Name != "contracts/Lib.sol:Lib" {
+		t.Fatalf("unexpected artifacts %v", artifacts)
+	}
+
+	token, err := output.Artifact("Token")
+	if err != nil {
+		t.Fatalf("failed to resolve Token artifact; %s", err.Error())
+	}
+	if token.Bytecode != "0x6080604052" || len(token.ABI) != 1 {
+		t.Errorf("unexpected Token artifact %v", token)
+	}
+}
+
+func TestParseOutputErrors(t *testing.T) {
+	_, err := parseOutput([]byte(`{"errors": [{"severity": "error", "formattedMessage": "ParserError: Expected ';'"}]}`))
+	if err == nil {
+		t.Error("expected compilation error")
+	}
+}
@@ -12,14 +12,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -31,8 +28,6 @@ import (
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	ethrpc "github.com/ethereum/go-ethereum/rpc"
-	uuid "github.com/kthomas/go.uuid"
-	"golang.org/x/crypto/scrypt"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
@@ -48,12 +43,6 @@ import (
 
 const kovanChainID = uint64(42)
 
-var chainConfigs = map[string]*params.ChainConfig{}        // mapping of rpc client keys to *params.ChainConfig
-var ethclientRpcClients = map[string][]*ethclient.Client{} // mapping of rpc client keys to *ethclient.Client instances
-var ethrpcClients = map[string][]*ethrpc.Client{}          // mapping of rpc client keys to *ethrpc.Client instances
-
-var evmMutex = &sync.Mutex{}
-
 // default timeouts
 const defaultRpcTimeout = time.Second * 60
 const defaultEvmSyncTimeout = time.Second * 5
@@ -110,42 +99,21 @@ func evmSyncTimeout() time.Duration {
 	return *customEvmSyncTimeout
 }
 
-func evmClearCachedClients(rpcClientKey string) {
-	evmMutex.Lock()
-	delete(chainConfigs, rpcClientKey)
-	for i := range ethrpcClients[rpcClientKey] {
-		ethrpcClients[rpcClientKey][i].Close()
-	}
-	for i := range ethclientRpcClients[rpcClientKey] {
-		ethclientRpcClients[rpcClientKey][i].Close()
-	}
-	ethrpcClients[rpcClientKey] = make([]*ethrpc.Client, 0)
-	ethclientRpcClients[rpcClientKey] = make([]*ethclient.Client, 0)
-	evmMutex.Unlock()
-}
-
 // EVMDialJsonRpc - dials and caches a new JSON-RPC client instance at the JSON-RPC url and caches it using the given network id
 func EVMDialJsonRpc(rpcClientKey, rpcURL string) (*ethclient.Client, error) {
-	var client *ethclient.Client
-
-	if networkClients, _ := ethclientRpcClients[rpcClientKey]; len(networkClients) == 0 {
+	client := evmCachedEthClient(rpcClientKey)
+	if client == nil {
 		rpcClient, err := EVMResolveJsonRpcClient(rpcClientKey, rpcURL)
 		if err != nil {
 			prvdcommon.Log.Warningf("Failed to dial JSON-RPC host: %s", rpcURL)
 			return nil, err
 		}
-		client = ethclient.NewClient(rpcClient)
-		evmMutex.Lock()
-		ethrpcClients[rpcClientKey] = append(ethrpcClients[rpcClientKey], rpcClient)
-		ethclientRpcClients[rpcClientKey] = append(networkClients, client)
-		evmMutex.Unlock()
-	} else {
-		client = ethclientRpcClients[rpcClientKey][0]
+		_, client = EVMRegisterJsonRpcClient(rpcClientKey, rpcClient)
 	}
 
 	_, err := EVMGetSyncProgress(client)
 	if err != nil {
-		evmClearCachedClients(rpcClientKey)
+		EVMEvictClients(rpcClientKey)
 		return nil, err
 	}
 
@@ -154,80 +122,127 @@ func EVMDialJsonRpc(rpcClientKey, rpcURL string) (*ethclient.Client, error) {
 
 // EVMInvokeJsonRpcClient - invokes the JSON-RPC client for the given network and url
 func EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, method string, params []interface{}, response interface{}) error {
-	client := &http.Client{
-		Transport: &http.Transport{
-			DisableKeepAlives: true,
-		},
-		Timeout: rpcTimeout(),
-	}
-	id, err := uuid.NewV4()
+	body, err := invokeJsonRpc(rpcClientKey, rpcURL, method, nextRPCID(), params, &RPCOptions{})
 	if err != nil {
-		prvdcommon.Log.Warningf("Failed to generate UUID for JSON-RPC request; %s", err.Error())
 		return err
 	}
-	payload := map[string]interface{}{
-		"method":  method,
-		"params":  params,
-		"id":      id.String(),
-		"jsonrpc": "2.0",
-	}
-	body, err := json.Marshal(payload)
+	err = json.Unmarshal(body, response)
 	if err != nil {
-		prvdcommon.Log.Warningf("Failed to marshal JSON payload for %s JSON-RPC invocation; %s", method, err.Error())
-		return err
+		return fmt.Errorf("Failed to unmarshal %s JSON-RPC response: %s; %s", method, body, err.Error())
 	}
-	resp, err := client.Post(rpcURL, "application/json", bytes.NewReader(body))
+	return nil
+}
+
+// EVMDecodeMode controls how a JSON-RPC result is decoded into a typed target
+type EVMDecodeMode int
+
+const (
+	// EVMDecodeLenient leaves the target untouched when the result is null
+	EVMDecodeLenient EVMDecodeMode = iota
+
+	// EVMDecodeStrict returns ErrEVMNullResult when the result is null
+	EVMDecodeStrict
+)
+
+// ErrEVMNullResult is returned when a JSON-RPC result is null and strict decoding was requested
+var ErrEVMNullResult = errors.New("JSON-RPC result was null")
+
+// EVMJsonRpcError is returned when a node responds with an error object
+type EVMJsonRpcError struct {
+	*api.EthereumJsonRpcResponseError
+	Method string
+}
+
+// Error implements the error interface
+func (e *EVMJsonRpcError) Error() string {
+	return fmt.Sprintf("%s JSON-RPC method failed; code: %d; %s", e.Method, e.Code, e.Message)
+}
+
+// EVMInvokeJsonRpcResult invokes the JSON-RPC method and decodes its result into the given typed
+// target, rather than into an interface{} requiring type assertions; an error object in the response
+// is returned as an *EVMJsonRpcError
+func EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, method string, params []interface{}, result interface{}, mode EVMDecodeMode) error {
+	var resp evmJsonRpcEnvelope
+	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, method, params, &resp)
 	if err != nil {
-		prvdcommon.Log.Warningf("Failed to invoke JSON-RPC method: %s; %s", method, err.Error())
 		return err
 	}
-	defer resp.Body.Close()
-	buf := new(bytes.Buffer)
-	buf.ReadFrom(resp.Body)
-	err = json.Unmarshal(buf.Bytes(), response)
+	return resp.decode(method, result, mode)
+}
+
+type evmJsonRpcEnvelope struct {
+	Result json.RawMessage                   `json:"result"`
+	Error  *api.EthereumJsonRpcResponseError `json:"error,omitempty"`
+}
+
+func (e *evmJsonRpcEnvelope) decode(method string, result interface{}, mode EVMDecodeMode) error {
+	if e.Error != nil {
+		return &EVMJsonRpcError{EthereumJsonRpcResponseError: e.Error, Method: method}
+	}
+	if len(e.Result) == 0 || bytes.Equal(e.Result, []byte("null")) {
+		if mode == EVMDecodeStrict && result != nil {
+			return fmt.Errorf("failed to decode %s JSON-RPC result; %w", method, ErrEVMNullResult)
+		}
+		return nil
+	}
+	if result == nil {
+		return nil
+	}
+	err := json.Unmarshal(e.Result, result)
 	if err != nil {
-		return fmt.Errorf("Failed to unmarshal %s JSON-RPC response: %s; %s", method, buf.Bytes(), err.Error())
+		return fmt.Errorf("failed to unmarshal %s JSON-RPC result; %s", method, err.Error())
 	}
-	prvdcommon.Log.Debugf("Invocation of JSON-RPC method %s succeeded (%v-byte response)", method, buf.Len())
 	return nil
 }
 
+// evmInvokeJsonRpcResult invokes the JSON-RPC method and strictly decodes its result
+func evmInvokeJsonRpcResult(rpcClientKey, rpcURL, method string, params []interface{}, result interface{}) error {
+	return EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, method, params, result, EVMDecodeStrict)
+}
+
+// evmHexUint64Field decodes the named hex quantity from a generic JSON-RPC result object
+func evmHexUint64Field(result interface{}, key string) (uint64, error) {
+	obj, ok := result.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse %s from JSON-RPC response; result was not an object", key)
+	}
+	str, ok := obj[key].(string)
+	if !ok {
+		return 0, fmt.Errorf("Unable to parse %s from JSON-RPC response", key)
+	}
+	val, err := hexutil.DecodeUint64(str)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to decode %s hex; %s", key, err.Error())
+	}
+	return val, nil
+}
+
 // EVMResolveEthClient resolves a cached *ethclient.Client client or dials and caches a new instance
 func EVMResolveEthClient(rpcClientKey, rpcURL string) (*ethclient.Client, error) {
-	var client *ethclient.Client
-	if networkClients, _ := ethclientRpcClients[rpcClientKey]; len(networkClients) == 0 {
-		client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
-		if err != nil {
-			prvdcommon.Log.Warningf("Failed to dial RPC client for JSON-RPC host: %s", rpcURL)
-			return nil, err
-		}
-		evmMutex.Lock()
-		ethclientRpcClients[rpcClientKey] = append(networkClients, client)
-		evmMutex.Unlock()
-	} else {
-		client = ethclientRpcClients[rpcClientKey][0]
+	if client := evmCachedEthClient(rpcClientKey); client != nil {
 		prvdcommon.Log.Debugf("Resolved cached *ethclient.Client instance for JSON-RPC host @ %s", rpcURL)
+		return client, nil
+	}
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		prvdcommon.Log.Warningf("Failed to dial RPC client for JSON-RPC host: %s", rpcURL)
+		return nil, err
 	}
 	return client, nil
 }
 
 // EVMResolveJsonRpcClient resolves a cached *ethclient.Client client or dials and caches a new instance
 func EVMResolveJsonRpcClient(rpcClientKey, rpcURL string) (*ethrpc.Client, error) {
-	var client *ethrpc.Client
-	if networkClients, _ := ethrpcClients[rpcClientKey]; len(networkClients) == 0 {
-		erpc, err := ethrpc.Dial(rpcURL)
-		if err != nil {
-			prvdcommon.Log.Warningf("Failed to dial RPC client for JSON-RPC host: %s", rpcURL)
-			return nil, err
-		}
-		client = erpc
-		evmMutex.Lock()
-		ethrpcClients[rpcClientKey] = append(networkClients, client)
-		evmMutex.Unlock()
-	} else {
-		client = ethrpcClients[rpcClientKey][0]
+	if client := evmCachedRpcClient(rpcClientKey); client != nil {
 		prvdcommon.Log.Debugf("Resolved JSON-RPC host @ %s", rpcURL)
+		return client, nil
 	}
+	erpc, err := dialRPC(rpcClientKey, rpcURL)
+	if err != nil {
+		prvdcommon.Log.Warningf("Failed to dial RPC client for JSON-RPC host: %s", rpcURL)
+		return nil, err
+	}
+	client, _ := EVMRegisterJsonRpcClient(rpcClientKey, erpc)
 	return client, nil
 }
 
@@ -305,83 +320,7 @@ func EVMMarshalKeyPairJSON(addr common.Address, privateKey *ecdsa.PrivateKey) ([
 
 // EVMMarshalEncryptedKey encrypts key as version 3.
 func EVMMarshalEncryptedKey(addr common.Address, privateKey *ecdsa.PrivateKey, secret string) ([]byte, error) {
-	const (
-		// n,r,p = 2^12, 8, 6 uses 4MB memory and approx 100ms CPU time on a modern CPU.
-		LightScryptN = 1 << 12
-		LightScryptP = 6
-
-		scryptR     = 4
-		scryptDKLen = 32
-	)
-
-	type cipherparamsJSON struct {
-		IV string `json:"iv"`
-	}
-
-	type cryptoJSON struct {
-		Cipher       string                 `json:"cipher"`
-		CipherText   string                 `json:"ciphertext"`
-		CipherParams cipherparamsJSON       `json:"cipherparams"`
-		KDF          string                 `json:"kdf"`
-		KDFParams    map[string]interface{} `json:"kdfparams"`
-		MAC          string                 `json:"mac"`
-	}
-
-	type web3v3 struct {
-		ID      string     `json:"id"`
-		Address string     `json:"address"`
-		Crypto  cryptoJSON `json:"crypto"`
-		Version int        `json:"version"`
-	}
-
-	salt := make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
-		prvdcommon.Log.Errorf("Failed while reading from crypto/rand; %s", err.Error())
-		return nil, err
-	}
-
-	derivedKey, err := scrypt.Key([]byte(secret), salt, LightScryptN, scryptR, LightScryptP, scryptDKLen)
-	if err != nil {
-		return nil, err
-	}
-	encryptKey := derivedKey[:16]
-	keyBytes := ethcrypto.FromECDSA(privateKey)
-
-	iv := make([]byte, aes.BlockSize) // 16
-	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
-		prvdcommon.Log.Errorf("Failed while reading from crypto/rand; %s", err.Error())
-		return nil, err
-	}
-
-	cipherText, err := aesCTRXOR(encryptKey, keyBytes, iv)
-	if err != nil {
-		return nil, err
-	}
-	mac := ethcrypto.Keccak256(derivedKey[16:32], cipherText)
-
-	keyUUID, _ := generateEVMKeyUUID()
-
-	return json.Marshal(web3v3{
-		ID:      keyUUID,
-		Address: hex.EncodeToString(addr[:]),
-		Crypto: cryptoJSON{
-			Cipher:     "aes-128-ctr",
-			CipherText: hex.EncodeToString(cipherText),
-			CipherParams: cipherparamsJSON{
-				IV: hex.EncodeToString(iv),
-			},
-			KDF: "scrypt",
-			KDFParams: map[string]interface{}{
-				"n":     LightScryptN,
-				"r":     scryptR,
-				"p":     LightScryptP,
-				"dklen": scryptDKLen,
-				"salt":  hex.EncodeToString(salt),
-			},
-			MAC: hex.EncodeToString(mac),
-		},
-		Version: 3,
-	})
+	return evmEncryptKeystore(addr, privateKey, secret, evmLegacyKeystoreScryptParams)
 }
 
 func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
@@ -971,8 +910,12 @@ func parseContractABI(contractAbi interface{}) (*abi.ABI, error) {
 	return &abival, nil
 }
 
-// EVMEthCall invokes eth_call manually via JSON-RPC
-func EVMEthCall(rpcClientKey, rpcURL string, params []interface{}) (*api.EthereumJsonRpcResponse, error) {
+// EVMEthCall invokes eth_call manually via JSON-RPC; the optional opts may specify the block
+// and state overrides to apply to the call
+func EVMEthCall(rpcClientKey, rpcURL string, params []interface{}, opts ...*EVMCallOpts) (*api.EthereumJsonRpcResponse, error) {
+	if len(opts) > 0 {
+		params = evmCallParams(params, opts[0])
+	}
 	var jsonRPCResponse = &api.EthereumJsonRpcResponse{}
 	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_call", params, &jsonRPCResponse)
 	return jsonRPCResponse, err
@@ -981,32 +924,29 @@ func EVMEthCall(rpcClientKey, rpcURL string, params []interface{}) (*api.Ethereu
 // EVMGetBlockNumber retrieves the latest block known to the JSON-RPC client
 func EVMGetBlockNumber(rpcClientKey, rpcURL string) *uint64 {
 	params := make([]interface{}, 0)
-	var resp = &api.EthereumJsonRpcResponse{}
+	var blockNumber hexutil.Uint64
 	prvdcommon.Log.Debugf("attempting to fetch latest block number via JSON-RPC eth_blockNumber method")
-	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_blockNumber", params, &resp)
+	err := EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "eth_blockNumber", params, &blockNumber, EVMDecodeStrict)
 	if err != nil {
 		prvdcommon.Log.Warningf("failed to invoke eth_blockNumber method via JSON-RPC; %s", err.Error())
 		return nil
 	}
-	blockNumber, err := hexutil.DecodeBig(resp.Result.(string))
-	if err != nil {
-		return nil
-	}
-	_blockNumber := blockNumber.Uint64()
+	_blockNumber := uint64(blockNumber)
 	return &_blockNumber
 }
 
 // EVMGetChainConfig parses the cached network config mapped to the given
 // `rpcClientKey`, if one exists; otherwise, the mainnet chain config is returned.
 func EVMGetChainConfig(rpcClientKey, rpcURL string) (*params.ChainConfig, error) {
-	if cfg, ok := chainConfigs[rpcClientKey]; ok {
+	if cfg := evmCachedChainConfig(rpcClientKey); cfg != nil {
 		return cfg, nil
 	}
-	cfg := params.MainnetChainConfig
+	_cfg := *params.MainnetChainConfig // copy to avoid mutating the shared mainnet config
+	cfg := &_cfg
 	chainID, err := strconv.ParseUint(rpcClientKey, 10, 64)
 	if err == nil {
 		cfg.ChainID = big.NewInt(int64(chainID))
-		chainConfigs[rpcClientKey] = cfg
+		EVMRegisterChainConfig(rpcClientKey, cfg)
 	} else {
 		cfg.ChainID, err = EVMGetChainID(rpcClientKey, rpcURL)
 		if err != nil {
@@ -1021,19 +961,19 @@ func EVMGetChainID(rpcClientKey, rpcURL string) (*big.Int, error) {
 	ethClient, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
 	if err != nil {
 		errmsg := fmt.Sprintf("Failed to obtain network id for *ethclient.Client instance with RPC URL: %s; %s", rpcURL, err.Error())
-		prvdcommon.Log.Warningf(errmsg)
-		return nil, fmt.Errorf(errmsg)
+		prvdcommon.Log.Warning(errmsg)
+		return nil, errors.New(errmsg)
 	}
 	if ethClient == nil {
 		errmsg := fmt.Sprintf("failed to read network id for unresolved *ethclient.Client instance; network id: %s; JSON-RPC URL: %s", rpcClientKey, rpcURL)
-		prvdcommon.Log.Warningf(errmsg)
-		return nil, fmt.Errorf(errmsg)
+		prvdcommon.Log.Warning(errmsg)
+		return nil, errors.New(errmsg)
 	}
 	chainID, err := ethClient.NetworkID(context.TODO())
 	if err != nil {
 		errmsg := fmt.Sprintf("failed to read chain id for *ethclient.Client instance with RPC URL: %s; %s", rpcURL, err.Error())
-		prvdcommon.Log.Warningf(errmsg)
-		return nil, fmt.Errorf(errmsg)
+		prvdcommon.Log.Warning(errmsg)
+		return nil, errors.New(errmsg)
 	}
 	if chainID != nil {
 		prvdcommon.Log.Debugf("received chain id from *ethclient.Client instance with RPC URL: %s; %s", rpcURL, chainID)
@@ -1044,14 +984,14 @@ func EVMGetChainID(rpcClientKey, rpcURL string) (*big.Int, error) {
 // EVMGetGasPrice returns the gas price
 func EVMGetGasPrice(rpcClientKey, rpcURL string) *string {
 	params := make([]interface{}, 0)
-	var resp = &api.EthereumJsonRpcResponse{}
+	var gasPrice *string
 	prvdcommon.Log.Debugf("Attempting to fetch gas price via JSON-RPC eth_gasPrice method")
-	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_gasPrice", params, &resp)
+	err := EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "eth_gasPrice", params, &gasPrice, EVMDecodeLenient)
 	if err != nil {
 		prvdcommon.Log.Warningf("Failed to invoke eth_gasPrice method via JSON-RPC; %s", err.Error())
 		return nil
 	}
-	return prvdcommon.StringOrNil(resp.Result.(string))
+	return gasPrice
 }
 
 // EVMGetLatestBlock retrieves the latsest block
@@ -1067,15 +1007,7 @@ func EVMGetLatestBlockNumber(rpcClientKey, rpcURL string) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	blockNumberStr, blockNumberStrOk := resp.Result.(map[string]interface{})["number"].(string)
-	if !blockNumberStrOk {
-		return 0, errors.New("Unable to parse block number from JSON-RPC response")
-	}
-	blockNumber, err := hexutil.DecodeUint64(blockNumberStr)
-	if err != nil {
-		return 0, fmt.Errorf("Unable to decode block number hex; %s", err.Error())
-	}
-	return blockNumber, nil
+	return evmHexUint64Field(resp.Result, "number")
 }
 
 // EVMGetBlockGasLimit retrieves the latest block gas limit
@@ -1084,15 +1016,7 @@ func EVMGetBlockGasLimit(rpcClientKey, rpcURL string) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	blockGasLimitStr, blockGasLimitStrOk := resp.Result.(map[string]interface{})["gasLimit"].(string)
-	if !blockGasLimitStrOk {
-		return 0, errors.New("Unable to parse block gas limit from JSON-RPC response")
-	}
-	blockGasLimit, err := hexutil.DecodeUint64(blockGasLimitStr)
-	if err != nil {
-		return 0, fmt.Errorf("Unable to decode block gas limit hex; %s", err.Error())
-	}
-	return blockGasLimit, nil
+	return evmHexUint64Field(resp.Result, "gasLimit")
 }
 
 // EVMGetBlockByNumber retrieves a given block by number
@@ -1118,6 +1042,25 @@ func EVMGetNativeBalance(rpcClientKey, rpcURL, addr string) (*big.Int, error) {
 	return client.BalanceAt(context.TODO(), common.HexToAddress(addr), nil)
 }
 
+// evmNetwork resolves network status using direct JSON-RPC
+type evmNetwork struct {
+	rpcClientKey string
+	rpcURL       string
+}
+
+// NewEVMNetwork returns an api.NetworkStatusProvider which resolves status using direct JSON-RPC
+func NewEVMNetwork(rpcClientKey, rpcURL string) api.NetworkStatusProvider {
+	return &evmNetwork{
+		rpcClientKey: rpcClientKey,
+		rpcURL:       rpcURL,
+	}
+}
+
+// Status implements api.NetworkStatusProvider
+func (n *evmNetwork) Status() (*api.NetworkStatus, error) {
+	return EVMGetNetworkStatus(n.rpcClientKey, n.rpcURL)
+}
+
 // EVMGetNetworkStatus retrieves current metadata from the JSON-RPC client;
 // returned struct includes block height, chainID, number of connected peers,
 // protocol version, and syncing state.
@@ -1144,14 +1087,14 @@ func EVMGetNetworkStatus(rpcClientKey, rpcURL string) (*api.NetworkStatus, error
 	defer func() {
 		if r := recover(); r != nil {
 			prvdcommon.Log.Debugf("Recovered from failed attempt to retrieve network sync progress from JSON-RPC host: %s", rpcURL)
-			evmClearCachedClients(rpcClientKey)
+			EVMEvictClients(rpcClientKey)
 		}
 	}()
 
 	syncProgress, err := EVMGetSyncProgress(ethClient)
 	if err != nil {
 		prvdcommon.Log.Warningf("Failed to read network sync progress using JSON-RPC host; %s", err.Error())
-		evmClearCachedClients(rpcClientKey)
+		EVMEvictClients(rpcClientKey)
 		return nil, err
 	}
 	var state string
@@ -1173,19 +1116,22 @@ func EVMGetNetworkStatus(rpcClientKey, rpcURL string) (*api.NetworkStatus, error
 			prvdcommon.Log.Warningf("Failed to read latest block for %s using JSON-RPC host; %s", rpcURL, err.Error())
 			return nil, err
 		}
-		hdr := resp.Result.(map[string]interface{})
+		hdr, ok := resp.Result.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unable to read latest block for %s; JSON-RPC result was not an object", rpcURL)
+		}
 		delete(hdr, "transactions") // HACK
 		delete(hdr, "uncles")       // HACK
 
 		meta["last_block_header"] = hdr
-		block, err = hexutil.DecodeUint64(hdr["number"].(string))
+		block, err = evmHexUint64Field(hdr, "number")
 		if err != nil {
-			return nil, fmt.Errorf("Unable to decode block number hex; %s", err.Error())
+			return nil, err
 		}
 
-		_lastBlockAt, err := hexutil.DecodeUint64(hdr["timestamp"].(string))
+		_lastBlockAt, err := evmHexUint64Field(hdr, "timestamp")
 		if err != nil {
-			return nil, fmt.Errorf("Unable to decode block timestamp hex; %s", err.Error())
+			return nil, err
 		}
 		lastBlockAt = &_lastBlockAt
 	} else {
@@ -1231,17 +1177,18 @@ func EVMGetPeerCount(rpcClientKey, rpcURL string) uint64 {
 // EVMGetProtocolVersion returns the JSON-RPC client protocol version
 func EVMGetProtocolVersion(rpcClientKey, rpcURL string) *string {
 	params := make([]interface{}, 0)
-	var resp = &api.EthereumJsonRpcResponse{}
+	var protocolVersion *string
 	prvdcommon.Log.Debugf("Attempting to fetch protocol version via JSON-RPC eth_protocolVersion method")
-	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_protocolVersion", params, &resp)
+	err := EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "eth_protocolVersion", params, &protocolVersion, EVMDecodeLenient)
 	if err != nil {
 		prvdcommon.Log.Debugf("Attempting to fetch protocol version via JSON-RPC net_version method")
-		err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "net_version", params, &resp)
-
-		prvdcommon.Log.Warningf("Failed to invoke eth_protocolVersion method via JSON-RPC; %s", err.Error())
-		return nil
+		err := EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "net_version", params, &protocolVersion, EVMDecodeLenient)
+		if err != nil {
+			prvdcommon.Log.Warningf("Failed to invoke eth_protocolVersion method via JSON-RPC; %s", err.Error())
+			return nil
+		}
 	}
-	return prvdcommon.StringOrNil(resp.Result.(string))
+	return protocolVersion
 }
 
 // EVMGetCode retrieves the code stored at the named address in the given scope;
@@ -1250,14 +1197,14 @@ func EVMGetCode(rpcClientKey, rpcURL, addr, scope string) (*string, error) {
 	params := make([]interface{}, 0)
 	params = append(params, addr)
 	params = append(params, scope)
-	var resp = &api.EthereumJsonRpcResponse{}
+	var code *string
 	prvdcommon.Log.Debugf("Attempting to fetch code from %s via eth_getCode JSON-RPC method", addr)
-	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_getCode", params, &resp)
+	err := EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "eth_getCode", params, &code, EVMDecodeLenient)
 	if err != nil {
 		prvdcommon.Log.Warningf("Failed to invoke eth_getCode method via JSON-RPC; %s", err.Error())
 		return nil, err
 	}
-	return prvdcommon.StringOrNil(resp.Result.(string)), nil
+	return code, nil
 }
 
 // EVMGetSyncProgress retrieves the status of the current network sync
@@ -1358,6 +1305,15 @@ func EVMTraceTx(rpcClientKey, rpcURL string, hash *string) (interface{}, error)
 	return result, nil
 }
 
+// EVMTraceTxCallTree traces the given tx and reconstructs its call hierarchy; see EVMTraceTx
+func EVMTraceTxCallTree(rpcClientKey, rpcURL string, hash *string) (*api.CallTreeNode, error) {
+	result, err := EVMTraceTx(rpcClientKey, rpcURL, hash)
+	if err != nil {
+		return nil, err
+	}
+	return result.(*api.EthereumTxTraceResponse).CallTree()
+}
+
 // EVMGetTxReceipt retrieves the full transaction receipt via JSON-RPC given the transaction hash
 func EVMGetTxReceipt(rpcClientKey, rpcURL, txHash, from string) (*types.Receipt, error) {
 	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestEVMJsonRpcEnvelopeDecode(t *testing.T) {
+	var env evmJsonRpcEnvelope
+	json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"result":null}`), &env)
+
+	var blockNumber *hexutil.Uint64
+	if err := env.decode("eth_blockNumber", &blockNumber, EVMDecodeLenient); err != nil || blockNumber != nil {
+		t.Errorf("expected lenient decode of null result to leave target nil; %v", err)
+	}
+	if err := env.decode("eth_blockNumber", &blockNumber, EVMDecodeStrict); !errors.Is(err, ErrEVMNullResult) {
+		t.Errorf("expected strict decode of null result to fail; %v", err)
+	}
+
+	env = evmJsonRpcEnvelope{}
+	json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`), &env)
+	err := env.decode("admin_peers", &blockNumber, EVMDecodeLenient)
+	var rpcErr *EVMJsonRpcError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("expected error object to be returned as *EVMJsonRpcError; %v", err)
+	}
+
+	env = evmJsonRpcEnvelope{}
+	json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x2a"}`), &env)
+	if err := env.decode("eth_blockNumber", &blockNumber, EVMDecodeStrict); err != nil || uint64(*blockNumber) != 42 {
+		t.Errorf("unexpected block number decode; %v", err)
+	}
+}
+
+func TestEVMHexUint64Field(t *testing.T) {
+	if _, err := evmHexUint64Field(nil, "number"); err == nil {
+		t.Error("expected error reading field from null result")
+	}
+	val, err := evmHexUint64Field(map[string]interface{}{"number": "0x10"}, "number")
+	if err != nil || val != 16 {
+		t.Errorf("unexpected field value %d; %v", val, err)
+	}
+}
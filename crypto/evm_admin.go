@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// EVMNodeInfo is the admin_nodeInfo result describing the local node
+type EVMNodeInfo struct {
+	ID         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	Enode      string                     `json:"enode"`
+	ENR        string                     `json:"enr,omitempty"`
+	IP         string                     `json:"ip"`
+	ListenAddr string                     `json:"listenAddr"`
+	Ports      *EVMNodePorts              `json:"ports,omitempty"`
+	Protocols  map[string]json.RawMessage `json:"protocols,omitempty"`
+}
+
+// EVMNodePorts are the discovery and listener ports of a node
+type EVMNodePorts struct {
+	Discovery int `json:"discovery"`
+	Listener  int `json:"listener"`
+}
+
+// EVMPeerInfo is a single admin_peers entry describing a connected peer
+type EVMPeerInfo struct {
+	ID        string                     `json:"id"`
+	Name      string                     `json:"name"`
+	Enode     string                     `json:"enode,omitempty"`
+	ENR       string                     `json:"enr,omitempty"`
+	Caps      []string                   `json:"caps"`
+	Network   *EVMPeerNetwork            `json:"network,omitempty"`
+	Protocols map[string]json.RawMessage `json:"protocols,omitempty"`
+}
+
+// EVMPeerNetwork describes the connection to a peer
+type EVMPeerNetwork struct {
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	Inbound       bool   `json:"inbound"`
+	Trusted       bool   `json:"trusted"`
+	Static        bool   `json:"static"`
+}
+
+// HasCapability returns true if the peer advertises the given capability, i.e. `eth/66`
+func (p *EVMPeerInfo) HasCapability(cap string) bool {
+	for _, c := range p.Caps {
+		if c == cap {
+			return true
+		}
+	}
+	return false
+}
+
+// EVMGetNodeInfo returns the enode and protocol details of the node via admin_nodeInfo
+func EVMGetNodeInfo(rpcClientKey, rpcURL string) (*EVMNodeInfo, error) {
+	var info *EVMNodeInfo
+	prvdcommon.Log.Debugf("Attempting to fetch node info via admin_nodeInfo method via JSON-RPC")
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "admin_nodeInfo", []interface{}{}, &info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch node info; %s", err.Error())
+	}
+	return info, nil
+}
+
+// EVMListPeers returns the connected peers and their capabilities via admin_peers
+func EVMListPeers(rpcClientKey, rpcURL string) ([]*EVMPeerInfo, error) {
+	peers := make([]*EVMPeerInfo, 0)
+	prvdcommon.Log.Debugf("Attempting to list peers via admin_peers method via JSON-RPC")
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "admin_peers", []interface{}{}, &peers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list peers; %s", err.Error())
+	}
+	return peers, nil
+}
+
+// EVMAddPeer adds the given enode as a static peer via admin_addPeer
+func EVMAddPeer(rpcClientKey, rpcURL, enode string) error {
+	return evmAdminPeerOp(rpcClientKey, rpcURL, "admin_addPeer", enode)
+}
+
+// EVMRemovePeer disconnects and removes the given static peer via admin_removePeer
+func EVMRemovePeer(rpcClientKey, rpcURL, enode string) error {
+	return evmAdminPeerOp(rpcClientKey, rpcURL, "admin_removePeer", enode)
+}
+
+// EVMAddTrustedPeer adds the given enode as a trusted peer via admin_addTrustedPeer;
+// trusted peers may connect even when the peer limit has been reached
+func EVMAddTrustedPeer(rpcClientKey, rpcURL, enode string) error {
+	return evmAdminPeerOp(rpcClientKey, rpcURL, "admin_addTrustedPeer", enode)
+}
+
+// EVMRemoveTrustedPeer removes the given enode from the trusted peers via admin_removeTrustedPeer
+func EVMRemoveTrustedPeer(rpcClientKey, rpcURL, enode string) error {
+	return evmAdminPeerOp(rpcClientKey, rpcURL, "admin_removeTrustedPeer", enode)
+}
+
+func evmAdminPeerOp(rpcClientKey, rpcURL, method, enode string) error {
+	var ok bool
+	prvdcommon.Log.Debugf("Attempting to invoke %s method via JSON-RPC; enode: %s", method, enode)
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, method, []interface{}{enode}, &ok)
+	if err != nil {
+		return fmt.Errorf("failed to invoke %s for peer %s; %s", method, enode, err.Error())
+	}
+	if !ok {
+		return fmt.Errorf("failed to invoke %s for peer %s; node rejected the request", method, enode)
+	}
+	return nil
+}
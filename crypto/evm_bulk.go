@@ -0,0 +1,120 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// evmJsonRpcLimitExceededCode is the JSON-RPC error code used by most providers for rate limiting
+const evmJsonRpcLimitExceededCode = -32005
+
+// EVMBlockReceipts are the receipts of the transactions in a block, in transaction order
+type EVMBlockReceipts struct {
+	BlockNumber uint64           `json:"block_number"`
+	BlockHash   common.Hash      `json:"block_hash"`
+	Receipts    []*types.Receipt `json:"receipts"`
+}
+
+// EVMBulkGetBalances resolves the native balances of the given addresses at the latest block using a
+// pool of workers; results are in the order of the given addresses, and a *common.BulkFetchError is
+// returned alongside the results when any balance could not be resolved
+func EVMBulkGetBalances(rpcClientKey, rpcURL string, addrs []string, opts *prvdcommon.BulkFetchOptions) ([]*prvdcommon.BulkResult[string, *big.Int], error) {
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	prvdcommon.Log.Debugf("Attempting to resolve balances of %d address(es) via JSON-RPC", len(addrs))
+	return prvdcommon.BulkFetch(context.TODO(), addrs, func(ctx context.Context, addr string) (*big.Int, error) {
+		return client.BalanceAt(ctx, common.HexToAddress(addr), nil)
+	}, evmBulkFetchOptions(opts))
+}
+
+// EVMBulkGetBlockReceipts resolves the receipts of each transaction in the given (inclusive) block range
+// using a pool of workers, each of which resolves the receipts of a single block; results are in block
+// order, and a *common.BulkFetchError is returned alongside the results when any block failed
+func EVMBulkGetBlockReceipts(rpcClientKey, rpcURL string, fromBlock, toBlock uint64, opts *prvdcommon.BulkFetchOptions) ([]*prvdcommon.BulkResult[uint64, *EVMBlockReceipts], error) {
+	if toBlock < fromBlock {
+		return nil, fmt.Errorf("failed to resolve block receipts; invalid block range %d-%d", fromBlock, toBlock)
+	}
+
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]uint64, 0, toBlock-fromBlock+1)
+	for block := fromBlock; block <= toBlock; block++ {
+		blocks = append(blocks, block)
+		if block == toBlock {
+			break // guard against overflow when toBlock is the maximum uint64
+		}
+	}
+
+	prvdcommon.Log.Debugf("Attempting to resolve receipts of blocks %d-%d via JSON-RPC", fromBlock, toBlock)
+	return prvdcommon.BulkFetch(context.TODO(), blocks, func(ctx context.Context, number uint64) (*EVMBlockReceipts, error) {
+		block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve block %d; %s", number, err.Error())
+		}
+
+		result := &EVMBlockReceipts{
+			BlockNumber: number,
+			BlockHash:   block.Hash(),
+			Receipts:    make([]*types.Receipt, 0, len(block.Transactions())),
+		}
+		for _, tx := range block.Transactions() {
+			receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve receipt of tx %s in block %d; %s", tx.Hash().Hex(), number, err.Error())
+			}
+			result.Receipts = append(result.Receipts, receipt)
+		}
+		return result, nil
+	}, evmBulkFetchOptions(opts))
+}
+
+// evmBulkFetchOptions returns the given options, detecting rate limiting by JSON-RPC providers by default
+func evmBulkFetchOptions(opts *prvdcommon.BulkFetchOptions) *prvdcommon.BulkFetchOptions {
+	if opts == nil {
+		opts = &prvdcommon.BulkFetchOptions{}
+	}
+	if opts.RateLimited == nil {
+		_opts := *opts
+		_opts.RateLimited = EVMIsRateLimited
+		return &_opts
+	}
+	return opts
+}
+
+// EVMIsRateLimited returns true if the given error indicates the JSON-RPC provider is rate limiting
+// requests, either by HTTP 429 or a limit exceeded JSON-RPC error
+func EVMIsRateLimited(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var jsonRpcErr *EVMJsonRpcError
+	if errors.As(err, &jsonRpcErr) && jsonRpcErr.EthereumJsonRpcResponseError != nil && jsonRpcErr.Code == evmJsonRpcLimitExceededCode {
+		return true
+	}
+
+	var rpcErr ethrpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == evmJsonRpcLimitExceededCode {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "429") ||
+		strings.Contains(msg, "too many requests") ||
+		strings.Contains(msg, "rate limit") ||
+		strings.Contains(msg, "limit exceeded")
+}
@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	api "github.com/provideplatform/provide-go/api/nchain"
+)
+
+func TestEVMIsRateLimited(t *testing.T) {
+	limited := []error{
+		errors.New("429 Too Many Requests: {}"),
+		fmt.Errorf("failed to retrieve block 1; %s", "daily request count exceeded, request rate limited"),
+		&EVMJsonRpcError{
+			EthereumJsonRpcResponseError: &api.EthereumJsonRpcResponseError{Code: -32005, Message: "query returned more than 10000 results"},
+			Method:                       "eth_getLogs",
+		},
+	}
+	for _, err := range limited {
+		if !EVMIsRateLimited(err) {
+			t.Errorf("expected %s to be rate limited", err.Error())
+		}
+	}
+
+	if EVMIsRateLimited(errors.New("execution reverted")) || EVMIsRateLimited(nil) {
+		t.Error("unexpected rate limited error")
+	}
+}
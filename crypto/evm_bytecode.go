@@ -0,0 +1,171 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// EVMProxyTypeEIP1167 is a minimal proxy (clone) with the implementation embedded in its bytecode
+	EVMProxyTypeEIP1167 = "eip1167"
+
+	// EVMProxyTypeEIP1967 is a transparent or UUPS proxy storing its implementation in the EIP-1967 slot
+	EVMProxyTypeEIP1967 = "eip1967"
+
+	// EVMProxyTypeEIP1967Beacon is a beacon proxy storing its beacon in the EIP-1967 beacon slot
+	EVMProxyTypeEIP1967Beacon = "eip1967_beacon"
+
+	// EVMProxyTypeEIP1822 is a legacy UUPS proxy storing its implementation in the PROXIABLE slot
+	EVMProxyTypeEIP1822 = "eip1822"
+)
+
+var (
+	// evmEIP1967ImplementationSlot is bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1)
+	evmEIP1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// evmEIP1967BeaconSlot is bytes32(uint256(keccak256("eip1967.proxy.beacon")) - 1)
+	evmEIP1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+	// evmEIP1967AdminSlot is bytes32(uint256(keccak256("eip1967.proxy.admin")) - 1)
+	evmEIP1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+	// evmEIP1822ProxiableSlot is keccak256("PROXIABLE")
+	evmEIP1822ProxiableSlot = common.HexToHash("0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+
+	// evmImplementationSelector is the selector of implementation(), exposed by EIP-1967 beacons
+	evmImplementationSelector = common.FromHex("0x5c60da1b")
+
+	evmMinimalProxyPrefix = common.FromHex("0x363d3d373d3d3d363d73")
+	evmMinimalProxySuffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+)
+
+// EVMProxyInfo describes a detected proxy and its resolved addresses
+type EVMProxyInfo struct {
+	Type           string          `json:"type"`
+	Implementation *common.Address `json:"implementation,omitempty"`
+	Beacon         *common.Address `json:"beacon,omitempty"`
+	Admin          *common.Address `json:"admin,omitempty"`
+}
+
+// EVMParseMinimalProxy returns the implementation address embedded in EIP-1167 minimal proxy bytecode
+func EVMParseMinimalProxy(code []byte) (*common.Address, bool) {
+	if len(code) != len(evmMinimalProxyPrefix)+common.AddressLength+len(evmMinimalProxySuffix) {
+		return nil, false
+	}
+	if !bytes.HasPrefix(code, evmMinimalProxyPrefix) || !bytes.HasSuffix(code, evmMinimalProxySuffix) {
+		return nil, false
+	}
+	impl := common.BytesToAddress(code[len(evmMinimalProxyPrefix) : len(evmMinimalProxyPrefix)+common.AddressLength])
+	return &impl, true
+}
+
+// EVMStripMetadata strips the trailing CBOR-encoded compiler metadata from solidity bytecode; the
+// final two bytes of the bytecode encode the length of the metadata
+func EVMStripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	length := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - length
+	if length == 0 || start < 0 {
+		return code
+	}
+	// CBOR maps are prefixed with 0xa0-0xb7 (map with up to 23 entries)
+	if code[start] < 0xa0 || code[start] > 0xb7 {
+		return code
+	}
+	return code[0:start]
+}
+
+// EVMBytecodeEqual compares bytecode ignoring compiler metadata; note that immutable variables are
+// embedded in deployed bytecode and will cause otherwise-identical bytecode to differ
+func EVMBytecodeEqual(a, b []byte) bool {
+	return bytes.Equal(EVMStripMetadata(a), EVMStripMetadata(b))
+}
+
+// EVMCompareDeployedBytecode compares the bytecode deployed at the given address to the deployed
+// (runtime) bytecode of a compiled artifact, ignoring compiler metadata
+func EVMCompareDeployedBytecode(rpcClientKey, rpcURL, addr, deployedBytecode string) (bool, error) {
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return false, err
+	}
+	code, err := client.CodeAt(context.TODO(), common.HexToAddress(addr), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to retrieve code at %s; %s", addr, err.Error())
+	}
+	return EVMBytecodeEqual(code, common.FromHex(deployedBytecode)), nil
+}
+
+// EVMDetectProxy detects EIP-1167, EIP-1967 and EIP-1822 proxies deployed at the given address and
+// resolves their implementation addresses; nil is returned when the contract is not a known proxy
+func EVMDetectProxy(rpcClientKey, rpcURL, addr string) (*EVMProxyInfo, error) {
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	address := common.HexToAddress(addr)
+	code, err := client.CodeAt(context.TODO(), address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve code at %s; %s", addr, err.Error())
+	}
+	if len(code) == 0 {
+		return nil, nil
+	}
+
+	if impl, ok := EVMParseMinimalProxy(code); ok {
+		return &EVMProxyInfo{Type: EVMProxyTypeEIP1167, Implementation: impl}, nil
+	}
+
+	readSlot := func(slot common.Hash) (*common.Address, error) {
+		val, err := client.StorageAt(context.TODO(), address, slot, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read storage slot %s of %s; %s", slot.Hex(), addr, err.Error())
+		}
+		resolved := common.BytesToAddress(val)
+		if resolved == (common.Address{}) {
+			return nil, nil
+		}
+		return &resolved, nil
+	}
+
+	// proxies which are not referenced in the bytecode (i.e. set by an inherited constructor) are still
+	// detected by reading the well-known slots
+	impl, err := readSlot(evmEIP1967ImplementationSlot)
+	if err != nil {
+		return nil, err
+	}
+	if impl != nil {
+		admin, _ := readSlot(evmEIP1967AdminSlot)
+		return &EVMProxyInfo{Type: EVMProxyTypeEIP1967, Implementation: impl, Admin: admin}, nil
+	}
+
+	beacon, err := readSlot(evmEIP1967BeaconSlot)
+	if err != nil {
+		return nil, err
+	}
+	if beacon != nil {
+		info := &EVMProxyInfo{Type: EVMProxyTypeEIP1967Beacon, Beacon: beacon}
+		result, err := client.CallContract(context.TODO(), ethereum.CallMsg{To: beacon, Data: evmImplementationSelector}, nil)
+		if err == nil && len(result) >= 32 {
+			beaconImpl := common.BytesToAddress(result[0:32])
+			info.Implementation = &beaconImpl
+		}
+		return info, nil
+	}
+
+	impl, err = readSlot(evmEIP1822ProxiableSlot)
+	if err != nil {
+		return nil, err
+	}
+	if impl != nil {
+		return &EVMProxyInfo{Type: EVMProxyTypeEIP1822, Implementation: impl}, nil
+	}
+
+	return nil, nil
+}
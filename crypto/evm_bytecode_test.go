@@ -0,0 +1,34 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEVMParseMinimalProxy(t *testing.T) {
+	code := common.FromHex("0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3")
+	impl, ok := EVMParseMinimalProxy(code)
+	if !ok || *impl != common.HexToAddress("0xbebebebebebebebebebebebebebebebebebebebe") {
+		t.Errorf("failed to parse minimal proxy implementation; %v", impl)
+	}
+
+	if _, ok := EVMParseMinimalProxy(code[1:]); ok {
+		t.Error("expected truncated bytecode not to parse as minimal proxy")
+	}
+}
+
+func TestEVMBytecodeEqualIgnoresMetadata(t *testing.T) {
+	runtime := "6080604052348015600f57600080fd5b50"
+
+	// 11-byte CBOR map followed by its 2-byte length
+	a := common.FromHex(runtime + "a2646970667358221220aa" + "000b")
+	b := common.FromHex(runtime + "a2646970667358221220bb" + "000b")
+
+	if !EVMBytecodeEqual(a, b) {
+		t.Error("expected bytecode differing only in metadata to be equal")
+	}
+	if EVMBytecodeEqual(a, common.FromHex("6080")) {
+		t.Error("expected different bytecode not to be equal")
+	}
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// The EVM helpers are called concurrently from many goroutines; all access to the cached chain
+// configs and clients is guarded by evmMutex.
+
+var chainConfigs = map[string]*params.ChainConfig{}      // mapping of rpc client keys to *params.ChainConfig
+var ethclientRpcClients = map[string]*ethclient.Client{} // mapping of rpc client keys to *ethclient.Client instances
+var ethrpcClients = map[string]*ethrpc.Client{}          // mapping of rpc client keys to *ethrpc.Client instances
+
+var evmMutex = &sync.RWMutex{}
+
+// EVMRegisterChainConfig caches the chain config for the given rpc client key
+func EVMRegisterChainConfig(rpcClientKey string, cfg *params.ChainConfig) {
+	evmMutex.Lock()
+	defer evmMutex.Unlock()
+	chainConfigs[rpcClientKey] = cfg
+}
+
+// EVMRegisterJsonRpcClient caches an already-dialed client, i.e. one configured with custom
+// transport or credentials, for the given rpc client key; if another client was registered for
+// the key concurrently, the given client is closed and the registered client is returned
+func EVMRegisterJsonRpcClient(rpcClientKey string, client *ethrpc.Client) (*ethrpc.Client, *ethclient.Client) {
+	evmMutex.Lock()
+	defer evmMutex.Unlock()
+
+	if existing, ok := ethrpcClients[rpcClientKey]; ok {
+		if existing != client {
+			client.Close()
+		}
+		return existing, ethclientRpcClients[rpcClientKey]
+	}
+
+	ethrpcClients[rpcClientKey] = client
+	ethclientRpcClients[rpcClientKey] = ethclient.NewClient(client)
+	return client, ethclientRpcClients[rpcClientKey]
+}
+
+// EVMEvictClients closes and evicts the cached clients and chain config for the given rpc client key
+func EVMEvictClients(rpcClientKey string) {
+	evmMutex.Lock()
+	client := ethrpcClients[rpcClientKey]
+	delete(chainConfigs, rpcClientKey)
+	delete(ethrpcClients, rpcClientKey)
+	delete(ethclientRpcClients, rpcClientKey)
+	evmMutex.Unlock()
+
+	if client != nil {
+		client.Close()
+	}
+}
+
+func evmCachedChainConfig(rpcClientKey string) *params.ChainConfig {
+	evmMutex.RLock()
+	defer evmMutex.RUnlock()
+	return chainConfigs[rpcClientKey]
+}
+
+func evmCachedEthClient(rpcClientKey string) *ethclient.Client {
+	evmMutex.RLock()
+	defer evmMutex.RUnlock()
+	return ethclientRpcClients[rpcClientKey]
+}
+
+func evmCachedRpcClient(rpcClientKey string) *ethrpc.Client {
+	evmMutex.RLock()
+	defer evmMutex.RUnlock()
+	return ethrpcClients[rpcClientKey]
+}
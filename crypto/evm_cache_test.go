@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"sync"
+	"testing"
+
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestEVMClientCacheConcurrentRegisterEvict(t *testing.T) {
+	const rpcClientKey = "evm-cache-test"
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client, ethClient := EVMRegisterJsonRpcClient(rpcClientKey, ethrpc.DialInProc(ethrpc.NewServer()))
+			if client == nil || ethClient == nil {
+				t.Error("expected registered clients")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			evmCachedEthClient(rpcClientKey)
+			EVMEvictClients(rpcClientKey)
+		}()
+	}
+	wg.Wait()
+	EVMEvictClients(rpcClientKey)
+
+	registered := ethrpc.DialInProc(ethrpc.NewServer())
+	client, _ := EVMRegisterJsonRpcClient(rpcClientKey, registered)
+	if client != registered || evmCachedRpcClient(rpcClientKey) != registered {
+		t.Error("expected registered client to be cached")
+	}
+
+	EVMEvictClients(rpcClientKey)
+	if evmCachedRpcClient(rpcClientKey) != nil || evmCachedEthClient(rpcClientKey) != nil {
+		t.Error("expected evicted clients to be removed from the cache")
+	}
+}
@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// EVMCliqueStatus is the clique_status result summarizing recent sealing activity
+type EVMCliqueStatus struct {
+	InturnPercent  float64                   `json:"inturnPercent"`
+	NumBlocks      uint64                    `json:"numBlocks"`
+	SealerActivity map[common.Address]uint64 `json:"sealerActivity"`
+}
+
+// EVMCliqueGetSigners returns the authorized clique signers at the given block; a nil block
+// number resolves the signers at the latest block
+func EVMCliqueGetSigners(rpcClientKey, rpcURL string, blockNumber *uint64) ([]common.Address, error) {
+	return evmGetValidatorSet(rpcClientKey, rpcURL, "clique_getSigners", blockNumber)
+}
+
+// EVMCliqueGetProposals returns the pending clique proposals as a mapping of address to authorization
+func EVMCliqueGetProposals(rpcClientKey, rpcURL string) (map[common.Address]bool, error) {
+	proposals := map[common.Address]bool{}
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "clique_proposals", []interface{}{}, &proposals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch clique proposals; %s", err.Error())
+	}
+	return proposals, nil
+}
+
+// EVMCliquePropose proposes adding (auth = true) or removing (auth = false) the given signer
+func EVMCliquePropose(rpcClientKey, rpcURL, addr string, auth bool) error {
+	return evmConsensusOp(rpcClientKey, rpcURL, "clique_propose", common.HexToAddress(addr), auth)
+}
+
+// EVMCliqueDiscard drops the pending clique proposal for the given signer
+func EVMCliqueDiscard(rpcClientKey, rpcURL, addr string) error {
+	return evmConsensusOp(rpcClientKey, rpcURL, "clique_discard", common.HexToAddress(addr))
+}
+
+// EVMCliqueGetStatus returns the sealing activity of the clique signers
+func EVMCliqueGetStatus(rpcClientKey, rpcURL string) (*EVMCliqueStatus, error) {
+	var status *EVMCliqueStatus
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "clique_status", []interface{}{}, &status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch clique status; %s", err.Error())
+	}
+	return status, nil
+}
+
+// EVMIstanbulGetValidators returns the istanbul (IBFT) validators at the given block; a nil block
+// number resolves the validators at the latest block
+func EVMIstanbulGetValidators(rpcClientKey, rpcURL string, blockNumber *uint64) ([]common.Address, error) {
+	return evmGetValidatorSet(rpcClientKey, rpcURL, "istanbul_getValidators", blockNumber)
+}
+
+// EVMIstanbulGetCandidates returns the pending istanbul validator proposals as a mapping of address to authorization
+func EVMIstanbulGetCandidates(rpcClientKey, rpcURL string) (map[common.Address]bool, error) {
+	candidates := map[common.Address]bool{}
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "istanbul_candidates", []interface{}{}, &candidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch istanbul candidates; %s", err.Error())
+	}
+	return candidates, nil
+}
+
+// EVMIstanbulPropose proposes adding (auth = true) or removing (auth = false) the given validator
+func EVMIstanbulPropose(rpcClientKey, rpcURL, addr string, auth bool) error {
+	return evmConsensusOp(rpcClientKey, rpcURL, "istanbul_propose", common.HexToAddress(addr), auth)
+}
+
+// EVMIstanbulDiscard drops the pending istanbul proposal for the given validator
+func EVMIstanbulDiscard(rpcClientKey, rpcURL, addr string) error {
+	return evmConsensusOp(rpcClientKey, rpcURL, "istanbul_discard", common.HexToAddress(addr))
+}
+
+func evmGetValidatorSet(rpcClientKey, rpcURL, method string, blockNumber *uint64) ([]common.Address, error) {
+	var block interface{} = "latest"
+	if blockNumber != nil {
+		block = hexutil.Uint64(*blockNumber)
+	}
+
+	validators := make([]common.Address, 0)
+	prvdcommon.Log.Debugf("Attempting to fetch validator set via %s method via JSON-RPC", method)
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, method, []interface{}{block}, &validators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch validator set; %s", err.Error())
+	}
+	return validators, nil
+}
+
+func evmConsensusOp(rpcClientKey, rpcURL, method string, params ...interface{}) error {
+	prvdcommon.Log.Debugf("Attempting to invoke %s method via JSON-RPC", method)
+	err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, method, params, nil)
+	if err != nil {
+		return fmt.Errorf("failed to invoke %s; %s", method, err.Error())
+	}
+	return nil
+}
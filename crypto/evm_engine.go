@@ -0,0 +1,172 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// EVMPayloadStatusValid indicates the payload was fully validated
+	EVMPayloadStatusValid = "VALID"
+
+	// EVMPayloadStatusInvalid indicates the payload failed validation
+	EVMPayloadStatusInvalid = "INVALID"
+
+	// EVMPayloadStatusSyncing indicates the execution client is syncing and could not validate the payload
+	EVMPayloadStatusSyncing = "SYNCING"
+
+	// EVMPayloadStatusAccepted indicates the payload was accepted on a side chain without full validation
+	EVMPayloadStatusAccepted = "ACCEPTED"
+
+	// EVMPayloadStatusInvalidBlockHash indicates the block hash of the payload did not match its contents
+	EVMPayloadStatusInvalidBlockHash = "INVALID_BLOCK_HASH"
+)
+
+// EVMExecutionPayload is an execution layer block as exchanged over the engine API
+type EVMExecutionPayload struct {
+	ParentHash    common.Hash      `json:"parentHash"`
+	FeeRecipient  common.Address   `json:"feeRecipient"`
+	StateRoot     common.Hash      `json:"stateRoot"`
+	ReceiptsRoot  common.Hash      `json:"receiptsRoot"`
+	LogsBloom     hexutil.Bytes    `json:"logsBloom"`
+	PrevRandao    common.Hash      `json:"prevRandao"`
+	BlockNumber   hexutil.Uint64   `json:"blockNumber"`
+	GasLimit      hexutil.Uint64   `json:"gasLimit"`
+	GasUsed       hexutil.Uint64   `json:"gasUsed"`
+	Timestamp     hexutil.Uint64   `json:"timestamp"`
+	ExtraData     hexutil.Bytes    `json:"extraData"`
+	BaseFeePerGas *hexutil.Big     `json:"baseFeePerGas"`
+	BlockHash     common.Hash      `json:"blockHash"`
+	Transactions  []hexutil.Bytes  `json:"transactions"`
+	Withdrawals   []*EVMWithdrawal `json:"withdrawals,omitempty"`
+}
+
+// EVMWithdrawal is a validator withdrawal included in an execution payload
+type EVMWithdrawal struct {
+	Index          hexutil.Uint64 `json:"index"`
+	ValidatorIndex hexutil.Uint64 `json:"validatorIndex"`
+	Address        common.Address `json:"address"`
+	Amount         hexutil.Uint64 `json:"amount"` // in gwei
+}
+
+// EVMForkchoiceState is the head, safe and finalized block hashes as determined by the consensus client
+type EVMForkchoiceState struct {
+	HeadBlockHash      common.Hash `json:"headBlockHash"`
+	SafeBlockHash      common.Hash `json:"safeBlockHash"`
+	FinalizedBlockHash common.Hash `json:"finalizedBlockHash"`
+}
+
+// EVMPayloadAttributes instruct the execution client to begin building a payload
+type EVMPayloadAttributes struct {
+	Timestamp             hexutil.Uint64   `json:"timestamp"`
+	PrevRandao            common.Hash      `json:"prevRandao"`
+	SuggestedFeeRecipient common.Address   `json:"suggestedFeeRecipient"`
+	Withdrawals           []*EVMWithdrawal `json:"withdrawals,omitempty"`
+}
+
+// EVMPayloadStatus is the result of validating a payload
+type EVMPayloadStatus struct {
+	Status          string       `json:"status"`
+	LatestValidHash *common.Hash `json:"latestValidHash"`
+	ValidationError *string      `json:"validationError"`
+}
+
+// EVMForkchoiceUpdatedResult is the result of engine_forkchoiceUpdated
+type EVMForkchoiceUpdatedResult struct {
+	PayloadStatus EVMPayloadStatus `json:"payloadStatus"`
+	PayloadID     *hexutil.Bytes   `json:"payloadId"`
+}
+
+// EVMRegisterEngineJWTSecret configures the hex-encoded engine API JWT secret for the given rpc client key
+func EVMRegisterEngineJWTSecret(rpcClientKey, secret string) error {
+	jwtSecret, err := ParseJWTSecret(secret)
+	if err != nil {
+		return err
+	}
+	RegisterRPCCredentials(rpcClientKey, &RPCCredentials{JWTSecret: jwtSecret})
+	return nil
+}
+
+// EVMEngineExchangeCapabilities returns the engine API methods supported by the execution client
+func EVMEngineExchangeCapabilities(rpcClientKey, rpcURL string, capabilities []string) ([]string, error) {
+	supported := make([]string, 0)
+	err := evmEngineInvoke(rpcClientKey, rpcURL, "engine_exchangeCapabilities", []interface{}{capabilities}, &supported)
+	if err != nil {
+		return nil, err
+	}
+	return supported, nil
+}
+
+// EVMEngineForkchoiceUpdated updates the forkchoice state of the execution client using the given engine
+// API version; when attrs is non-nil, payload building is initiated and the result includes its payload id
+func EVMEngineForkchoiceUpdated(rpcClientKey, rpcURL string, version int, state *EVMForkchoiceState, attrs *EVMPayloadAttributes) (*EVMForkchoiceUpdatedResult, error) {
+	var result *EVMForkchoiceUpdatedResult
+	method := evmEngineMethod("engine_forkchoiceUpdated", version)
+	err := evmEngineInvoke(rpcClientKey, rpcURL, method, []interface{}{state, attrs}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EVMEngineNewPayload submits the given payload for validation using the given engine API version;
+// extra params required by later versions, i.e. versioned hashes, are appended to the request
+func EVMEngineNewPayload(rpcClientKey, rpcURL string, version int, payload *EVMExecutionPayload, extra ...interface{}) (*EVMPayloadStatus, error) {
+	var status *EVMPayloadStatus
+	method := evmEngineMethod("engine_newPayload", version)
+	params := append([]interface{}{payload}, extra...)
+	err := evmEngineInvoke(rpcClientKey, rpcURL, method, params, &status)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// EVMEngineGetPayload retrieves the payload built for the given payload id using the given engine
+// API version; the block value is only reported by version 2 and later
+func EVMEngineGetPayload(rpcClientKey, rpcURL string, version int, payloadID hexutil.Bytes) (*EVMExecutionPayload, *big.Int, error) {
+	var raw json.RawMessage
+	method := evmEngineMethod("engine_getPayload", version)
+	err := evmEngineInvoke(rpcClientKey, rpcURL, method, []interface{}{payloadID}, &raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if version < 2 {
+		var payload *EVMExecutionPayload
+		err = json.Unmarshal(raw, &payload)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal %s result; %s", method, err.Error())
+		}
+		return payload, nil, nil
+	}
+
+	var envelope struct {
+		ExecutionPayload *EVMExecutionPayload `json:"executionPayload"`
+		BlockValue       *hexutil.Big         `json:"blockValue"`
+	}
+	err = json.Unmarshal(raw, &envelope)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal %s result; %s", method, err.Error())
+	}
+	return envelope.ExecutionPayload, (*big.Int)(envelope.BlockValue), nil
+}
+
+func evmEngineMethod(method string, version int) string {
+	if version < 1 {
+		version = 1
+	}
+	return fmt.Sprintf("%sV%d", method, version)
+}
+
+func evmEngineInvoke(rpcClientKey, rpcURL, method string, params []interface{}, result interface{}) error {
+	err := InvokeRPC(rpcClientKey, rpcURL, method, params, result, &RPCOptions{DecodeMode: EVMDecodeStrict})
+	if err != nil {
+		return fmt.Errorf("failed to invoke %s; %s", method, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package crypto
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEVMEngineForkchoiceUpdated(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}       `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "engine_forkchoiceUpdatedV2" || len(req.Params) != 2 {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result": map[string]interface{}{
+				"payloadStatus": map[string]interface{}{"status": "VALID", "latestValidHash": common.Hash{0x01}.Hex()},
+				"payloadId":     "0x0102030405060708",
+			},
+		})
+	}))
+	defer srv.Close()
+
+	result, err := EVMEngineForkchoiceUpdated("engine-test", srv.URL, 2, &EVMForkchoiceState{HeadBlockHash: common.Hash{0x01}}, &EVMPayloadAttributes{Timestamp: 1})
+	if err != nil {
+		t.Fatalf("failed to update forkchoice; %s", err.Error())
+	}
+	if result.PayloadStatus.Status != EVMPayloadStatusValid || result.PayloadID == nil || len(*result.PayloadID) != 8 {
+		t.Errorf("unexpected forkchoice updated result %v", result)
+	}
+
+	_, err = EVMEngineForkchoiceUpdated("engine-test", srv.URL, 1, &EVMForkchoiceState{}, nil)
+	if err == nil {
+		t.Error("expected error object to be returned as an error")
+	}
+}
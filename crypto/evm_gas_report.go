@@ -0,0 +1,286 @@
+package crypto
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+	prvdabi "github.com/provideplatform/provide-go/crypto/abi"
+)
+
+// opcode categories, as reported by EVMGasReport
+const (
+	EVMOpcodeCategoryArithmetic   = "arithmetic"
+	EVMOpcodeCategoryBitwise      = "comparison_bitwise"
+	EVMOpcodeCategoryHashing      = "hashing"
+	EVMOpcodeCategoryEnvironment  = "environment"
+	EVMOpcodeCategoryBlock        = "block"
+	EVMOpcodeCategoryStack        = "stack"
+	EVMOpcodeCategoryMemory       = "memory"
+	EVMOpcodeCategoryStorage      = "storage"
+	EVMOpcodeCategoryFlow         = "flow"
+	EVMOpcodeCategoryLogging      = "logging"
+	EVMOpcodeCategoryCall         = "call"
+	EVMOpcodeCategoryCreate       = "create"
+	EVMOpcodeCategorySelfdestruct = "selfdestruct"
+	EVMOpcodeCategoryOther        = "other"
+)
+
+// evmGasReportTraceConfig configures the default struct logger to omit everything but opcodes and gas
+var evmGasReportTraceConfig = map[string]interface{}{
+	"disableStack":     true,
+	"disableStorage":   true,
+	"enableMemory":     false,
+	"enableReturnData": false,
+}
+
+var evmOpcodeCategories = map[string]string{
+	"ADD": EVMOpcodeCategoryArithmetic, "MUL": EVMOpcodeCategoryArithmetic, "SUB": EVMOpcodeCategoryArithmetic,
+	"DIV": EVMOpcodeCategoryArithmetic, "SDIV": EVMOpcodeCategoryArithmetic, "MOD": EVMOpcodeCategoryArithmetic,
+	"SMOD": EVMOpcodeCategoryArithmetic, "ADDMOD": EVMOpcodeCategoryArithmetic, "MULMOD": EVMOpcodeCategoryArithmetic,
+	"EXP": EVMOpcodeCategoryArithmetic, "SIGNEXTEND": EVMOpcodeCategoryArithmetic,
+
+	"LT": EVMOpcodeCategoryBitwise, "GT": EVMOpcodeCategoryBitwise, "SLT": EVMOpcodeCategoryBitwise,
+	"SGT": EVMOpcodeCategoryBitwise, "EQ": EVMOpcodeCategoryBitwise, "ISZERO": EVMOpcodeCategoryBitwise,
+	"AND": EVMOpcodeCategoryBitwise, "OR": EVMOpcodeCategoryBitwise, "XOR": EVMOpcodeCategoryBitwise,
+	"NOT": EVMOpcodeCategoryBitwise, "BYTE": EVMOpcodeCategoryBitwise, "SHL": EVMOpcodeCategoryBitwise,
+	"SHR": EVMOpcodeCategoryBitwise, "SAR": EVMOpcodeCategoryBitwise,
+
+	"SHA3": EVMOpcodeCategoryHashing, "KECCAK256": EVMOpcodeCategoryHashing,
+
+	"ADDRESS": EVMOpcodeCategoryEnvironment, "BALANCE": EVMOpcodeCategoryEnvironment, "ORIGIN": EVMOpcodeCategoryEnvironment,
+	"CALLER": EVMOpcodeCategoryEnvironment, "CALLVALUE": EVMOpcodeCategoryEnvironment, "CALLDATALOAD": EVMOpcodeCategoryEnvironment,
+	"CALLDATASIZE": EVMOpcodeCategoryEnvironment, "CODESIZE": EVMOpcodeCategoryEnvironment, "GASPRICE": EVMOpcodeCategoryEnvironment,
+	"EXTCODESIZE": EVMOpcodeCategoryEnvironment, "EXTCODECOPY": EVMOpcodeCategoryEnvironment, "RETURNDATASIZE": EVMOpcodeCategoryEnvironment,
+	"EXTCODEHASH": EVMOpcodeCategoryEnvironment, "SELFBALANCE": EVMOpcodeCategoryEnvironment, "GAS": EVMOpcodeCategoryEnvironment,
+
+	"BLOCKHASH": EVMOpcodeCategoryBlock, "COINBASE": EVMOpcodeCategoryBlock, "TIMESTAMP": EVMOpcodeCategoryBlock,
+	"NUMBER": EVMOpcodeCategoryBlock, "DIFFICULTY": EVMOpcodeCategoryBlock, "PREVRANDAO": EVMOpcodeCategoryBlock,
+	"GASLIMIT": EVMOpcodeCategoryBlock, "CHAINID": EVMOpcodeCategoryBlock, "BASEFEE": EVMOpcodeCategoryBlock,
+	"BLOBHASH": EVMOpcodeCategoryBlock, "BLOBBASEFEE": EVMOpcodeCategoryBlock,
+
+	"POP": EVMOpcodeCategoryStack,
+
+	"MLOAD": EVMOpcodeCategoryMemory, "MSTORE": EVMOpcodeCategoryMemory, "MSTORE8": EVMOpcodeCategoryMemory,
+	"MSIZE": EVMOpcodeCategoryMemory, "MCOPY": EVMOpcodeCategoryMemory, "CALLDATACOPY": EVMOpcodeCategoryMemory,
+	"CODECOPY": EVMOpcodeCategoryMemory, "RETURNDATACOPY": EVMOpcodeCategoryMemory,
+
+	"SLOAD": EVMOpcodeCategoryStorage, "SSTORE": EVMOpcodeCategoryStorage, "TLOAD": EVMOpcodeCategoryStorage,
+	"TSTORE": EVMOpcodeCategoryStorage,
+
+	"STOP": EVMOpcodeCategoryFlow, "JUMP": EVMOpcodeCategoryFlow, "JUMPI": EVMOpcodeCategoryFlow,
+	"JUMPDEST": EVMOpcodeCategoryFlow, "PC": EVMOpcodeCategoryFlow, "RETURN": EVMOpcodeCategoryFlow,
+	"REVERT": EVMOpcodeCategoryFlow, "INVALID": EVMOpcodeCategoryFlow,
+
+	"CALL": EVMOpcodeCategoryCall, "CALLCODE": EVMOpcodeCategoryCall, "DELEGATECALL": EVMOpcodeCategoryCall,
+	"STATICCALL": EVMOpcodeCategoryCall,
+
+	"CREATE": EVMOpcodeCategoryCreate, "CREATE2": EVMOpcodeCategoryCreate,
+
+	"SELFDESTRUCT": EVMOpcodeCategorySelfdestruct, "SUICIDE": EVMOpcodeCategorySelfdestruct,
+}
+
+// EVMGasReportCall is a call to be traced for a gas report, in the eth_call message format
+type EVMGasReportCall struct {
+	From  string  `json:"from,omitempty"`
+	To    string  `json:"to"`
+	Data  string  `json:"data,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+// EVMFunctionGasStats are the gas statistics of a function across the traced transactions and calls
+type EVMFunctionGasStats struct {
+	Function string `json:"function"`
+	Selector string `json:"selector"`
+	Calls    int    `json:"calls"`
+	Min      uint64 `json:"min"`
+	Max      uint64 `json:"max"`
+	Avg      uint64 `json:"avg"`
+	Total    uint64 `json:"total"`
+}
+
+// EVMGasReport is a structured gas report, suitable for comparison in CI
+type EVMGasReport struct {
+	Functions map[string]*EVMFunctionGasStats `json:"functions"` // mapping of function (or selector) to stats
+	Opcodes   map[string]uint64               `json:"opcodes"`   // mapping of opcode category to gas
+	Total     uint64                          `json:"total"`
+}
+
+// evmStructLog is a step of the default struct logger trace
+type evmStructLog struct {
+	Op      string `json:"op"`
+	Gas     uint64 `json:"gas"`
+	GasCost uint64 `json:"gasCost"`
+	Depth   int    `json:"depth"`
+}
+
+type evmStructLogTrace struct {
+	Gas        uint64          `json:"gas"`
+	Failed     bool            `json:"failed"`
+	StructLogs []*evmStructLog `json:"structLogs"`
+}
+
+// NewEVMGasReport initializes an empty gas report
+func NewEVMGasReport() *EVMGasReport {
+	return &EVMGasReport{
+		Functions: map[string]*EVMFunctionGasStats{},
+		Opcodes:   map[string]uint64{},
+	}
+}
+
+// EVMTraceGasReport traces the given mined transactions, using debug_traceTransaction, and calls, using
+// debug_traceCall at the latest block, and reports their gas usage per function and per opcode category
+func EVMTraceGasReport(rpcClientKey, rpcURL string, txHashes []string, calls []*EVMGasReportCall) (*EVMGasReport, error) {
+	report := NewEVMGasReport()
+
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, txHash := range txHashes {
+		tx, _, err := client.TransactionByHash(context.TODO(), common.HexToHash(txHash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve tx %s; %s", txHash, err.Error())
+		}
+
+		var trace *evmStructLogTrace
+		prvdcommon.Log.Debugf("Attempting to trace tx %s for gas report via JSON-RPC", txHash)
+		err = evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "debug_traceTransaction", []interface{}{txHash, evmGasReportTraceConfig}, &trace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace tx %s; %s", txHash, err.Error())
+		}
+		report.add(tx.Data(), trace)
+	}
+
+	for _, call := range calls {
+		var trace *evmStructLogTrace
+		prvdcommon.Log.Debugf("Attempting to trace call to %s for gas report via JSON-RPC", call.To)
+		err := evmInvokeJsonRpcResult(rpcClientKey, rpcURL, "debug_traceCall", []interface{}{call, "latest", evmGasReportTraceConfig}, &trace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace call to %s; %s", call.To, err.Error())
+		}
+		report.add(common.FromHex(call.Data), trace)
+	}
+
+	return report, nil
+}
+
+// add records the given trace of a transaction or call having the given calldata
+func (r *EVMGasReport) add(calldata []byte, trace *evmStructLogTrace) {
+	if trace == nil {
+		return
+	}
+
+	selector := ""
+	function := "fallback"
+	if len(calldata) >= 4 {
+		selector = fmt.Sprintf("0x%s", hex.EncodeToString(calldata[0:4]))
+		function = selector
+		if sigs := prvdabi.LookupSelector(selector); len(sigs) > 0 {
+			function = sigs[0].Signature
+		}
+	}
+
+	stats := r.Functions[function]
+	if stats == nil {
+		stats = &EVMFunctionGasStats{
+			Function: function,
+			Selector: selector,
+			Min:      trace.Gas,
+		}
+		r.Functions[function] = stats
+	}
+	stats.Calls++
+	stats.Total += trace.Gas
+	if trace.Gas < stats.Min {
+		stats.Min = trace.Gas
+	}
+	if trace.Gas > stats.Max {
+		stats.Max = trace.Gas
+	}
+	stats.Avg = stats.Total / uint64(stats.Calls)
+
+	for op, gas := range evmOpcodeGasByCategory(trace.StructLogs) {
+		r.Opcodes[op] += gas
+	}
+	r.Total += trace.Gas
+}
+
+// Regressions returns a description of each function whose average gas exceeds that of the given
+// baseline report by more than the given tolerance percentage; functions absent from the baseline
+// are not considered regressions
+func (r *EVMGasReport) Regressions(baseline *EVMGasReport, tolerancePercent uint64) []string {
+	regressions := make([]string, 0)
+	for function, stats := range r.Functions {
+		base, ok := baseline.Functions[function]
+		if !ok || base.Avg == 0 {
+			continue
+		}
+		if stats.Avg*100 > base.Avg*(100+tolerancePercent) {
+			regressions = append(regressions, fmt.Sprintf("%s: average gas increased from %d to %d", function, base.Avg, stats.Avg))
+		}
+	}
+	sort.Strings(regressions)
+	return regressions
+}
+
+// EVMOpcodeCategory returns the category of the given opcode mnemonic
+func EVMOpcodeCategory(op string) string {
+	if category, ok := evmOpcodeCategories[op]; ok {
+		return category
+	}
+	switch {
+	case strings.HasPrefix(op, "PUSH"), strings.HasPrefix(op, "DUP"), strings.HasPrefix(op, "SWAP"):
+		return EVMOpcodeCategoryStack
+	case strings.HasPrefix(op, "LOG"):
+		return EVMOpcodeCategoryLogging
+	}
+	return EVMOpcodeCategoryOther
+}
+
+// evmOpcodeGasByCategory attributes the gas of each step to its opcode category; the gas reported
+// for call and create steps includes the gas forwarded to the callee, so their own cost is resolved
+// as the gas consumed by the step less the gas consumed by the steps of the callee
+func evmOpcodeGasByCategory(steps []*evmStructLog) map[string]uint64 {
+	costs := make([]uint64, len(steps))
+	suffix := make([]uint64, len(steps)+1) // suffix[i] is the sum of costs[i:]
+	next := map[int]int{}                  // mapping of depth to the index of the nearest subsequent step at that depth
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		k, ok := next[step.Depth]
+		if parent, parentOk := next[step.Depth-1]; parentOk && (!ok || parent < k) {
+			k, ok = parent, true
+		}
+
+		switch {
+		case !ok || steps[k].Depth < step.Depth:
+			costs[i] = step.GasCost // last step of a frame
+		case k == i+1:
+			costs[i] = evmSaturatingSub(step.Gas, steps[k].Gas)
+		default:
+			costs[i] = evmSaturatingSub(evmSaturatingSub(step.Gas, steps[k].Gas), suffix[i+1]-suffix[k])
+		}
+
+		suffix[i] = suffix[i+1] + costs[i]
+		next[step.Depth] = i
+	}
+
+	categories := map[string]uint64{}
+	for i, step := range steps {
+		categories[EVMOpcodeCategory(step.Op)] += costs[i]
+	}
+	return categories
+}
+
+func evmSaturatingSub(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEVMOpcodeGasByCategory(t *testing.T) {
+	steps := []*evmStructLog{
+		{Op: "PUSH1", Gas: 1000, GasCost: 3, Depth: 1},
+		{Op: "CALL", Gas: 997, GasCost: 900, Depth: 1}, // includes gas forwarded to the callee
+		{Op: "PUSH1", Gas: 800, GasCost: 3, Depth: 2},
+		{Op: "STOP", Gas: 797, GasCost: 0, Depth: 2},
+		{Op: "SSTORE", Gas: 894, GasCost: 20, Depth: 1},
+		{Op: "STOP", Gas: 874, GasCost: 0, Depth: 1},
+	}
+
+	categories := evmOpcodeGasByCategory(steps)
+	expected := map[string]uint64{
+		EVMOpcodeCategoryStack:   6,
+		EVMOpcodeCategoryCall:    100,
+		EVMOpcodeCategoryStorage: 20,
+		EVMOpcodeCategoryFlow:    0,
+	}
+	for category, gas := range expected {
+		if categories[category] != gas {
+			t.Errorf("expected %d gas for %s; got %d", gas, category, categories[category])
+		}
+	}
+}
+
+func TestEVMGasReportRegressions(t *testing.T) {
+	transfer := common.FromHex("0xa9059cbb")
+
+	baseline := NewEVMGasReport()
+	baseline.add(transfer, &evmStructLogTrace{Gas: 50000})
+
+	report := NewEVMGasReport()
+	report.add(transfer, &evmStructLogTrace{Gas: 52000})
+	report.add(transfer, &evmStructLogTrace{Gas: 60000})
+	report.add(nil, &evmStructLogTrace{Gas: 21000})
+
+	stats := report.Functions["transfer(address,uint256)"]
+	if stats == nil || stats.Calls != 2 || stats.Min != 52000 || stats.Max != 60000 || stats.Avg != 56000 {
+		t.Fatalf("unexpected transfer stats %+v", stats)
+	}
+	if report.Functions["fallback"] == nil {
+		t.Error("expected fallback stats")
+	}
+
+	if regressions := report.Regressions(baseline, 15); len(regressions) != 0 {
+		t.Errorf("unexpected regressions %v", regressions)
+	}
+	if regressions := report.Regressions(baseline, 10); len(regressions) != 1 {
+		t.Errorf("expected 1 regression; got %v", regressions)
+	}
+}
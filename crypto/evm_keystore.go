@@ -0,0 +1,272 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const evmKeystoreVersion = 3
+const evmKeystoreCipher = "aes-128-ctr"
+const evmKeystoreKDFScrypt = "scrypt"
+const evmKeystoreKDFPBKDF2 = "pbkdf2"
+const evmKeystoreDKLen = 32
+
+// ErrEVMKeystoreDecryption is returned when a keystore cannot be decrypted using the given passphrase
+var ErrEVMKeystoreDecryption = errors.New("could not decrypt keystore with given passphrase")
+
+// EVMKeystoreScryptParams are the scrypt cost parameters used to derive the keystore encryption key
+type EVMKeystoreScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+var (
+	// EVMKeystoreStandardScryptParams are the geth default scrypt parameters; n,r,p = 2^18, 8, 1 uses
+	// 256MB memory and approx 1s CPU time on a modern CPU
+	EVMKeystoreStandardScryptParams = &EVMKeystoreScryptParams{N: 1 << 18, R: 8, P: 1}
+
+	// EVMKeystoreLightScryptParams are the geth light scrypt parameters; n,r,p = 2^12, 8, 6 uses 4MB
+	// memory and approx 100ms CPU time on a modern CPU
+	EVMKeystoreLightScryptParams = &EVMKeystoreScryptParams{N: 1 << 12, R: 8, P: 6}
+
+	// evmLegacyKeystoreScryptParams are the parameters historically used by EVMMarshalEncryptedKey
+	evmLegacyKeystoreScryptParams = &EVMKeystoreScryptParams{N: 1 << 12, R: 4, P: 6}
+)
+
+type evmKeystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+type evmKeystoreCrypto struct {
+	Cipher       string                  `json:"cipher"`
+	CipherText   string                  `json:"ciphertext"`
+	CipherParams evmKeystoreCipherParams `json:"cipherparams"`
+	KDF          string                  `json:"kdf"`
+	KDFParams    map[string]interface{}  `json:"kdfparams"`
+	MAC          string                  `json:"mac"`
+}
+
+type evmKeystore struct {
+	ID      string            `json:"id"`
+	Address string            `json:"address"`
+	Crypto  evmKeystoreCrypto `json:"crypto"`
+	Version int               `json:"version"`
+}
+
+// EVMEncryptKeystore encrypts the given private key as a version 3 (UTC/JSON) keystore using the
+// given scrypt parameters; the standard geth parameters are used when none are given
+func EVMEncryptKeystore(privateKey *ecdsa.PrivateKey, passphrase string, params *EVMKeystoreScryptParams) ([]byte, error) {
+	if params == nil {
+		params = EVMKeystoreStandardScryptParams
+	}
+	return evmEncryptKeystore(ethcrypto.PubkeyToAddress(privateKey.PublicKey), privateKey, passphrase, params)
+}
+
+func evmEncryptKeystore(addr common.Address, privateKey *ecdsa.PrivateKey, passphrase string, params *EVMKeystoreScryptParams) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		prvdcommon.Log.Errorf("Failed while reading from crypto/rand; %s", err.Error())
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, evmKeystoreDKLen)
+	if err != nil {
+		return nil, err
+	}
+	encryptKey := derivedKey[:16]
+	keyBytes := ethcrypto.FromECDSA(privateKey)
+
+	iv := make([]byte, aes.BlockSize) // 16
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		prvdcommon.Log.Errorf("Failed while reading from crypto/rand; %s", err.Error())
+		return nil, err
+	}
+
+	cipherText, err := aesCTRXOR(encryptKey, keyBytes, iv)
+	if err != nil {
+		return nil, err
+	}
+	mac := ethcrypto.Keccak256(derivedKey[16:32], cipherText)
+
+	keyUUID, _ := generateEVMKeyUUID()
+
+	return json.Marshal(evmKeystore{
+		ID:      keyUUID,
+		Address: hex.EncodeToString(addr[:]),
+		Crypto: evmKeystoreCrypto{
+			Cipher:     evmKeystoreCipher,
+			CipherText: hex.EncodeToString(cipherText),
+			CipherParams: evmKeystoreCipherParams{
+				IV: hex.EncodeToString(iv),
+			},
+			KDF: evmKeystoreKDFScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     params.N,
+				"r":     params.R,
+				"p":     params.P,
+				"dklen": evmKeystoreDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(mac),
+		},
+		Version: evmKeystoreVersion,
+	})
+}
+
+// EVMDecryptKeystore decrypts the given version 3 (UTC/JSON) keystore, as written by geth and most
+// wallets, using the given passphrase; both scrypt and pbkdf2 key derivation are supported
+func EVMDecryptKeystore(keyJSON []byte, passphrase string) (*common.Address, *ecdsa.PrivateKey, error) {
+	var keystore *evmKeystore
+	err := json.Unmarshal(keyJSON, &keystore)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal keystore; %s", err.Error())
+	}
+	if keystore.Version != evmKeystoreVersion {
+		return nil, nil, fmt.Errorf("failed to decrypt keystore; unsupported version %d", keystore.Version)
+	}
+	if keystore.Crypto.Cipher != evmKeystoreCipher {
+		return nil, nil, fmt.Errorf("failed to decrypt keystore; unsupported cipher %s", keystore.Crypto.Cipher)
+	}
+
+	mac, err := hex.DecodeString(keystore.Crypto.MAC)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode keystore mac; %s", err.Error())
+	}
+	iv, err := hex.DecodeString(keystore.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode keystore iv; %s", err.Error())
+	}
+	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode keystore ciphertext; %s", err.Error())
+	}
+
+	derivedKey, err := evmKeystoreDeriveKey(&keystore.Crypto, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hmac.Equal(ethcrypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, nil, ErrEVMKeystoreDecryption
+	}
+
+	keyBytes, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt keystore; %s", err.Error())
+	}
+
+	privateKey, err := ethcrypto.ToECDSA(common.LeftPadBytes(keyBytes, 32))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt keystore; invalid private key; %s", err.Error())
+	}
+
+	addr := ethcrypto.PubkeyToAddress(privateKey.PublicKey)
+	if keystore.Address != "" && common.HexToAddress(keystore.Address) != addr {
+		return nil, nil, fmt.Errorf("failed to decrypt keystore; key does not match address %s", keystore.Address)
+	}
+	return &addr, privateKey, nil
+}
+
+// EVMReadKeystoreFile decrypts the keystore file at the given path; the hex-encoded private key can
+// be passed to EVMSignTx using: hex.EncodeToString(ethcrypto.FromECDSA(privateKey))
+func EVMReadKeystoreFile(path, passphrase string) (*common.Address, *ecdsa.PrivateKey, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read keystore file %s; %s", path, err.Error())
+	}
+	return EVMDecryptKeystore(keyJSON, passphrase)
+}
+
+// EVMWriteKeystoreFile encrypts the given private key and writes it to the given directory using the
+// geth UTC/JSON file naming convention; the path of the written keystore file is returned
+func EVMWriteKeystoreFile(dir string, privateKey *ecdsa.PrivateKey, passphrase string, params *EVMKeystoreScryptParams) (string, error) {
+	keyJSON, err := EVMEncryptKeystore(privateKey, passphrase, params)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", fmt.Errorf("failed to create keystore directory %s; %s", dir, err.Error())
+	}
+
+	addr := ethcrypto.PubkeyToAddress(privateKey.PublicKey)
+	path := filepath.Join(dir, evmKeystoreFilename(time.Now().UTC(), addr))
+	err = ioutil.WriteFile(path, keyJSON, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to write keystore file %s; %s", path, err.Error())
+	}
+	return path, nil
+}
+
+// evmKeystoreFilename returns the geth keystore filename, i.e. UTC--2006-01-02T15-04-05.000000000Z--<address>
+func evmKeystoreFilename(t time.Time, addr common.Address) string {
+	timestamp := strings.Replace(t.Format("2006-01-02T15-04-05.000000000Z07:00"), ":", "-", -1)
+	return fmt.Sprintf("UTC--%s--%s", timestamp, hex.EncodeToString(addr[:]))
+}
+
+func evmKeystoreDeriveKey(keyCrypto *evmKeystoreCrypto, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(evmKeystoreStringParam(keyCrypto.KDFParams, "salt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode keystore salt; %s", err.Error())
+	}
+	dkLen := evmKeystoreIntParam(keyCrypto.KDFParams, "dklen")
+	if dkLen < 32 {
+		return nil, fmt.Errorf("failed to derive keystore key; invalid dklen %d", dkLen)
+	}
+
+	switch keyCrypto.KDF {
+	case evmKeystoreKDFScrypt:
+		n := evmKeystoreIntParam(keyCrypto.KDFParams, "n")
+		r := evmKeystoreIntParam(keyCrypto.KDFParams, "r")
+		p := evmKeystoreIntParam(keyCrypto.KDFParams, "p")
+		derivedKey, err := scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive keystore key; %s", err.Error())
+		}
+		return derivedKey, nil
+	case evmKeystoreKDFPBKDF2:
+		prf := evmKeystoreStringParam(keyCrypto.KDFParams, "prf")
+		if prf != "hmac-sha256" {
+			return nil, fmt.Errorf("failed to derive keystore key; unsupported pbkdf2 prf %s", prf)
+		}
+		c := evmKeystoreIntParam(keyCrypto.KDFParams, "c")
+		return pbkdf2.Key([]byte(passphrase), salt, c, dkLen, sha256.New), nil
+	}
+
+	return nil, fmt.Errorf("failed to derive keystore key; unsupported kdf %s", keyCrypto.KDF)
+}
+
+func evmKeystoreIntParam(params map[string]interface{}, key string) int {
+	if val, ok := params[key].(float64); ok {
+		return int(val)
+	}
+	return 0
+}
+
+func evmKeystoreStringParam(params map[string]interface{}, key string) string {
+	if val, ok := params[key].(string); ok {
+		return val
+	}
+	return ""
+}
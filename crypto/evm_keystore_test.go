@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// testEVMKeystore is the very light scrypt keystore used by the geth keystore tests
+const testEVMKeystore = `{"address":"45dea0fb0bba44f4fcf290bba71fd57d7117cbb8","crypto":{"cipher":"aes-128-ctr","ciphertext":"b87781948a1befd247bff51ef4063f716cf6c2d3481163e9a8f42e1f9bb74145","cipherparams":{"iv":"dc4926b48a105133d2f16b96833abf1e"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":2,"p":1,"r":8,"salt":"004244bbdc51cadda545b1cfa43cff9ed2ae88e08c61f1479dbb45410722f8f0"},"mac":"39990c1684557447940d4c69e06b1b82b2aceacb43f284df65c956daf3046b85"},"id":"ce541d8d-c79b-40f8-9f8c-20f59616faba","version":3}`
+
+func TestEVMDecryptKeystore(t *testing.T) {
+	addr, _, err := EVMDecryptKeystore([]byte(testEVMKeystore), "")
+	if err != nil {
+		t.Fatalf("failed to decrypt keystore; %s", err.Error())
+	}
+	if !strings.EqualFold(addr.Hex(), "0x45dea0fb0bba44f4fcf290bba71fd57d7117cbb8") {
+		t.Errorf("unexpected keystore address %s", addr.Hex())
+	}
+
+	_, _, err = EVMDecryptKeystore([]byte(testEVMKeystore), "wrong")
+	if err != ErrEVMKeystoreDecryption {
+		t.Errorf("expected decryption error; got %v", err)
+	}
+}
+
+func TestEVMKeystoreFileRoundTrip(t *testing.T) {
+	privateKey, _ := ethcrypto.GenerateKey()
+
+	dir, err := ioutil.TempDir("", "keystore")
+	if err != nil {
+		t.Fatalf("failed to create temp dir; %s", err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	path, err := EVMWriteKeystoreFile(dir, privateKey, "secret", &EVMKeystoreScryptParams{N: 2, R: 8, P: 1})
+	if err != nil {
+		t.Fatalf("failed to write keystore file; %s", err.Error())
+	}
+	if !strings.HasPrefix(filepath.Base(path), "UTC--") {
+		t.Errorf("unexpected keystore filename %s", path)
+	}
+
+	addr, decrypted, err := EVMReadKeystoreFile(path, "secret")
+	if err != nil {
+		t.Fatalf("failed to read keystore file; %s", err.Error())
+	}
+	if *addr != ethcrypto.PubkeyToAddress(privateKey.PublicKey) || decrypted.D.Cmp(privateKey.D) != 0 {
+		t.Error("decrypted key does not match the exported key")
+	}
+}
+
+func TestEVMMarshalEncryptedKeyDecrypts(t *testing.T) {
+	privateKey, _ := ethcrypto.GenerateKey()
+	addr := ethcrypto.PubkeyToAddress(privateKey.PublicKey)
+
+	keyJSON, err := EVMMarshalEncryptedKey(addr, privateKey, "secret")
+	if err != nil {
+		t.Fatalf("failed to marshal encrypted key; %s", err.Error())
+	}
+	decryptedAddr, _, err := EVMDecryptKeystore(keyJSON, "secret")
+	if err != nil || *decryptedAddr != addr {
+		t.Errorf("failed to decrypt marshaled key; %v", err)
+	}
+}
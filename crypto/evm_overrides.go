@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const evmDefaultCallBlock = "latest"
+
+// EVMCallOpts are optional parameters for eth_call helpers
+type EVMCallOpts struct {
+	// Block is the block number (hex) or tag at which to execute the call; defaults to latest
+	Block string
+
+	// StateOverrides are injected into the call; i.e. to simulate token approvals or contract upgrades
+	StateOverrides EVMStateOverrides
+}
+
+// NewEVMStateOverrides initializes an empty set of state overrides
+func NewEVMStateOverrides() EVMStateOverrides {
+	return EVMStateOverrides{}
+}
+
+// SetBalance overrides the balance of the given account
+func (o EVMStateOverrides) SetBalance(addr string, balance *big.Int) EVMStateOverrides {
+	o.account(addr).Balance = (*hexutil.Big)(balance)
+	return o
+}
+
+// SetNonce overrides the nonce of the given account
+func (o EVMStateOverrides) SetNonce(addr string, nonce uint64) EVMStateOverrides {
+	_nonce := hexutil.Uint64(nonce)
+	o.account(addr).Nonce = &_nonce
+	return o
+}
+
+// SetCode overrides the code of the given account; i.e. to dry-run a contract upgrade
+func (o EVMStateOverrides) SetCode(addr string, code []byte) EVMStateOverrides {
+	_code := hexutil.Bytes(code)
+	o.account(addr).Code = &_code
+	return o
+}
+
+// SetStorage patches a single storage slot of the given account, leaving the remaining storage intact
+func (o EVMStateOverrides) SetStorage(addr string, slot, value common.Hash) EVMStateOverrides {
+	account := o.account(addr)
+	if account.StateDiff == nil {
+		account.StateDiff = map[common.Hash]common.Hash{}
+	}
+	account.StateDiff[slot] = value
+	return o
+}
+
+func (o EVMStateOverrides) account(addr string) *EVMAccountOverride {
+	address := common.HexToAddress(addr)
+	if o[address] == nil {
+		o[address] = &EVMAccountOverride{}
+	}
+	return o[address]
+}
+
+// EVMMappingSlot returns the storage slot of `mapping[key]` for a solidity mapping declared
+// at the given slot; i.e. the ERC20 balance of an address
+func EVMMappingSlot(key common.Hash, slot uint64) common.Hash {
+	return ethcrypto.Keccak256Hash(key.Bytes(), common.BigToHash(new(big.Int).SetUint64(slot)).Bytes())
+}
+
+// EVMNestedMappingSlot returns the storage slot of `mapping[key0][key1]` for a solidity mapping
+// declared at the given slot; i.e. the ERC20 allowance of an owner and spender
+func EVMNestedMappingSlot(key0, key1 common.Hash, slot uint64) common.Hash {
+	return ethcrypto.Keccak256Hash(key1.Bytes(), EVMMappingSlot(key0, slot).Bytes())
+}
+
+// EVMAddressKey left-pads the given address for use as a mapping key
+func EVMAddressKey(addr string) common.Hash {
+	return common.BytesToHash(common.HexToAddress(addr).Bytes())
+}
+
+// evmCallParams appends the block and any state overrides to the given eth_call params
+func evmCallParams(params []interface{}, opts *EVMCallOpts) []interface{} {
+	if opts == nil {
+		return params
+	}
+
+	_params := append([]interface{}{}, params...)
+	if len(_params) == 1 {
+		block := opts.Block
+		if block == "" {
+			block = evmDefaultCallBlock
+		}
+		_params = append(_params, block)
+	}
+	if len(opts.StateOverrides) > 0 && len(_params) == 2 {
+		_params = append(_params, opts.StateOverrides)
+	}
+	return _params
+}
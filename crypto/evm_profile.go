@@ -0,0 +1,146 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// evmBalanceOfSelector is the selector of balanceOf(address)
+var evmBalanceOfSelector = common.FromHex("0x70a08231")
+
+// EVMAddressProfile is a block-explorer-style summary of the activity of an address
+type EVMAddressProfile struct {
+	Address        common.Address      `json:"address"`
+	Nonce          uint64              `json:"nonce"`
+	Balance        *big.Int            `json:"balance"`
+	IsContract     bool                `json:"is_contract"`
+	FirstSeenBlock *uint64             `json:"first_seen_block,omitempty"`
+	TokenBalances  map[string]*big.Int `json:"token_balances,omitempty"` // mapping of token address to balance
+}
+
+// EVMGetAddressProfile summarizes the nonce, native balance, contract/EOA classification and first-seen
+// block of the given address, and its balances of the given ERC-20 tokens, if any; resolving the first-seen
+// block requires an archive node and is skipped (nil) when historical state is unavailable
+func EVMGetAddressProfile(rpcClientKey, rpcURL, addr string, tokens []string) (*EVMAddressProfile, error) {
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	address := common.HexToAddress(addr)
+	profile := &EVMAddressProfile{Address: address}
+
+	profile.Nonce, err = client.NonceAt(context.TODO(), address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve nonce of %s; %s", addr, err.Error())
+	}
+
+	profile.Balance, err = client.BalanceAt(context.TODO(), address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve balance of %s; %s", addr, err.Error())
+	}
+
+	code, err := client.CodeAt(context.TODO(), address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve code of %s; %s", addr, err.Error())
+	}
+	profile.IsContract = len(code) > 0
+
+	if profile.Nonce > 0 || profile.Balance.Sign() > 0 || profile.IsContract {
+		latest, err := client.BlockNumber(context.TODO())
+		if err == nil {
+			firstSeen, err := evmFindFirstActiveBlock(client, address, latest)
+			if err != nil {
+				prvdcommon.Log.Debugf("failed to resolve first-seen block of %s; %s", addr, err.Error())
+			} else {
+				profile.FirstSeenBlock = firstSeen
+			}
+		}
+	}
+
+	if len(tokens) > 0 {
+		profile.TokenBalances = map[string]*big.Int{}
+		for _, token := range tokens {
+			balance, err := evmERC20BalanceOf(client, token, address)
+			if err != nil {
+				prvdcommon.Log.Debugf("failed to resolve %s balance of %s; %s", token, addr, err.Error())
+				continue
+			}
+			profile.TokenBalances[common.HexToAddress(token).Hex()] = balance
+		}
+	}
+
+	return profile, nil
+}
+
+// evmFindFirstActiveBlock binary searches for the earliest block at which the address had a nonce,
+// balance or code; activity is assumed to be monotonic, which holds for all but fully drained accounts
+func evmFindFirstActiveBlock(client *ethclient.Client, address common.Address, latest uint64) (*uint64, error) {
+	return evmSearchFirstBlock(latest, func(block uint64) (bool, error) {
+		number := new(big.Int).SetUint64(block)
+		nonce, err := client.NonceAt(context.TODO(), address, number)
+		if err != nil {
+			return false, err
+		}
+		if nonce > 0 {
+			return true, nil
+		}
+		balance, err := client.BalanceAt(context.TODO(), address, number)
+		if err != nil {
+			return false, err
+		}
+		if balance.Sign() > 0 {
+			return true, nil
+		}
+		code, err := client.CodeAt(context.TODO(), address, number)
+		if err != nil {
+			return false, err
+		}
+		return len(code) > 0, nil
+	})
+}
+
+// evmSearchFirstBlock returns the first block in [0, latest] for which active returns true, or nil
+func evmSearchFirstBlock(latest uint64, active func(block uint64) (bool, error)) (*uint64, error) {
+	ok, err := active(latest)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	lo, hi := uint64(0), latest
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := active(mid)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return &lo, nil
+}
+
+func evmERC20BalanceOf(client *ethclient.Client, token string, owner common.Address) (*big.Int, error) {
+	to := common.HexToAddress(token)
+	data := append(append([]byte{}, evmBalanceOfSelector...), common.LeftPadBytes(owner.Bytes(), 32)...)
+	result, err := client.CallContract(context.TODO(), ethereum.CallMsg{
+		To:   &to,
+		Data: data,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) < 32 {
+		return nil, fmt.Errorf("unexpected %d-byte balanceOf result", len(result))
+	}
+	return new(big.Int).SetBytes(result[0:32]), nil
+}
@@ -0,0 +1,24 @@
+package crypto
+
+import "testing"
+
+func TestEVMSearchFirstBlock(t *testing.T) {
+	calls := 0
+	firstSeen, err := evmSearchFirstBlock(1000, func(block uint64) (bool, error) {
+		calls++
+		return block >= 421, nil
+	})
+	if err != nil || firstSeen == nil || *firstSeen != 421 {
+		t.Errorf("unexpected first-seen block %v; %v", firstSeen, err)
+	}
+	if calls > 12 {
+		t.Errorf("expected logarithmic number of lookups; got %d", calls)
+	}
+
+	firstSeen, _ = evmSearchFirstBlock(1000, func(block uint64) (bool, error) {
+		return false, nil
+	})
+	if firstSeen != nil {
+		t.Error("expected nil first-seen block for inactive address")
+	}
+}
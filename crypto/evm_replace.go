@@ -0,0 +1,380 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// defaultEVMFeeBumpPercent is the minimum gas price bump accepted by the geth txpool for replacements
+const defaultEVMFeeBumpPercent = uint64(10)
+
+// evmCancelTxGasLimit is the gas limit of a zero-value self-transfer
+const evmCancelTxGasLimit = uint64(21000)
+
+// evmReceiptPollInterval is the interval at which receipts are polled by EVMAwaitTxReceipt
+var evmReceiptPollInterval = time.Second * 2
+
+// ErrEVMTxAlreadyMined is returned when attempting to replace a transaction which has been mined
+var ErrEVMTxAlreadyMined = errors.New("transaction has already been mined")
+
+// EVMReplacementOpts configure a replacement transaction
+type EVMReplacementOpts struct {
+	// FeeBumpPercent is the minimum percentage by which the gas price, or for dynamic fee transactions
+	// both the fee cap and the tip cap, of the replaced transaction are increased; defaults to 10, the
+	// minimum bump accepted by geth
+	FeeBumpPercent uint64
+
+	// GasPrice is used when it exceeds the bumped gas price, i.e. when the network gas price has risen;
+	// it applies to legacy and access list transactions
+	GasPrice *big.Int
+
+	// GasFeeCap is used when it exceeds the bumped fee cap of a dynamic fee transaction
+	GasFeeCap *big.Int
+
+	// GasTipCap is used when it exceeds the bumped tip cap of a dynamic fee transaction
+	GasTipCap *big.Int
+}
+
+// EVMSpeedUpTx replaces the given pending transaction with an otherwise-identical transaction, at the
+// same nonce and of the same type, having bumped fees; the replacement is signed using the given
+// EVMTxSigner, which must sign for the sender of the original transaction, and broadcast; the hash
+// of the replacement is returned
+func EVMSpeedUpTx(rpcClientKey, rpcURL, txHash string, txSigner EVMTxSigner, opts *EVMReplacementOpts) (string, error) {
+	return evmReplaceTx(rpcClientKey, rpcURL, txHash, txSigner, opts, false)
+}
+
+// EVMCancelTx replaces the given pending transaction with a zero-value transfer from the sender to
+// itself, at the same nonce and of the same type, having bumped fees; the replacement is signed using
+// the given EVMTxSigner, which must sign for the sender of the original transaction, and broadcast;
+// the hash of the replacement is returned
+func EVMCancelTx(rpcClientKey, rpcURL, txHash string, txSigner EVMTxSigner, opts *EVMReplacementOpts) (string, error) {
+	return evmReplaceTx(rpcClientKey, rpcURL, txHash, txSigner, opts, true)
+}
+
+func evmReplaceTx(rpcClientKey, rpcURL, txHash string, txSigner EVMTxSigner, opts *EVMReplacementOpts, cancel bool) (string, error) {
+	var tx *evmRPCTx
+	err := EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "eth_getTransactionByHash", []interface{}{txHash}, &tx, EVMDecodeStrict)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve tx %s; %s", txHash, err.Error())
+	}
+	if tx.BlockHash != nil {
+		return "", ErrEVMTxAlreadyMined
+	}
+
+	if opts == nil {
+		opts = &EVMReplacementOpts{}
+	}
+	replacement, err := tx.replacement(opts, cancel)
+	if err != nil {
+		return "", fmt.Errorf("failed to replace tx %s; %s", txHash, err.Error())
+	}
+
+	var signer types.Signer
+	if replacement.Type == evmTxTypeLegacy {
+		chainID, err := EVMGetChainID(rpcClientKey, rpcURL)
+		if err != nil {
+			return "", err
+		}
+		block, err := EVMGetLatestBlockNumber(rpcClientKey, rpcURL)
+		if err != nil {
+			return "", err
+		}
+		signer = types.MakeSigner(EVMChainConfigFactory(chainID), new(big.Int).SetUint64(block))
+	} else {
+		signer = &evmTypedTxSigner{tx: replacement}
+	}
+
+	prvdcommon.Log.Debugf("replacing type %d tx %s at nonce %d on behalf of %s", replacement.Type, txHash, replacement.Nonce, tx.From.Hex())
+	raw, err := replacement.sign(signer, txSigner, tx.From)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign replacement for tx %s; %s", txHash, err.Error())
+	}
+
+	var replacementHash string
+	err = EVMInvokeJsonRpcResult(rpcClientKey, rpcURL, "eth_sendRawTransaction", []interface{}{hexutil.Encode(raw)}, &replacementHash, EVMDecodeStrict)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast replacement for tx %s; %s", txHash, err.Error())
+	}
+	return replacementHash, nil
+}
+
+const (
+	evmTxTypeLegacy     = uint8(0)
+	evmTxTypeAccessList = uint8(1)
+	evmTxTypeDynamicFee = uint8(2)
+)
+
+// evmRPCTx is a transaction as returned by eth_getTransactionByHash, including typed (EIP-2718)
+// transactions which cannot be represented by types.Transaction
+type evmRPCTx struct {
+	Type                 hexutil.Uint64   `json:"type"`
+	BlockHash            *common.Hash     `json:"blockHash"`
+	ChainID              *hexutil.Big     `json:"chainId"`
+	From                 common.Address   `json:"from"`
+	Nonce                hexutil.Uint64   `json:"nonce"`
+	Gas                  hexutil.Uint64   `json:"gas"`
+	GasPrice             *hexutil.Big     `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big     `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big     `json:"maxPriorityFeePerGas"`
+	To                   *common.Address  `json:"to"`
+	Value                *hexutil.Big     `json:"value"`
+	Input                hexutil.Bytes    `json:"input"`
+	AccessList           []evmAccessTuple `json:"accessList"`
+}
+
+// evmAccessTuple is an EIP-2930 access list entry
+type evmAccessTuple struct {
+	Address     common.Address `json:"address"`
+	StorageKeys []common.Hash  `json:"storageKeys"`
+}
+
+// replacement returns the unsigned replacement of the transaction, preserving its type and, unless
+// the replacement is a cancellation, its access list
+func (tx *evmRPCTx) replacement(opts *EVMReplacementOpts, cancel bool) (*evmTypedTx, error) {
+	replacement := &evmTypedTx{
+		Type:       uint8(tx.Type),
+		Nonce:      uint64(tx.Nonce),
+		Gas:        uint64(tx.Gas),
+		To:         tx.To,
+		Value:      big.NewInt(0),
+		Data:       tx.Input,
+		AccessList: tx.AccessList,
+	}
+	if tx.Value != nil {
+		replacement.Value = tx.Value.ToInt()
+	}
+
+	switch replacement.Type {
+	case evmTxTypeLegacy, evmTxTypeAccessList:
+		if tx.GasPrice == nil {
+			return nil, errors.New("gas price not set")
+		}
+		replacement.GasFeeCap = evmBumpFee(tx.GasPrice.ToInt(), opts.FeeBumpPercent, opts.GasPrice)
+	case evmTxTypeDynamicFee:
+		if tx.MaxFeePerGas == nil || tx.MaxPriorityFeePerGas == nil {
+			return nil, errors.New("fee cap or tip cap not set")
+		}
+		replacement.GasFeeCap = evmBumpFee(tx.MaxFeePerGas.ToInt(), opts.FeeBumpPercent, opts.GasFeeCap)
+		replacement.GasTipCap = evmBumpFee(tx.MaxPriorityFeePerGas.ToInt(), opts.FeeBumpPercent, opts.GasTipCap)
+		if replacement.GasTipCap.Cmp(replacement.GasFeeCap) > 0 {
+			replacement.GasFeeCap = replacement.GasTipCap
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tx type: %d", replacement.Type)
+	}
+
+	if replacement.Type != evmTxTypeLegacy {
+		if tx.ChainID == nil {
+			return nil, errors.New("chain id not set")
+		}
+		replacement.ChainID = tx.ChainID.ToInt()
+	}
+
+	if cancel {
+		from := tx.From
+		replacement.To = &from
+		replacement.Value = big.NewInt(0)
+		replacement.Gas = evmCancelTxGasLimit
+		replacement.Data = nil
+		replacement.AccessList = nil
+	}
+
+	return replacement, nil
+}
+
+// evmBumpFee bumps the given fee by the given percentage, using the given override when it exceeds the bumped fee
+func evmBumpFee(fee *big.Int, percent uint64, override *big.Int) *big.Int {
+	bumped := EVMBumpGasPrice(fee, percent)
+	if override != nil && override.Cmp(bumped) > 0 {
+		return new(big.Int).Set(override)
+	}
+	return bumped
+}
+
+// evmTypedTx is an unsigned legacy, access list (EIP-2930) or dynamic fee (EIP-1559) transaction;
+// GasFeeCap is the gas price of legacy and access list transactions
+type evmTypedTx struct {
+	Type       uint8
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *common.Address
+	Value      *big.Int
+	Data       []byte
+	AccessList []evmAccessTuple
+}
+
+// carrier returns the legacy transaction passed to an EVMTxSigner; for typed transactions, it only
+// carries the signature, as the hash signed is that of the typed transaction
+func (tx *evmTypedTx) carrier() *types.Transaction {
+	if tx.To == nil {
+		return types.NewContractCreation(tx.Nonce, tx.Value, tx.Gas, tx.GasFeeCap, tx.Data)
+	}
+	return types.NewTransaction(tx.Nonce, *tx.To, tx.Value, tx.Gas, tx.GasFeeCap, tx.Data)
+}
+
+// fields returns the RLP payload fields of a typed transaction, followed by the given signature values
+func (tx *evmTypedTx) fields(sig ...*big.Int) []interface{} {
+	to := []byte{}
+	if tx.To != nil {
+		to = tx.To.Bytes()
+	}
+	accessList := tx.AccessList
+	if accessList == nil {
+		accessList = []evmAccessTuple{}
+	}
+
+	fields := []interface{}{tx.ChainID, tx.Nonce}
+	if tx.Type == evmTxTypeDynamicFee {
+		fields = append(fields, tx.GasTipCap)
+	}
+	fields = append(fields, tx.GasFeeCap, tx.Gas, to, tx.Value, tx.Data, accessList)
+	for _, val := range sig {
+		fields = append(fields, val)
+	}
+	return fields
+}
+
+// encode returns the EIP-2718 envelope of a typed transaction, i.e. type || rlp(fields)
+func (tx *evmTypedTx) encode(sig ...*big.Int) ([]byte, error) {
+	payload, err := rlp.EncodeToBytes(tx.fields(sig...))
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{tx.Type}, payload...), nil
+}
+
+// hash returns the signing hash of a typed transaction
+func (tx *evmTypedTx) hash() common.Hash {
+	envelope, _ := tx.encode()
+	return ethcrypto.Keccak256Hash(envelope)
+}
+
+// sign signs the transaction using the given EVMTxSigner, verifying the signature was made by the
+// given sender, and returns the raw signed transaction
+func (tx *evmTypedTx) sign(signer types.Signer, txSigner EVMTxSigner, from common.Address) ([]byte, error) {
+	signedTx, err := txSigner.SignTx(signer, tx.carrier())
+	if err != nil {
+		return nil, err
+	}
+
+	signedFrom, err := types.Sender(signer, signedTx)
+	if err != nil || signedFrom != from {
+		return nil, fmt.Errorf("signer is not the sender %s", from.Hex())
+	}
+
+	if tx.Type == evmTxTypeLegacy {
+		return rlp.EncodeToBytes(signedTx)
+	}
+	v, r, s := signedTx.RawSignatureValues()
+	return tx.encode(v, r, s)
+}
+
+// evmTypedTxSigner is a types.Signer for a single typed transaction, which go-ethereum cannot yet
+// represent; the hash signed is that of the typed transaction, and the recovery id is the y-parity
+type evmTypedTxSigner struct {
+	tx *evmTypedTx
+}
+
+// Sender recovers the sender of the typed transaction from the signature carried by the given transaction
+func (s *evmTypedTxSigner) Sender(tx *types.Transaction) (common.Address, error) {
+	v, r, sv := tx.RawSignatureValues()
+	if v.BitLen() > 1 || !ethcrypto.ValidateSignatureValues(byte(v.Uint64()), r, sv, true) {
+		return common.Address{}, types.ErrInvalidSig
+	}
+
+	sig := make([]byte, 65)
+	copy(sig[32-len(r.Bytes()):32], r.Bytes())
+	copy(sig[64-len(sv.Bytes()):64], sv.Bytes())
+	sig[64] = byte(v.Uint64())
+
+	pubkey, err := ethcrypto.SigToPub(s.tx.hash().Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return ethcrypto.PubkeyToAddress(*pubkey), nil
+}
+
+// SignatureValues returns the signature values of the given 65-byte [R || S || V] signature
+func (s *evmTypedTxSigner) SignatureValues(tx *types.Transaction, sig []byte) (r, sv, v *big.Int, err error) {
+	if len(sig) != 65 {
+		return nil, nil, nil, fmt.Errorf("wrong size for signature: got %d, want 65", len(sig))
+	}
+	recoveryID := sig[64]
+	if recoveryID >= 27 {
+		recoveryID -= 27
+	}
+	r = new(big.Int).SetBytes(sig[:32])
+	sv = new(big.Int).SetBytes(sig[32:64])
+	v = new(big.Int).SetUint64(uint64(recoveryID))
+	return r, sv, v, nil
+}
+
+// Hash returns the signing hash of the typed transaction
+func (s *evmTypedTxSigner) Hash(tx *types.Transaction) common.Hash {
+	return s.tx.hash()
+}
+
+// Equal returns true if the given signer signs the same typed transaction
+func (s *evmTypedTxSigner) Equal(signer types.Signer) bool {
+	other, ok := signer.(*evmTypedTxSigner)
+	return ok && other.tx == s.tx
+}
+
+// EVMBumpGasPrice returns the given gas price increased by the given percentage, rounded up; the
+// default bump of 10% is used when the percentage is 0
+func EVMBumpGasPrice(gasPrice *big.Int, percent uint64) *big.Int {
+	if percent == 0 {
+		percent = defaultEVMFeeBumpPercent
+	}
+	bumped := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// EVMAwaitTxReceipt polls for a receipt of any of the given transaction hashes until the given timeout
+// elapses; this allows the receipt of a transaction or any of its replacements to be awaited, as
+// whichever is mined first invalidates the others
+func EVMAwaitTxReceipt(rpcClientKey, rpcURL string, timeout time.Duration, txHashes ...string) (*types.Receipt, error) {
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(evmReceiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, txHash := range txHashes {
+			receipt, err := client.TransactionReceipt(ctx, common.HexToHash(txHash))
+			if err == nil && receipt != nil {
+				return receipt, nil
+			}
+			if err != nil && err != ethereum.NotFound && ctx.Err() == nil {
+				prvdcommon.Log.Debugf("failed to retrieve tx receipt for %s; %s", txHash, err.Error())
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to await tx receipt within %v", timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestEVMBumpGasPrice(t *testing.T) {
+	cases := []struct {
+		gasPrice int64
+		percent  uint64
+		expected int64
+	}{
+		{100, 0, 110},
+		{1000000000, 12, 1120000000},
+		{15, 10, 17}, // rounded up
+	}
+
+	for _, c := range cases {
+		bumped := EVMBumpGasPrice(big.NewInt(c.gasPrice), c.percent)
+		if bumped.Cmp(big.NewInt(c.expected)) != 0 {
+			t.Errorf("expected %d bumped by %d%% to be %d; got %s", c.gasPrice, c.percent, c.expected, bumped.String())
+		}
+	}
+}
+
+// evmTestKeySigner signs transactions using a local private key
+type evmTestKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *evmTestKeySigner) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, signer, s.key)
+}
+
+func TestEVMSpeedUpTxDynamicFee(t *testing.T) {
+	key, _ := ethcrypto.GenerateKey()
+	from := ethcrypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	accessList := []evmAccessTuple{{Address: to, StorageKeys: []common.Hash{{0x01}}}}
+
+	var raw hexutil.Bytes
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}       `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		var result interface{}
+		switch req.Method {
+		case "eth_getTransactionByHash":
+			result = map[string]interface{}{
+				"type":                 "0x2",
+				"blockHash":            nil,
+				"chainId":              "0x5",
+				"from":                 from.Hex(),
+				"nonce":                "0x7",
+				"gas":                  "0x15f90",
+				"maxFeePerGas":         "0x64",
+				"maxPriorityFeePerGas": "0xa",
+				"to":                   to.Hex(),
+				"value":                "0x1",
+				"input":                "0xabcd",
+				"accessList":           accessList,
+			}
+		case "eth_sendRawTransaction":
+			json.Unmarshal(req.Params[0], &raw)
+			result = common.Hash{0x02}.Hex()
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer srv.Close()
+
+	hash, err := EVMSpeedUpTx("replace-test", srv.URL, common.Hash{0x01}.Hex(), &evmTestKeySigner{key: key}, nil)
+	if err != nil {
+		t.Fatalf("failed to speed up tx; %s", err.Error())
+	}
+	if hash != (common.Hash{0x02}).Hex() {
+		t.Errorf("expected replacement hash to be returned; got %s", hash)
+	}
+
+	if len(raw) == 0 || raw[0] != evmTxTypeDynamicFee {
+		t.Fatalf("expected dynamic fee replacement; got %x", raw)
+	}
+	var decoded struct {
+		ChainID    *big.Int
+		Nonce      uint64
+		GasTipCap  *big.Int
+		GasFeeCap  *big.Int
+		Gas        uint64
+		To         common.Address
+		Value      *big.Int
+		Data       []byte
+		AccessList []evmAccessTuple
+		V, R, S    *big.Int
+	}
+	err = rlp.DecodeBytes(raw[1:], &decoded)
+	if err != nil {
+		t.Fatalf("failed to decode replacement; %s", err.Error())
+	}
+	if decoded.Nonce != 7 || decoded.GasTipCap.Int64() != 11 || decoded.GasFeeCap.Int64() != 110 {
+		t.Errorf("expected nonce 7 with bumped fees; got nonce %d, tip cap %s, fee cap %s", decoded.Nonce, decoded.GasTipCap, decoded.GasFeeCap)
+	}
+	if len(decoded.AccessList) != 1 || decoded.AccessList[0].Address != to || len(decoded.AccessList[0].StorageKeys) != 1 {
+		t.Errorf("expected access list to be preserved; got %v", decoded.AccessList)
+	}
+
+	replacement := &evmTypedTx{
+		Type:       evmTxTypeDynamicFee,
+		ChainID:    decoded.ChainID,
+		Nonce:      decoded.Nonce,
+		GasTipCap:  decoded.GasTipCap,
+		GasFeeCap:  decoded.GasFeeCap,
+		Gas:        decoded.Gas,
+		To:         &decoded.To,
+		Value:      decoded.Value,
+		Data:       decoded.Data,
+		AccessList: decoded.AccessList,
+	}
+	sig := make([]byte, 65)
+	copy(sig[32-len(decoded.R.Bytes()):32], decoded.R.Bytes())
+	copy(sig[64-len(decoded.S.Bytes()):64], decoded.S.Bytes())
+	sig[64] = byte(decoded.V.Uint64())
+	pubkey, err := ethcrypto.SigToPub(replacement.hash().Bytes(), sig)
+	if err != nil || ethcrypto.PubkeyToAddress(*pubkey) != from {
+		t.Errorf("expected replacement to be signed by %s", from.Hex())
+	}
+}
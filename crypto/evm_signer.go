@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// EVMTxSigner signs transactions without exposing private key material; it is implemented by the
+// vault-backed signer (vault.EVMSigner) and the hardware wallet signer (crypto/hardware)
+type EVMTxSigner interface {
+	SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error)
+}
+
+// EVMSignTxWithSigner builds a transaction using EVMTxFactory and signs it using the given
+// EVMTxSigner; it is otherwise identical to EVMSignTx
+func EVMSignTxWithSigner(
+	rpcClientKey,
+	rpcURL,
+	from string,
+	txSigner EVMTxSigner,
+	to,
+	data *string,
+	val *big.Int,
+	nonce *uint64,
+	gasLimit uint64,
+	gasPrice *uint64,
+) (*types.Transaction, *string, error) {
+	signer, tx, _, err := EVMTxFactory(
+		rpcClientKey,
+		rpcURL,
+		from,
+		to,
+		data,
+		val,
+		nonce,
+		gasLimit,
+		gasPrice,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build tx prior to signing; %s", err.Error())
+	}
+
+	prvdcommon.Log.Debugf("signing tx on behalf of %s", from)
+	signedTx, err := txSigner.SignTx(signer, tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign tx on behalf of %s; %s", from, err.Error())
+	}
+
+	return signedTx, prvdcommon.StringOrNil(fmt.Sprintf("0x%x", signedTx.Hash())), nil
+}
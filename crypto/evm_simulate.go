@@ -0,0 +1,204 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	api "github.com/provideplatform/provide-go/api/nchain"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+const evmSimulationBlock = "pending"
+const evmDefaultTracer = "callTracer"
+
+var evmRevertSelector = common.FromHex("0x08c379a0") // Error(string)
+var evmPanicSelector = common.FromHex("0x4e487b71")  // Panic(uint256)
+
+// EVMAccountOverride replaces the state of a single account for the duration of a call
+type EVMAccountOverride struct {
+	Balance   *hexutil.Big                `json:"balance,omitempty"`
+	Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+	Code      *hexutil.Bytes              `json:"code,omitempty"`
+	State     map[common.Hash]common.Hash `json:"state,omitempty"`     // replaces all storage
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"` // patches individual storage slots
+}
+
+// EVMStateOverrides maps account addresses to state overrides
+type EVMStateOverrides map[common.Address]*EVMAccountOverride
+
+// EVMSimulateOpts configures a transaction simulation
+type EVMSimulateOpts struct {
+	// Trace additionally runs the call through debug_traceCall
+	Trace bool
+
+	// Tracer is the debug_traceCall tracer; defaults to callTracer
+	Tracer string
+
+	// StateOverrides are applied to the eth_call, eth_estimateGas and debug_traceCall invocations
+	StateOverrides EVMStateOverrides
+}
+
+// EVMSimulationResult is the predicted outcome of a transaction
+type EVMSimulationResult struct {
+	Success      bool             `json:"success"`
+	GasUsed      uint64           `json:"gas_used,omitempty"`
+	ReturnData   hexutil.Bytes    `json:"return_data,omitempty"`
+	RevertReason *string          `json:"revert_reason,omitempty"`
+	Trace        *json.RawMessage `json:"trace,omitempty"`
+}
+
+// EVMSimulateTx dry-runs the described transaction at the pending block via eth_call and
+// eth_estimateGas to predict success, gas use and revert reason prior to signing and broadcast
+func EVMSimulateTx(
+	rpcClientKey,
+	rpcURL,
+	from string,
+	to,
+	data *string,
+	val *big.Int,
+	gasLimit uint64,
+	gasPrice *uint64,
+	opts *EVMSimulateOpts,
+) (*EVMSimulationResult, error) {
+	msg := map[string]interface{}{
+		"from": common.HexToAddress(from),
+	}
+	if to != nil {
+		msg["to"] = common.HexToAddress(*to)
+	}
+	if data != nil {
+		msg["data"] = hexutil.Bytes(common.FromHex(*data))
+	}
+	if val != nil {
+		msg["value"] = (*hexutil.Big)(val)
+	}
+	if gasLimit > 0 {
+		msg["gas"] = hexutil.Uint64(gasLimit)
+	}
+	if gasPrice != nil {
+		msg["gasPrice"] = hexutil.Uint64(*gasPrice)
+	}
+
+	return evmSimulateCallMsg(rpcClientKey, rpcURL, msg, opts)
+}
+
+// EVMSimulateTransaction dry-runs a constructed (i.e., by EVMTxFactory) transaction on behalf of `from`
+func EVMSimulateTransaction(rpcClientKey, rpcURL, from string, tx *types.Transaction, opts *EVMSimulateOpts) (*EVMSimulationResult, error) {
+	var to *string
+	if tx.To() != nil {
+		to = prvdcommon.StringOrNil(tx.To().Hex())
+	}
+	data := hexutil.Encode(tx.Data())
+	gasPrice := tx.GasPrice().Uint64()
+	return EVMSimulateTx(rpcClientKey, rpcURL, from, to, &data, tx.Value(), tx.Gas(), &gasPrice, opts)
+}
+
+func evmSimulateCallMsg(rpcClientKey, rpcURL string, msg map[string]interface{}, opts *EVMSimulateOpts) (*EVMSimulationResult, error) {
+	result := &EVMSimulationResult{}
+
+	callOpts := &EVMCallOpts{Block: evmSimulationBlock}
+	if opts != nil {
+		callOpts.StateOverrides = opts.StateOverrides
+	}
+	params := evmCallParams([]interface{}{msg}, callOpts)
+
+	var callResp = &api.EthereumJsonRpcResponse{}
+	err := EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_call", params, &callResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate tx via eth_call; %s", err.Error())
+	}
+
+	if callResp.Error != nil {
+		result.RevertReason = evmRevertReasonFromError(callResp.Error)
+	} else {
+		result.Success = true
+		if output, ok := callResp.Result.(string); ok {
+			result.ReturnData = common.FromHex(output)
+		}
+
+		var gasResp = &api.EthereumJsonRpcResponse{}
+		err = EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "eth_estimateGas", params, &gasResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas for simulated tx; %s", err.Error())
+		}
+		if gasResp.Error != nil && len(callOpts.StateOverrides) > 0 {
+			// not all nodes accept state overrides for eth_estimateGas; the eth_call outcome stands
+			prvdcommon.Log.Debugf("failed to estimate gas for simulated tx with state overrides; %s", gasResp.Error.Message)
+		} else if gasResp.Error != nil {
+			result.Success = false
+			result.RevertReason = evmRevertReasonFromError(gasResp.Error)
+		} else if gas, ok := gasResp.Result.(string); ok {
+			result.GasUsed, _ = hexutil.DecodeUint64(gas)
+		}
+	}
+
+	if opts != nil && opts.Trace {
+		tracer := opts.Tracer
+		if tracer == "" {
+			tracer = evmDefaultTracer
+		}
+		traceCfg := map[string]interface{}{
+			"tracer": tracer,
+		}
+		if len(opts.StateOverrides) > 0 {
+			traceCfg["stateOverrides"] = opts.StateOverrides
+		}
+
+		var traceResp struct {
+			Result *json.RawMessage                  `json:"result"`
+			Error  *api.EthereumJsonRpcResponseError `json:"error,omitempty"`
+		}
+		err = EVMInvokeJsonRpcClient(rpcClientKey, rpcURL, "debug_traceCall", []interface{}{msg, evmSimulationBlock, traceCfg}, &traceResp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace simulated tx via debug_traceCall; %s", err.Error())
+		}
+		if traceResp.Error != nil {
+			return nil, fmt.Errorf("failed to trace simulated tx via debug_traceCall; %s", traceResp.Error.Message)
+		}
+		result.Trace = traceResp.Result
+	}
+
+	prvdcommon.Log.Debugf("simulated tx; success: %v; gas: %d", result.Success, result.GasUsed)
+	return result, nil
+}
+
+// EVMDecodeRevertReason decodes Error(string) and Panic(uint256) revert data
+func EVMDecodeRevertReason(data []byte) (string, bool) {
+	if len(data) < 4 {
+		return "", false
+	}
+
+	switch {
+	case bytes.Equal(data[0:4], evmRevertSelector):
+		typ, _ := abi.NewType("string", "", nil)
+		values, err := abi.Arguments{{Type: typ}}.UnpackValues(data[4:])
+		if err != nil || len(values) == 0 {
+			return "", false
+		}
+		return values[0].(string), true
+	case bytes.Equal(data[0:4], evmPanicSelector):
+		typ, _ := abi.NewType("uint256", "", nil)
+		values, err := abi.Arguments{{Type: typ}}.UnpackValues(data[4:])
+		if err != nil || len(values) == 0 {
+			return "", false
+		}
+		return fmt.Sprintf("panic: 0x%x", values[0].(*big.Int)), true
+	}
+
+	return "", false
+}
+
+func evmRevertReasonFromError(rpcErr *api.EthereumJsonRpcResponseError) *string {
+	if data, ok := rpcErr.Data.(string); ok {
+		if reason, ok := EVMDecodeRevertReason(common.FromHex(data)); ok {
+			return &reason
+		}
+	}
+	return prvdcommon.StringOrNil(rpcErr.Message)
+}
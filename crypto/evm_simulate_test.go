@@ -0,0 +1,60 @@
+package crypto
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEVMDecodeRevertReason(t *testing.T) {
+	data := common.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000012" +
+		"696e73756666696369656e742066756e64730000000000000000000000000000")
+
+	reason, ok := EVMDecodeRevertReason(data)
+	if !ok || reason != "insufficient funds" {
+		t.Errorf("unexpected revert reason %q", reason)
+	}
+
+	panicData := common.FromHex("0x4e487b71" +
+		"0000000000000000000000000000000000000000000000000000000000000011")
+	reason, ok = EVMDecodeRevertReason(panicData)
+	if !ok || reason != "panic: 0x11" {
+		t.Errorf("unexpected panic reason %q", reason)
+	}
+
+	if _, ok := EVMDecodeRevertReason([]byte{0x01}); ok {
+		t.Error("expected short revert data not to decode")
+	}
+}
+
+func TestEVMCallParamsWithStateOverrides(t *testing.T) {
+	token := "0x5fbdb2315678afecb367f032d93f642f64180aa3"
+	owner := "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"
+
+	overrides := NewEVMStateOverrides().
+		SetBalance(owner, big.NewInt(1)).
+		SetStorage(token, EVMMappingSlot(EVMAddressKey(owner), 0), common.BigToHash(big.NewInt(1000)))
+
+	params := evmCallParams([]interface{}{map[string]interface{}{"to": token}}, &EVMCallOpts{StateOverrides: overrides})
+	if len(params) != 3 || params[1] != "latest" {
+		t.Fatalf("unexpected eth_call params %v", params)
+	}
+
+	raw, _ := json.Marshal(params[2])
+	var decoded map[string]map[string]interface{}
+	json.Unmarshal(raw, &decoded)
+	if decoded[owner]["balance"] != "0x1" {
+		t.Errorf("unexpected balance override %s", raw)
+	}
+	if len(decoded[token]["stateDiff"].(map[string]interface{})) != 1 {
+		t.Errorf("unexpected storage override %s", raw)
+	}
+
+	if len(evmCallParams([]interface{}{"msg", "0x1"}, nil)) != 2 {
+		t.Error("expected params to be untouched without opts")
+	}
+}
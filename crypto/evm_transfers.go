@@ -0,0 +1,168 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+const defaultTokenTransferScanChunkSize = uint64(2000)
+
+const (
+	// TokenStandardERC20 is the ERC-20 fungible token standard
+	TokenStandardERC20 = "ERC20"
+
+	// TokenStandardERC721 is the ERC-721 non-fungible token standard
+	TokenStandardERC721 = "ERC721"
+)
+
+// evmTransferTopic is the topic of Transfer(address,address,uint256), shared by ERC-20 and ERC-721
+var evmTransferTopic = common.BytesToHash(Keccak256("Transfer(address,address,uint256)"))
+
+// EVMTokenTransfer is a decoded ERC-20 or ERC-721 Transfer event
+type EVMTokenTransfer struct {
+	Standard    string         `json:"standard"`
+	Token       common.Address `json:"token"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	Value       *big.Int       `json:"value,omitempty"`    // ERC-20 amount
+	TokenID     *big.Int       `json:"token_id,omitempty"` // ERC-721 token id
+	BlockNumber uint64         `json:"block_number"`
+	TxHash      common.Hash    `json:"tx_hash"`
+	LogIndex    uint           `json:"log_index"`
+}
+
+// EVMTokenTransferScanOpts configures a token transfer scan
+type EVMTokenTransferScanOpts struct {
+	// ChunkSize is the number of blocks scanned per eth_getLogs request; defaults to 2000
+	ChunkSize uint64
+
+	// Tokens restricts the scan to the given token contract addresses
+	Tokens []string
+
+	// Progress is invoked after each chunk with the last scanned block and the number of transfers found so far
+	Progress func(scannedTo, toBlock uint64, found int)
+}
+
+// EVMGetTokenTransfers scans the Transfer event logs of ERC-20 and ERC-721 tokens sent or received by
+// the given address within the given block range; large ranges are scanned in chunks
+func EVMGetTokenTransfers(rpcClientKey, rpcURL, addr string, fromBlock, toBlock uint64, opts *EVMTokenTransferScanOpts) ([]*EVMTokenTransfer, error) {
+	if toBlock < fromBlock {
+		return nil, fmt.Errorf("failed to scan token transfers; invalid block range %d-%d", fromBlock, toBlock)
+	}
+
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &EVMTokenTransferScanOpts{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = defaultTokenTransferScanChunkSize
+	}
+
+	tokens := make([]common.Address, 0, len(opts.Tokens))
+	for _, token := range opts.Tokens {
+		tokens = append(tokens, common.HexToAddress(token))
+	}
+
+	addrTopic := common.BytesToHash(common.HexToAddress(addr).Bytes())
+	topicSets := [][][]common.Hash{
+		{{evmTransferTopic}, {addrTopic}},      // sent
+		{{evmTransferTopic}, nil, {addrTopic}}, // received
+	}
+
+	seen := map[string]bool{}
+	transfers := make([]*EVMTokenTransfer, 0)
+
+	for start := fromBlock; start <= toBlock; start += chunkSize {
+		end := start + chunkSize - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+
+		for _, topics := range topicSets {
+			logs, err := client.FilterLogs(context.TODO(), ethereum.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(start),
+				ToBlock:   new(big.Int).SetUint64(end),
+				Addresses: tokens,
+				Topics:    topics,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to scan token transfers in blocks %d-%d; %s", start, end, err.Error())
+			}
+
+			for i := range logs {
+				key := fmt.Sprintf("%s:%d", logs[i].TxHash.Hex(), logs[i].Index)
+				if seen[key] {
+					continue // self-transfers match both topic sets
+				}
+				transfer := decodeEVMTokenTransfer(&logs[i])
+				if transfer != nil {
+					seen[key] = true
+					transfers = append(transfers, transfer)
+				}
+			}
+		}
+
+		prvdcommon.Log.Debugf("scanned blocks %d-%d for token transfers involving %s; %d found", start, end, addr, len(transfers))
+		if opts.Progress != nil {
+			opts.Progress(end, toBlock, len(transfers))
+		}
+
+		if end == toBlock {
+			break
+		}
+	}
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if transfers[i].BlockNumber != transfers[j].BlockNumber {
+			return transfers[i].BlockNumber < transfers[j].BlockNumber
+		}
+		return transfers[i].LogIndex < transfers[j].LogIndex
+	})
+
+	return transfers, nil
+}
+
+// decodeEVMTokenTransfer decodes a Transfer log; ERC-721 transfers index the token id, so the
+// standard is distinguished by the number of topics
+func decodeEVMTokenTransfer(log *types.Log) *EVMTokenTransfer {
+	if len(log.Topics) < 3 || log.Topics[0] != evmTransferTopic {
+		return nil
+	}
+
+	transfer := &EVMTokenTransfer{
+		Token:       log.Address,
+		From:        common.BytesToAddress(log.Topics[1].Bytes()),
+		To:          common.BytesToAddress(log.Topics[2].Bytes()),
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+	}
+
+	switch len(log.Topics) {
+	case 3:
+		if len(log.Data) < 32 {
+			return nil
+		}
+		transfer.Standard = TokenStandardERC20
+		transfer.Value = new(big.Int).SetBytes(log.Data[0:32])
+	case 4:
+		transfer.Standard = TokenStandardERC721
+		transfer.TokenID = log.Topics[3].Big()
+	default:
+		return nil
+	}
+
+	return transfer
+}
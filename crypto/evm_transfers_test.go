@@ -0,0 +1,33 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDecodeEVMTokenTransfer(t *testing.T) {
+	from := common.HexToAddress("0x01")
+	to := common.HexToAddress("0x02")
+
+	erc20 := decodeEVMTokenTransfer(&types.Log{
+		Topics: []common.Hash{evmTransferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:   common.BigToHash(big.NewInt(1000)).Bytes(),
+	})
+	if erc20 == nil || erc20.Standard != TokenStandardERC20 || erc20.Value.Int64() != 1000 || erc20.From != from || erc20.To != to {
+		t.Errorf("unexpected ERC20 transfer %v", erc20)
+	}
+
+	erc721 := decodeEVMTokenTransfer(&types.Log{
+		Topics: []common.Hash{evmTransferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes()), common.BigToHash(big.NewInt(7))},
+	})
+	if erc721 == nil || erc721.Standard != TokenStandardERC721 || erc721.TokenID.Int64() != 7 {
+		t.Errorf("unexpected ERC721 transfer %v", erc721)
+	}
+
+	if decodeEVMTokenTransfer(&types.Log{Topics: []common.Hash{common.HexToHash("0x01")}}) != nil {
+		t.Error("expected non-transfer log not to decode")
+	}
+}
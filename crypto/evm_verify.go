@@ -0,0 +1,179 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const evmStandardJSONLanguageSolidity = "Solidity"
+
+// EVMSource is the content of a single source file
+type EVMSource struct {
+	Content string `json:"content"`
+}
+
+// EVMOptimizerSettings are the solc optimizer settings
+type EVMOptimizerSettings struct {
+	Enabled bool `json:"enabled"`
+	Runs    int  `json:"runs,omitempty"`
+}
+
+// EVMCompilerSettings are the solc standard-json compiler settings
+type EVMCompilerSettings struct {
+	Optimizer       *EVMOptimizerSettings          `json:"optimizer,omitempty"`
+	EVMVersion      string                         `json:"evmVersion,omitempty"`
+	Remappings      []string                       `json:"remappings,omitempty"`
+	Libraries       map[string]map[string]string   `json:"libraries,omitempty"`
+	Metadata        map[string]interface{}         `json:"metadata,omitempty"`
+	OutputSelection map[string]map[string][]string `json:"outputSelection,omitempty"`
+}
+
+// EVMStandardJSONInput is the solc standard-json input; it is both the input used to compile
+// and the canonical payload for source verification
+type EVMStandardJSONInput struct {
+	Language string                `json:"language"`
+	Sources  map[string]*EVMSource `json:"sources"`
+	Settings *EVMCompilerSettings  `json:"settings,omitempty"`
+}
+
+// NewEVMStandardJSONInput initializes a solidity standard-json input for the given sources,
+// keyed by path
+func NewEVMStandardJSONInput(sources map[string]string, settings *EVMCompilerSettings) *EVMStandardJSONInput {
+	input := &EVMStandardJSONInput{
+		Language: evmStandardJSONLanguageSolidity,
+		Sources:  map[string]*EVMSource{},
+		Settings: settings,
+	}
+	for path, content := range sources {
+		input.Sources[path] = &EVMSource{Content: content}
+	}
+	return input
+}
+
+// EVMVerificationParams describe a deployed contract to be verified
+type EVMVerificationParams struct {
+	Address          string
+	DeploymentTxHash string
+	ContractName     string // fully-qualified, i.e. contracts/Token.sol:Token
+	CompilerVersion  string // i.e. v0.8.19+commit.7dd6d404
+	CreationBytecode string // compiled creation bytecode, excluding constructor arguments
+	ABI              interface{}
+	Input            *EVMStandardJSONInput
+}
+
+// EVMVerificationPayload is a source verification request for Sourcify/Etherscan-compatible APIs
+type EVMVerificationPayload struct {
+	Address                     common.Address        `json:"address"`
+	ChainID                     string                `json:"chain_id"`
+	ContractName                string                `json:"contract_name"`
+	CompilerVersion             string                `json:"compiler_version"`
+	Input                       *EVMStandardJSONInput `json:"input"`
+	ConstructorArguments        hexutil.Bytes         `json:"constructor_arguments,omitempty"`
+	DecodedConstructorArguments []interface{}         `json:"decoded_constructor_arguments,omitempty"`
+}
+
+// EVMBuildVerificationPayload assembles a verification payload for the given deployed contract; the
+// constructor arguments are recovered from the deployment transaction input
+func EVMBuildVerificationPayload(rpcClientKey, rpcURL string, params *EVMVerificationParams) (*EVMVerificationPayload, error) {
+	if params.Input == nil {
+		return nil, fmt.Errorf("failed to build verification payload for %s; no standard-json input", params.Address)
+	}
+
+	client, err := EVMDialJsonRpc(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, err := EVMGetChainID(rpcClientKey, rpcURL)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &EVMVerificationPayload{
+		Address:         common.HexToAddress(params.Address),
+		ChainID:         chainID.String(),
+		ContractName:    params.ContractName,
+		CompilerVersion: params.CompilerVersion,
+		Input:           params.Input,
+	}
+
+	if params.DeploymentTxHash != "" {
+		tx, _, err := client.TransactionByHash(context.TODO(), common.HexToHash(params.DeploymentTxHash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve deployment tx %s; %s", params.DeploymentTxHash, err.Error())
+		}
+
+		payload.ConstructorArguments, err = EVMExtractConstructorArgs(tx.Data(), common.FromHex(params.CreationBytecode))
+		if err != nil {
+			return nil, err
+		}
+
+		if params.ABI != nil && len(payload.ConstructorArguments) > 0 {
+			contractABI, err := parseContractABI(params.ABI)
+			if err != nil {
+				return nil, err
+			}
+			payload.DecodedConstructorArguments, err = contractABI.Constructor.Inputs.UnpackValues(payload.ConstructorArguments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode constructor arguments; %s", err.Error())
+			}
+		}
+	}
+
+	return payload, nil
+}
+
+// EVMExtractConstructorArgs returns the ABI-encoded constructor arguments appended to the creation
+// bytecode in the given deployment tx input; compiler metadata is permitted to differ
+func EVMExtractConstructorArgs(txInput, creationBytecode []byte) ([]byte, error) {
+	if len(txInput) < len(creationBytecode) {
+		return nil, fmt.Errorf("failed to extract constructor arguments; deployment input is shorter than the creation bytecode")
+	}
+
+	prefix := txInput[0:len(creationBytecode)]
+	if !bytes.Equal(prefix, creationBytecode) && !EVMBytecodeEqual(prefix, creationBytecode) {
+		return nil, fmt.Errorf("failed to extract constructor arguments; deployment input does not match the creation bytecode")
+	}
+	return txInput[len(creationBytecode):], nil
+}
+
+// EtherscanParams returns the form parameters for the Etherscan-compatible verifysourcecode API
+func (p *EVMVerificationPayload) EtherscanParams(apiKey string) (url.Values, error) {
+	input, err := json.Marshal(p.Input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal standard-json input; %s", err.Error())
+	}
+
+	params := url.Values{}
+	params.Set("apikey", apiKey)
+	params.Set("module", "contract")
+	params.Set("action", "verifysourcecode")
+	params.Set("chainId", p.ChainID)
+	params.Set("contractaddress", p.Address.Hex())
+	params.Set("sourceCode", string(input))
+	params.Set("codeformat", "solidity-standard-json-input")
+	params.Set("contractname", p.ContractName)
+	params.Set("compilerversion", p.CompilerVersion)
+	params.Set("constructorArguements", strings.TrimPrefix(p.ConstructorArguments.String(), "0x")) // sic
+	return params, nil
+}
+
+// SourcifyParams returns the request body for the Sourcify standard-json verification API
+func (p *EVMVerificationPayload) SourcifyParams() map[string]interface{} {
+	return map[string]interface{}{
+		"address":         p.Address.Hex(),
+		"chain":           p.ChainID,
+		"compilerVersion": strings.TrimPrefix(p.CompilerVersion, "v"),
+		"contractName":    p.ContractName[strings.LastIndex(p.ContractName, ":")+1:],
+		"files": map[string]interface{}{
+			"input.json": p.Input,
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEVMExtractConstructorArgs(t *testing.T) {
+	creation := common.FromHex("0x6080604052" + "a2646970667358221220aa" + "000b")
+	args := common.BigToHash(common.Big1).Bytes()
+
+	// the deployed creation bytecode was compiled with different metadata
+	deployed := append(common.FromHex("0x6080604052"+"a2646970667358221220bb"+"000b"), args...)
+
+	extracted, err := EVMExtractConstructorArgs(deployed, creation)
+	if err != nil {
+		t.Fatalf("failed to extract constructor args; %s", err.Error())
+	}
+	if common.BytesToHash(extracted) != common.BigToHash(common.Big1) {
+		t.Errorf("unexpected constructor args %x", extracted)
+	}
+
+	if _, err := EVMExtractConstructorArgs(common.FromHex("0x6000"), creation); err == nil {
+		t.Error("expected error extracting args from mismatched deployment input")
+	}
+}
+
+func TestEVMVerificationPayloadParams(t *testing.T) {
+	payload := &EVMVerificationPayload{
+		Address:              common.HexToAddress("0x01"),
+		ChainID:              "5",
+		ContractName:         "contracts/Token.sol:Token",
+		CompilerVersion:      "v0.8.19+commit.7dd6d404",
+		Input:                NewEVMStandardJSONInput(map[string]string{"contracts/Token.sol": "contract Token {}"}, nil),
+		ConstructorArguments: common.FromHex("0x2a"),
+	}
+
+	params, err := payload.EtherscanParams("key")
+	if err != nil {
+		t.Fatalf("failed to build etherscan params; %s", err.Error())
+	}
+	if params.Get("constructorArguements") != "2a" || params.Get("codeformat") != "solidity-standard-json-input" {
+		t.Errorf("unexpected etherscan params %v", params)
+	}
+
+	sourcify := payload.SourcifyParams()
+	if sourcify["contractName"] != "Token" || sourcify["compilerVersion"] != "0.8.19+commit.7dd6d404" {
+		t.Errorf("unexpected sourcify params %v", sourcify)
+	}
+}
@@ -0,0 +1,173 @@
+package hardware
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+const (
+	// DeviceLedger is a Ledger hardware wallet, connected via USB HID
+	DeviceLedger = "ledger"
+
+	// DeviceTrezor is a Trezor hardware wallet, connected via USB HID
+	DeviceTrezor = "trezor"
+)
+
+// DefaultDerivationPath is the first account of the default ethereum derivation path
+const DefaultDerivationPath = "m/44'/60'/0'/0/0"
+
+var _ crypto.EVMTxSigner = (*Signer)(nil)
+
+// SignerOptions configure a hardware wallet signer
+type SignerOptions struct {
+	// Device is the hardware wallet type; one of ledger or trezor
+	Device string
+
+	// DerivationPath of the signing account; defaults to m/44'/60'/0'/0/0
+	DerivationPath string
+
+	// ChainID is the EIP-155 chain id signed by the device
+	ChainID *big.Int
+
+	// PIN is invoked when a trezor requires a PIN to be entered, using the scrambled matrix displayed
+	// on the device, or a passphrase; it is not used for ledger devices, which are unlocked on-device
+	PIN func(passphrase bool) (string, error)
+}
+
+// Signer signs EVM transactions using an account derived on a hardware wallet; the private key never
+// leaves the device, and each transaction must be confirmed on-device
+type Signer struct {
+	Address common.Address
+
+	account accounts.Account
+	chainID *big.Int
+	wallet  accounts.Wallet
+}
+
+// NewSigner opens the first connected hardware wallet of the given device type and derives the
+// signing account
+func NewSigner(opts *SignerOptions) (*Signer, error) {
+	if opts == nil {
+		return nil, errors.New("failed to initialize hardware wallet signer; options are required")
+	}
+
+	var hub *usbwallet.Hub
+	var err error
+	switch opts.Device {
+	case DeviceLedger:
+		hub, err = usbwallet.NewLedgerHub()
+	case DeviceTrezor:
+		hub, err = usbwallet.NewTrezorHubWithHID()
+	default:
+		return nil, fmt.Errorf("failed to initialize hardware wallet signer; unsupported device %s", opts.Device)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize %s hub; %s", opts.Device, err.Error())
+	}
+
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("failed to initialize hardware wallet signer; no %s device connected", opts.Device)
+	}
+	wallet := wallets[0]
+
+	err = openWallet(wallet, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	derivationPath := opts.DerivationPath
+	if derivationPath == "" {
+		derivationPath = DefaultDerivationPath
+	}
+	path, err := accounts.ParseDerivationPath(derivationPath)
+	if err != nil {
+		wallet.Close()
+		return nil, fmt.Errorf("failed to parse derivation path %s; %s", derivationPath, err.Error())
+	}
+
+	account, err := wallet.Derive(path, true)
+	if err != nil {
+		wallet.Close()
+		return nil, fmt.Errorf("failed to derive %s account at %s; %s", opts.Device, derivationPath, err.Error())
+	}
+
+	prvdcommon.Log.Debugf("derived %s account %s at %s", opts.Device, account.Address.Hex(), derivationPath)
+	return &Signer{
+		Address: account.Address,
+		account: account,
+		chainID: opts.ChainID,
+		wallet:  wallet,
+	}, nil
+}
+
+// openWallet opens the wallet, prompting for a trezor PIN and passphrase, as required
+func openWallet(wallet accounts.Wallet, opts *SignerOptions) error {
+	err := wallet.Open("")
+	for err == usbwallet.ErrTrezorPINNeeded || err == usbwallet.ErrTrezorPassphraseNeeded {
+		if opts.PIN == nil {
+			return fmt.Errorf("failed to open %s; %s", opts.Device, err.Error())
+		}
+		secret, pinErr := opts.PIN(err == usbwallet.ErrTrezorPassphraseNeeded)
+		if pinErr != nil {
+			return fmt.Errorf("failed to open %s; %s", opts.Device, pinErr.Error())
+		}
+		err = wallet.Open(secret)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open %s; %s", opts.Device, err.Error())
+	}
+	return nil
+}
+
+// SignTx signs the given transaction on the device; the sender of the signed transaction is
+// verified using the given signer (i.e., as returned by crypto.EVMTxFactory) to guard against
+// a chain id mismatch
+func (s *Signer) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	signedTx, err := s.wallet.SignTx(s.account, tx, s.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx on hardware wallet; %s", err.Error())
+	}
+
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify hardware wallet signature; %s", err.Error())
+	}
+	if sender != s.Address {
+		return nil, fmt.Errorf("failed to verify hardware wallet signature; recovered sender %s does not match %s", sender.Hex(), s.Address.Hex())
+	}
+
+	return signedTx, nil
+}
+
+// SignerFn returns a bind.SignerFn suitable for use with go-ethereum contract bindings
+func (s *Signer) SignerFn() bind.SignerFn {
+	return func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != s.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		return s.SignTx(signer, tx)
+	}
+}
+
+// TransactOpts returns bind.TransactOpts which sign transactions using the hardware wallet
+func (s *Signer) TransactOpts() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:   s.Address,
+		Signer: s.SignerFn(),
+	}
+}
+
+// Close releases the device
+func (s *Signer) Close() error {
+	return s.wallet.Close()
+}
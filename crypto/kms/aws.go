@@ -0,0 +1,225 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const awsKMSService = "kms"
+const awsKMSSigningAlgorithm = "ECDSA_SHA_256"
+
+// AWSCredentials are the credentials used to sign AWS KMS requests
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWSCredentialsFromEnv returns the AWS credentials configured in the environment
+func AWSCredentialsFromEnv() *AWSCredentials {
+	return &AWSCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// AWSBackend is an AWS KMS ECC_SECG_P256K1 signing key
+type AWSBackend struct {
+	KeyID       string
+	Region      string
+	Credentials *AWSCredentials
+
+	// Endpoint overrides the regional KMS endpoint, i.e. for VPC endpoints or local testing
+	Endpoint string
+
+	client *http.Client
+}
+
+// NewAWSBackend initializes an AWS KMS backend for the given key id or ARN; credentials are read
+// from the environment when none are given
+func NewAWSBackend(keyID, region string, credentials *AWSCredentials) (*AWSBackend, error) {
+	if credentials == nil {
+		credentials = AWSCredentialsFromEnv()
+	}
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return nil, errors.New("failed to initialize AWS KMS backend; no credentials")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		return nil, errors.New("failed to initialize AWS KMS backend; no region")
+	}
+
+	return &AWSBackend{
+		KeyID:       keyID,
+		Region:      region,
+		Credentials: credentials,
+		client:      &http.Client{Timeout: time.Second * 30},
+	}, nil
+}
+
+// PublicKey returns the DER-encoded SubjectPublicKeyInfo of the key
+func (b *AWSBackend) PublicKey(ctx context.Context) ([]byte, error) {
+	var resp struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	err := b.invoke(ctx, "GetPublicKey", map[string]interface{}{
+		"KeyId": b.KeyID,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.PublicKey, nil
+}
+
+// Sign signs the given 32-byte digest, returning a DER-encoded ECDSA signature
+func (b *AWSBackend) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	var resp struct {
+		Signature []byte `json:"Signature"`
+	}
+	err := b.invoke(ctx, "Sign", map[string]interface{}{
+		"KeyId":            b.KeyID,
+		"Message":          base64.StdEncoding.EncodeToString(digest),
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": awsKMSSigningAlgorithm,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+func (b *AWSBackend) endpoint() string {
+	if b.Endpoint != "" {
+		return b.Endpoint
+	}
+	return fmt.Sprintf("https://kms.%s.amazonaws.com/", b.Region)
+}
+
+// invoke invokes the given AWS KMS JSON API action; []byte response fields are base64-decoded
+func (b *AWSBackend) invoke(ctx context.Context, action string, params map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal AWS KMS %s request; %s", action, err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", fmt.Sprintf("TrentService.%s", action))
+	b.signRequest(req, body, time.Now().UTC())
+
+	client := b.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to invoke AWS KMS %s; %s", action, err.Error())
+	}
+	defer resp.Body.Close()
+
+	raw, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to invoke AWS KMS %s; status: %v; %s", action, resp.StatusCode, string(raw))
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal AWS KMS %s response; %s", action, err.Error())
+	}
+	return nil
+}
+
+// signRequest signs the given request using AWS signature version 4
+func (b *AWSBackend) signRequest(req *http.Request, body []byte, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if b.Credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.Credentials.SessionToken)
+	}
+
+	headers := []string{"content-type", "host", "x-amz-date", "x-amz-target"}
+	if b.Credentials.SessionToken != "" {
+		headers = append(headers, "x-amz-security-token")
+	}
+	sort.Strings(headers)
+
+	canonicalHeaders := ""
+	for _, header := range headers {
+		val := req.Header.Get(header)
+		if header == "host" {
+			val = req.URL.Host
+		}
+		canonicalHeaders += fmt.Sprintf("%s:%s\n", header, strings.TrimSpace(val))
+	}
+	signedHeaders := strings.Join(headers, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, b.Region, awsKMSService)
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte(fmt.Sprintf("AWS4%s", b.Credentials.SecretAccessKey)), []byte(date))
+	key = hmacSHA256(key, []byte(b.Region))
+	key = hmacSHA256(key, []byte(awsKMSService))
+	key = hmacSHA256(key, []byte("aws4_request"))
+	signature := hex.EncodeToString(hmacSHA256(key, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		b.Credentials.AccessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+}
+
+func canonicalQuery(query url.Values) string {
+	// url.Values.Encode sorts by key
+	return strings.Replace(query.Encode(), "+", "%20", -1)
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,131 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const gcpKMSBaseURL = "https://cloudkms.googleapis.com/v1"
+
+// GCPBackend is a Google Cloud KMS EC_SIGN_SECP256K1_SHA256 key version
+type GCPBackend struct {
+	// KeyVersion is the resource name of the key version, i.e.
+	// projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1
+	KeyVersion string
+
+	// TokenSource returns an OAuth2 access token authorized for cloudkms
+	TokenSource func(ctx context.Context) (string, error)
+
+	// BaseURL overrides the Cloud KMS API base URL, i.e. for private service connect or local testing
+	BaseURL string
+
+	client *http.Client
+}
+
+// NewGCPBackend initializes a Google Cloud KMS backend for the given key version resource name
+func NewGCPBackend(keyVersion string, tokenSource func(ctx context.Context) (string, error)) (*GCPBackend, error) {
+	if tokenSource == nil {
+		return nil, errors.New("failed to initialize GCP KMS backend; no token source")
+	}
+	return &GCPBackend{
+		KeyVersion:  keyVersion,
+		TokenSource: tokenSource,
+		client:      &http.Client{Timeout: time.Second * 30},
+	}, nil
+}
+
+// PublicKey returns the DER-encoded SubjectPublicKeyInfo of the key version
+func (b *GCPBackend) PublicKey(ctx context.Context) ([]byte, error) {
+	var resp struct {
+		PEM string `json:"pem"`
+	}
+	err := b.invoke(ctx, http.MethodGet, fmt.Sprintf("%s/publicKey", b.KeyVersion), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode([]byte(resp.PEM))
+	if block == nil {
+		return nil, errors.New("failed to decode GCP KMS public key PEM")
+	}
+	return block.Bytes, nil
+}
+
+// Sign signs the given 32-byte digest, returning a DER-encoded ECDSA signature; the digest is
+// passed as-is, which allows keccak256 digests to be signed by a SHA-256 key
+func (b *GCPBackend) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	var resp struct {
+		Signature []byte `json:"signature"`
+	}
+	err := b.invoke(ctx, http.MethodPost, fmt.Sprintf("%s:asymmetricSign", b.KeyVersion), map[string]interface{}{
+		"digest": map[string]interface{}{
+			"sha256": digest,
+		},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+// invoke invokes the Cloud KMS REST API; []byte fields are base64-encoded
+func (b *GCPBackend) invoke(ctx context.Context, method, path string, params interface{}, result interface{}) error {
+	var body *bytes.Reader
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("failed to marshal GCP KMS request; %s", err.Error())
+		}
+		body = bytes.NewReader(raw)
+	} else {
+		body = bytes.NewReader([]byte{})
+	}
+
+	baseURL := b.BaseURL
+	if baseURL == "" {
+		baseURL = gcpKMSBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/%s", strings.TrimSuffix(baseURL, "/"), path), body)
+	if err != nil {
+		return err
+	}
+
+	token, err := b.TokenSource(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve GCP access token; %s", err.Error())
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	if params != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := b.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to invoke GCP KMS %s; %s", path, err.Error())
+	}
+	defer resp.Body.Close()
+
+	raw, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to invoke GCP KMS %s; status: %v; %s", path, resp.StatusCode, string(raw))
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal GCP KMS %s response; %s", path, err.Error())
+	}
+	return nil
+}
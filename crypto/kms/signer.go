@@ -0,0 +1,163 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/provideplatform/provide-go/crypto"
+)
+
+var (
+	secp256k1N     = ethcrypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+var _ crypto.EVMTxSigner = (*Signer)(nil)
+
+// Backend is a cloud KMS holding a secp256k1 signing key
+type Backend interface {
+	// PublicKey returns the DER-encoded SubjectPublicKeyInfo of the key
+	PublicKey(ctx context.Context) ([]byte, error)
+
+	// Sign signs the given 32-byte digest, returning a DER-encoded ECDSA signature
+	Sign(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Signer signs EVM transactions using a secp256k1 key custodied by a cloud KMS; the private key
+// never leaves the KMS
+type Signer struct {
+	Address common.Address
+
+	backend Backend
+}
+
+// NewSigner initializes a Signer for the given KMS backend; the signing address is resolved from
+// the public key of the KMS key
+func NewSigner(ctx context.Context, backend Backend) (*Signer, error) {
+	der, err := backend.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve KMS public key; %s", err.Error())
+	}
+
+	address, err := addressFromDER(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		Address: address,
+		backend: backend,
+	}, nil
+}
+
+// SignHash signs the given 32-byte digest, returning the signature in the [R || S || V] format
+// expected by go-ethereum, where V is 0 or 1; the S value is normalized to the lower half of the
+// curve order, as required by EIP-2
+func (s *Signer) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("failed to sign hash; invalid digest length: %d", len(hash))
+	}
+
+	der, err := s.backend.Sign(context.TODO(), hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash using KMS; %s", err.Error())
+	}
+
+	sig, err := normalizeSignature(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return recoverSignature(hash, sig, s.Address)
+}
+
+// SignTx signs the given transaction using the given signer (i.e., as returned by crypto.EVMTxFactory)
+func (s *Signer) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	sig, err := s.SignHash(signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.WithSignature(signer, sig)
+}
+
+// SignerFn returns a bind.SignerFn suitable for use with go-ethereum contract bindings
+func (s *Signer) SignerFn() bind.SignerFn {
+	return func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != s.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		return s.SignTx(signer, tx)
+	}
+}
+
+// TransactOpts returns bind.TransactOpts which sign transactions using the KMS key
+func (s *Signer) TransactOpts() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:   s.Address,
+		Signer: s.SignerFn(),
+	}
+}
+
+// addressFromDER resolves the address of the given DER-encoded SubjectPublicKeyInfo; the standard
+// library x509 parser does not support the secp256k1 curve
+func addressFromDER(der []byte) (common.Address, error) {
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.RawValue `asn1:"optional"`
+		}
+		PublicKey asn1.BitString
+	}
+	_, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to parse KMS public key; %s", err.Error())
+	}
+
+	pubkey, err := ethcrypto.UnmarshalPubkey(spki.PublicKey.Bytes)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to parse KMS public key; %s", err.Error())
+	}
+	return ethcrypto.PubkeyToAddress(*pubkey), nil
+}
+
+// normalizeSignature converts the given DER-encoded signature to [R || S], with S in the lower
+// half of the curve order
+func normalizeSignature(der []byte) ([]byte, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	_, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KMS signature; %s", err.Error())
+	}
+
+	if sig.S.Cmp(secp256k1HalfN) > 0 {
+		sig.S = new(big.Int).Sub(secp256k1N, sig.S)
+	}
+
+	return append(common.LeftPadBytes(sig.R.Bytes(), 32), common.LeftPadBytes(sig.S.Bytes(), 32)...), nil
+}
+
+// recoverSignature appends the recovery id for which the given [R || S] signature recovers the address
+func recoverSignature(hash, sig []byte, address common.Address) ([]byte, error) {
+	for v := byte(0); v < 2; v++ {
+		candidate := append(append([]byte{}, sig...), v)
+		pubkey, err := ethcrypto.Ecrecover(hash, candidate)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(ethcrypto.Keccak256(pubkey[1:])[12:], address.Bytes()) {
+			return candidate, nil
+		}
+	}
+	return nil, errors.New("failed to recover signature recovery id")
+}
@@ -0,0 +1,135 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// testBackend signs using a local key, returning high-s signatures to exercise normalization
+type testBackend struct {
+	key *ecdsa.PrivateKey
+}
+
+func (b *testBackend) PublicKey(ctx context.Context) ([]byte, error) {
+	return testPublicKeyDER(b.key), nil
+}
+
+func (b *testBackend) Sign(ctx context.Context, digest []byte) ([]byte, error) {
+	sig, err := ethcrypto.Sign(digest, b.key)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Int).SetBytes(sig[0:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if s.Cmp(secp256k1HalfN) <= 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func testPublicKeyDER(key *ecdsa.PrivateKey) []byte {
+	type algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	der, _ := asn1.Marshal(struct {
+		Algorithm algorithm
+		PublicKey asn1.BitString
+	}{
+		Algorithm: algorithm{
+			Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+			Parameters: asn1.ObjectIdentifier{1, 3, 132, 0, 10},
+		},
+		PublicKey: asn1.BitString{Bytes: ethcrypto.FromECDSAPub(&key.PublicKey), BitLength: 520},
+	})
+	return der
+}
+
+func TestSignerSignTx(t *testing.T) {
+	key, _ := ethcrypto.GenerateKey()
+	signer, err := NewSigner(context.TODO(), &testBackend{key: key})
+	if err != nil {
+		t.Fatalf("failed to initialize KMS signer; %s", err.Error())
+	}
+	if signer.Address != ethcrypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("unexpected signer address %s", signer.Address.Hex())
+	}
+
+	txSigner := types.NewEIP155Signer(big.NewInt(5))
+	tx := types.NewTransaction(0, common.HexToAddress("0x01"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	signedTx, err := signer.SignTx(txSigner, tx)
+	if err != nil {
+		t.Fatalf("failed to sign tx; %s", err.Error())
+	}
+
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil || sender != signer.Address {
+		t.Errorf("unexpected tx sender %s; %v", sender.Hex(), err)
+	}
+
+	_, _, s := signedTx.RawSignatureValues()
+	if s.Cmp(secp256k1HalfN) > 0 {
+		t.Error("expected low-s signature")
+	}
+}
+
+func TestGCPBackend(t *testing.T) {
+	key, _ := ethcrypto.GenerateKey()
+	local := &testBackend{key: key}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/keys/1/publicKey":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: testPublicKeyDER(key)})),
+			})
+		case "/keys/1:asymmetricSign":
+			var req struct {
+				Digest struct {
+					SHA256 []byte `json:"sha256"`
+				} `json:"digest"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			sig, _ := local.Sign(r.Context(), req.Digest.SHA256)
+			json.NewEncoder(w).Encode(map[string]interface{}{"signature": sig})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	backend, _ := NewGCPBackend("keys/1", func(ctx context.Context) (string, error) {
+		return "token", nil
+	})
+	backend.BaseURL = srv.URL
+
+	signer, err := NewSigner(context.TODO(), backend)
+	if err != nil {
+		t.Fatalf("failed to initialize GCP KMS signer; %s", err.Error())
+	}
+
+	hash := ethcrypto.Keccak256([]byte("hello"))
+	sig, err := signer.SignHash(hash)
+	if err != nil {
+		t.Fatalf("failed to sign hash; %s", err.Error())
+	}
+	pubkey, err := ethcrypto.SigToPub(hash, sig)
+	if err != nil || ethcrypto.PubkeyToAddress(*pubkey) != signer.Address {
+		t.Error("signature does not recover the signer address")
+	}
+}
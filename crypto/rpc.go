@@ -0,0 +1,196 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	uuid "github.com/kthomas/go.uuid"
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+// RPCOptions are per-call options for InvokeRPC
+type RPCOptions struct {
+	// Headers are added to the request, i.e. API keys required by authenticated providers
+	Headers map[string]string
+
+	// ID is the JSON-RPC request id; one is generated using the configured RPCIDGenerator when nil
+	ID interface{}
+
+	// Timeout overrides the default JSON-RPC timeout
+	Timeout time.Duration
+
+	// DecodeMode controls null handling when decoding the result; defaults to EVMDecodeLenient
+	DecodeMode EVMDecodeMode
+
+	// MaxResponseSize overrides the default maximum response size, in bytes; a negative value
+	// disables the limit. See common.MaxResponseSize
+	MaxResponseSize int64
+}
+
+// RPCIDGenerator returns the id of the next JSON-RPC request
+type RPCIDGenerator func() interface{}
+
+var rpcIDGenerator atomic.Value
+
+// UUIDRPCIDGenerator generates a random uuid for each JSON-RPC request; this is the default
+func UUIDRPCIDGenerator() interface{} {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return id.String()
+}
+
+// NewSequentialRPCIDGenerator returns a generator of sequential numeric JSON-RPC request ids
+func NewSequentialRPCIDGenerator() RPCIDGenerator {
+	var seq uint64
+	return func() interface{} {
+		return atomic.AddUint64(&seq, 1)
+	}
+}
+
+// SetRPCIDGenerator configures the generator used for JSON-RPC request ids
+func SetRPCIDGenerator(generator RPCIDGenerator) {
+	rpcIDGenerator.Store(generator)
+}
+
+func nextRPCID() interface{} {
+	if generator, ok := rpcIDGenerator.Load().(RPCIDGenerator); ok && generator != nil {
+		return generator()
+	}
+	return UUIDRPCIDGenerator()
+}
+
+// InvokeRPC invokes an arbitrary JSON-RPC method, including vendor-specific namespaces such as
+// erigon_, bor_ and trace_, and decodes its result into the given typed target; an error object
+// in the response is returned as an *EVMJsonRpcError
+func InvokeRPC(rpcClientKey, rpcURL, method string, params []interface{}, result interface{}, opts ...*RPCOptions) error {
+	var _opts *RPCOptions
+	if len(opts) > 0 && opts[0] != nil {
+		_opts = opts[0]
+	} else {
+		_opts = &RPCOptions{}
+	}
+
+	id := _opts.ID
+	if id == nil {
+		id = nextRPCID()
+	}
+	if params == nil {
+		params = make([]interface{}, 0)
+	}
+
+	body, err := invokeJsonRpc(rpcClientKey, rpcURL, method, id, params, _opts)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		evmJsonRpcEnvelope
+		ID json.RawMessage `json:"id"`
+	}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return fmt.Errorf("Failed to unmarshal %s JSON-RPC response: %s; %s", method, body, err.Error())
+	}
+
+	expectedID, _ := json.Marshal(id)
+	if len(resp.ID) > 0 && !bytes.Equal(resp.ID, expectedID) {
+		return fmt.Errorf("failed to invoke %s JSON-RPC method on %s; response id %s does not match request id %s", method, rpcClientKey, resp.ID, expectedID)
+	}
+
+	return resp.decode(method, result, _opts.DecodeMode)
+}
+
+// invokeJsonRpc posts a JSON-RPC 2.0 request, authenticated using any credentials registered for
+// the given rpc client key, and returns the raw response body
+func invokeJsonRpc(rpcClientKey, rpcURL, method string, id interface{}, params []interface{}, opts *RPCOptions) ([]byte, error) {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = rpcTimeout()
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+		},
+		Timeout: timeout,
+	}
+	payload := map[string]interface{}{
+		"method":  method,
+		"params":  params,
+		"id":      id,
+		"jsonrpc": "2.0",
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		prvdcommon.Log.Warningf("Failed to marshal JSON payload for %s JSON-RPC invocation; %s", method, err.Error())
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := rpcAuthHeaders(rpcClientKey, opts.Headers)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = hdr
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		prvdcommon.Log.Warningf("Failed to invoke JSON-RPC method: %s; %s", method, err.Error())
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	buf := new(bytes.Buffer)
+	_, err = buf.ReadFrom(rpcLimitReader(resp, opts.MaxResponseSize))
+	if err != nil {
+		prvdcommon.Log.Warningf("Failed to read JSON-RPC method %s response; %s", method, err.Error())
+		return nil, err
+	}
+	prvdcommon.Log.Debugf("Invocation of JSON-RPC method %s succeeded (%v-byte response)", method, buf.Len())
+	return buf.Bytes(), nil
+}
+
+// rpcLimitReader returns a reader over the response body which fails with common.ErrResponseTooLarge
+// once the given maximum response size (or the default, when 0) is exceeded
+func rpcLimitReader(resp *http.Response, maxResponseSize int64) io.Reader {
+	if maxResponseSize == 0 {
+		maxResponseSize = prvdcommon.MaxResponseSize()
+	}
+	if maxResponseSize < 0 {
+		return resp.Body
+	}
+	return prvdcommon.LimitReader(resp.Body, maxResponseSize)
+}
+
+// rpcLimitTransport enforces the default maximum response size on responses read by the
+// go-ethereum JSON-RPC client, which otherwise decodes responses of unbounded size
+type rpcLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *rpcLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &rpcLimitedBody{
+		Reader: rpcLimitReader(resp, 0),
+		Closer: resp.Body,
+	}
+	return resp, nil
+}
+
+type rpcLimitedBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,81 @@
+package crypto
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	prvdcommon "github.com/provideplatform/provide-go/common"
+)
+
+func TestInvokeRPCCustomMethodWithHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["method"] != "erigon_getHeaderByNumber" || r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req["id"],
+			"result":  map[string]interface{}{"number": "0x2a"},
+		})
+	}))
+	defer srv.Close()
+
+	var header struct {
+		Number string `json:"number"`
+	}
+	err := InvokeRPC("test", srv.URL, "erigon_getHeaderByNumber", []interface{}{"0x2a"}, &header, &RPCOptions{
+		Headers: map[string]string{"X-Api-Key": "secret"},
+		ID:      uint64(7),
+	})
+	if err != nil {
+		t.Fatalf("failed to invoke custom JSON-RPC method; %s", err.Error())
+	}
+	if header.Number != "0x2a" {
+		t.Errorf("unexpected result %v", header)
+	}
+}
+
+func TestInvokeRPCMismatchedID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":"other","result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	var result string
+	err := InvokeRPC("test", srv.URL, "eth_chainId", nil, &result, &RPCOptions{ID: "expected"})
+	if err == nil {
+		t.Error("expected error for mismatched response id")
+	}
+}
+
+func TestSequentialRPCIDGenerator(t *testing.T) {
+	generator := NewSequentialRPCIDGenerator()
+	if generator() != uint64(1) || generator() != uint64(2) {
+		t.Error("expected sequential request ids")
+	}
+}
+
+func TestInvokeRPCMaxResponseSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"` + strings.Repeat("0", 4096) + `"}`))
+	}))
+	defer srv.Close()
+
+	var result string
+	err := InvokeRPC("test", srv.URL, "eth_call", nil, &result, &RPCOptions{ID: 1, MaxResponseSize: 1024})
+	if !errors.Is(err, prvdcommon.ErrResponseTooLarge) {
+		t.Errorf("expected response too large error; got %v", err)
+	}
+
+	err = InvokeRPC("test", srv.URL, "eth_call", nil, &result, &RPCOptions{ID: 1, MaxResponseSize: 8192})
+	if err != nil || len(result) != 4096 {
+		t.Errorf("failed to invoke JSON-RPC method within maximum response size; %v", err)
+	}
+}
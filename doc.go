@@ -0,0 +1,13 @@
+// Package provide is the Provide golang client library.
+//
+// Clients for each of the platform APIs live under api/ (i.e., api/ident, api/vault,
+// api/nchain, api/baseline, api/privacy, api/c2); each exposes package-level functions
+// which accept a bearer token, along with an InitXService constructor for direct use of
+// the underlying api.Client. Blockchain helpers (i.e., the EVM JSON-RPC and transaction
+// signing helpers formerly in the root-level ethereum.go) live in crypto, and shared
+// utilities live in common. The version reported by api.Client in the User-Agent and
+// X-Client-Version headers is set at build time in version.
+//
+// The root package contains no functions; consumers of the legacy root-level ident.go
+// and ethereum.go helpers should import the equivalent api/ident and crypto packages.
+package provide
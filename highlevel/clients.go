@@ -0,0 +1,70 @@
+package highlevel
+
+import (
+	"errors"
+
+	"github.com/provideplatform/provide-go/api/baseline"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/api/nchain"
+	"github.com/provideplatform/provide-go/api/privacy"
+	"github.com/provideplatform/provide-go/api/vault"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// Clients are service clients pre-configured with the token of an authenticated principal
+type Clients struct {
+	Token *ident.Token
+	User  *ident.User
+
+	// MFAChallenge is present in lieu of the service clients when multi-factor authentication is
+	// required; the challenge is completed using ident.VerifyMFAChallenge and the resulting
+	// response passed to InitClients
+	MFAChallenge *ident.MFAChallenge
+
+	Ident    *ident.Service
+	Vault    *vault.Service
+	NChain   *nchain.Service
+	Baseline *baseline.Service
+	Privacy  *privacy.Service
+}
+
+// AuthenticateAndInitClients authenticates the user with the given credentials and returns service
+// clients configured for the authenticated user; when the user has enrolled in multi-factor
+// authentication, the returned clients contain only the pending MFAChallenge
+func AuthenticateAndInitClients(email, password string) (*Clients, error) {
+	authresp, err := ident.AuthenticateWithMFA(email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if authresp.MFAChallenge != nil {
+		return &Clients{
+			User:         authresp.User,
+			MFAChallenge: authresp.MFAChallenge,
+		}, nil
+	}
+
+	return InitClients(authresp)
+}
+
+// InitClients returns service clients configured for the principal of the given authentication response
+func InitClients(authresp *ident.AuthenticationResponse) (*Clients, error) {
+	if authresp == nil {
+		return nil, errors.New("failed to init clients; no authentication response provided")
+	}
+
+	token := accessToken(authresp.Token)
+	if token == "" {
+		return nil, errors.New("failed to init clients; authentication did not yield a token")
+	}
+
+	return &Clients{
+		Token:    authresp.Token,
+		User:     authresp.User,
+		Ident:    ident.InitIdentService(common.StringOrNil(token)),
+		Vault:    vault.InitVaultService(common.StringOrNil(token)),
+		NChain:   nchain.InitNChainService(token),
+		Baseline: baseline.InitBaselineService(token),
+		Privacy:  privacy.InitPrivacyService(token),
+	}, nil
+}
@@ -0,0 +1,228 @@
+package highlevel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/provideplatform/provide-go/api/baseline"
+	"github.com/provideplatform/provide-go/api/ident"
+	"github.com/provideplatform/provide-go/api/nchain"
+	"github.com/provideplatform/provide-go/api/vault"
+	"github.com/provideplatform/provide-go/common"
+)
+
+// OrganizationResources are the resources provisioned by CreateOrgWithVaultAndWallet
+type OrganizationResources struct {
+	Organization *ident.Organization
+	Token        *ident.Token // organization-scoped token
+	Vault        *vault.Vault
+	Wallet       *nchain.Wallet
+}
+
+// WorkgroupResources are the resources provisioned by DeployWorkgroupWithRegistryContract
+type WorkgroupResources struct {
+	Application *ident.Application
+	Token       *ident.Token // application-scoped token
+	Contract    *nchain.Contract
+	Workgroup   *baseline.Workgroup
+}
+
+// AuthenticateAndCreateApplication authenticates the user with the given credentials and
+// creates an application owned by the user, returning the user token and the application
+func AuthenticateAndCreateApplication(email, password, name string, params map[string]interface{}) (*ident.Token, *ident.Application, error) {
+	authresp, err := ident.Authenticate(email, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := accessToken(authresp.Token)
+	if token == "" {
+		return nil, nil, errors.New("failed to create application; authentication did not yield a token")
+	}
+
+	appParams := map[string]interface{}{}
+	for key, val := range params {
+		appParams[key] = val
+	}
+	appParams["name"] = name
+
+	app, err := ident.CreateApplication(token, appParams)
+	if err != nil {
+		return authresp.Token, nil, err
+	}
+
+	return authresp.Token, app, nil
+}
+
+// CreateOrgWithVaultAndWallet creates an organization, an organization-scoped token, a vault
+// and an HD wallet custodied by the vault; if any step fails, the resources created by the
+// completed steps are deleted, including any keys created in the vault, and the token is
+// revoked. Upon success, the caller is responsible for revoking the returned token.
+func CreateOrgWithVaultAndWallet(token, name string, params map[string]interface{}) (*OrganizationResources, error) {
+	rb := &rollback{}
+
+	orgParams := map[string]interface{}{}
+	for key, val := range params {
+		orgParams[key] = val
+	}
+	orgParams["name"] = name
+
+	org, err := ident.CreateOrganization(token, orgParams)
+	if err != nil {
+		return nil, err
+	}
+	rb.push(fmt.Sprintf("organization %s", org.ID), func() error {
+		return ident.DeleteOrganization(token, org.ID.String())
+	})
+
+	orgToken, err := ident.CreateToken(token, map[string]interface{}{
+		"organization_id": org.ID.String(),
+	})
+	if err != nil {
+		return nil, rb.run(err)
+	}
+
+	rb.push(fmt.Sprintf("organization token %s", orgToken.ID), func() error {
+		return ident.RevokeToken(token, orgToken.ID.String())
+	})
+
+	scopedToken := accessToken(orgToken)
+	if scopedToken == "" {
+		return nil, rb.run(errors.New("failed to create organization token"))
+	}
+
+	vlt, err := vault.CreateVault(scopedToken, map[string]interface{}{
+		"name":        fmt.Sprintf("%s vault", name),
+		"description": fmt.Sprintf("default vault for %s", name),
+	})
+	if err != nil {
+		return nil, rb.run(err)
+	}
+	rb.push(fmt.Sprintf("vault %s", vlt.ID), func() error {
+		return vault.DeleteVault(scopedToken, vlt.ID.String())
+	})
+	rb.push(fmt.Sprintf("vault %s keys", vlt.ID), func() error {
+		// the wallet call may have created a custodied key before failing
+		return deleteVaultKeys(scopedToken, vlt.ID.String())
+	})
+
+	wallet, err := nchain.CreateHDWallet(scopedToken, 0, map[string]interface{}{
+		"organization_id": org.ID.String(),
+		"vault_id":        vlt.ID.String(),
+	})
+	if err != nil {
+		return nil, rb.run(err)
+	}
+
+	return &OrganizationResources{
+		Organization: org,
+		Token:        orgToken,
+		Vault:        vlt,
+		Wallet:       wallet,
+	}, nil
+}
+
+// DeployWorkgroupWithRegistryContract creates a baseline application on the given network,
+// deploys the given registry contract (i.e., a compiled shield or org registry artifact)
+// on behalf of the application and initializes the workgroup; if any step fails, the
+// resources created by the completed steps are deleted and the application token is
+// revoked. Upon success, the caller is responsible for revoking the returned token.
+func DeployWorkgroupWithRegistryContract(token, name, networkID string, registry *nchain.CompiledArtifact) (*WorkgroupResources, error) {
+	if registry == nil {
+		return nil, errors.New("failed to deploy workgroup; registry contract artifact is required")
+	}
+
+	workgroupNetworkID, err := common.ParseID(networkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy workgroup; %s", err.Error())
+	}
+
+	rb := &rollback{}
+
+	app, err := ident.CreateApplication(token, map[string]interface{}{
+		"name":       name,
+		"network_id": workgroupNetworkID.String(),
+		"type":       "baseline",
+	})
+	if err != nil {
+		return nil, err
+	}
+	rb.push(fmt.Sprintf("application %s", app.ID), func() error {
+		return ident.DestroyApplication(token, app.ID.String())
+	})
+
+	appToken, err := ident.CreateApplicationToken(token, app.ID.String(), map[string]interface{}{})
+	if err != nil {
+		return nil, rb.run(err)
+	}
+
+	rb.push(fmt.Sprintf("application token %s", appToken.ID), func() error {
+		return ident.RevokeToken(token, appToken.ID.String())
+	})
+
+	scopedToken := accessToken(appToken)
+	if scopedToken == "" {
+		return nil, rb.run(errors.New("failed to create application token"))
+	}
+
+	contract, err := nchain.CreateContract(scopedToken, map[string]interface{}{
+		"address":    "0x",
+		"name":       registry.Name,
+		"network_id": networkID,
+		"params": map[string]interface{}{
+			"argv":              []interface{}{},
+			"compiled_artifact": registry,
+		},
+		"type": "registry",
+	})
+	if err != nil {
+		return nil, rb.run(err)
+	}
+	rb.push(fmt.Sprintf("contract %s", contract.ID), func() error {
+		return nchain.DeleteContract(scopedToken, contract.ID.String())
+	})
+
+	workgroupName := name
+	workgroup, err := baseline.CreateWorkgroupWithParams(scopedToken, &baseline.WorkgroupParams{
+		Name:      &workgroupName,
+		NetworkID: &workgroupNetworkID,
+	})
+	if err != nil {
+		return nil, rb.run(err)
+	}
+
+	return &WorkgroupResources{
+		Application: app,
+		Token:       appToken,
+		Contract:    contract,
+		Workgroup:   workgroup,
+	}, nil
+}
+
+// deleteVaultKeys deletes each key in the given vault
+func deleteVaultKeys(token, vaultID string) error {
+	keys, err := vault.ListKeys(token, vaultID, map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err := vault.DeleteKey(token, vaultID, key.ID.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accessToken returns the bearer token from the given token, preferring the OAuth access token
+func accessToken(token *ident.Token) string {
+	if token == nil {
+		return ""
+	}
+	if token.AccessToken != nil {
+		return *token.AccessToken
+	}
+	return common.Deref(token.Token)
+}
@@ -0,0 +1,130 @@
+package highlevel
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/provideplatform/provide-go/api/nchain"
+	"github.com/provideplatform/provide-go/common"
+)
+
+func TestDeployWorkgroupWithRegistryContractRollback(t *testing.T) {
+	networkID := common.MustParseID("024ff1ef-7369-4dee-969c-1918c6edb5d4")
+	appID := common.MustParseID("c3b55bc5-3ee5-4e6e-9d8f-2f4d2c3d5d80")
+	contractID := common.MustParseID("5d0f4d1c-8b1e-4d4e-a8d7-7d3c3f8c3a11")
+	tokenID := common.MustParseID("8f1e3c2a-6b7d-4e5f-9a0b-1c2d3e4f5a6b")
+
+	deleted := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&params)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/v1/"))
+			w.WriteHeader(204)
+		case r.URL.Path == "/api/v1/applications":
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": appID})
+		case r.URL.Path == "/api/v1/tokens":
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": tokenID, "access_token": "app-token"})
+		case r.URL.Path == "/api/v1/contracts":
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": contractID})
+		case r.URL.Path == "/api/v1/workgroups":
+			if params["network_id"] != networkID.String() {
+				t.Errorf("expected workgroup on network %s; got %v", networkID, params["network_id"])
+			}
+			w.WriteHeader(500)
+			json.NewEncoder(w).Encode(map[string]interface{}{"errors": []interface{}{map[string]interface{}{"message": "boom"}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	for _, svc := range []string{"IDENT", "NCHAIN", "BASELINE"} {
+		t.Setenv(svc+"_API_HOST", srvURL.Host)
+		t.Setenv(svc+"_API_SCHEME", srvURL.Scheme)
+	}
+
+	_, err := DeployWorkgroupWithRegistryContract("token", "workgroup", networkID.String(), &nchain.CompiledArtifact{})
+	if err == nil {
+		t.Fatal("expected workgroup creation failure to be returned")
+	}
+
+	expected := "contracts/" + contractID.String() + ",tokens/" + tokenID.String() + ",applications/" + appID.String()
+	if strings.Join(deleted, ",") != expected {
+		t.Errorf("expected contract, token and application to be rolled back; got %v", deleted)
+	}
+}
+
+func TestCreateOrgWithVaultAndWalletRollback(t *testing.T) {
+	orgID := common.MustParseID("3a0c6f7e-2d4b-4c8a-9e1f-5b6a7c8d9e0f")
+	tokenID := common.MustParseID("8f1e3c2a-6b7d-4e5f-9a0b-1c2d3e4f5a6b")
+	vaultID := common.MustParseID("6c5b4a39-2817-4f6e-8d5c-4b3a29180706")
+	keyID := common.MustParseID("0f1e2d3c-4b5a-4968-8776-655443322110")
+
+	deleted := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&params)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/api/v1/"))
+			w.WriteHeader(204)
+		case r.URL.Path == "/api/v1/organizations":
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": orgID})
+		case r.URL.Path == "/api/v1/tokens":
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": tokenID, "access_token": "org-token"})
+		case r.URL.Path == "/api/v1/vaults":
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": vaultID})
+		case r.URL.Path == "/api/v1/vaults/"+vaultID.String()+"/keys":
+			w.WriteHeader(200)
+			json.NewEncoder(w).Encode([]interface{}{map[string]interface{}{"id": keyID}})
+		case r.URL.Path == "/api/v1/wallets":
+			if params["vault_id"] != vaultID.String() {
+				t.Errorf("expected wallet custodied by vault %s; got %v", vaultID, params["vault_id"])
+			}
+			w.WriteHeader(500)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	srvURL, _ := url.Parse(srv.URL)
+	for _, svc := range []string{"IDENT", "NCHAIN", "VAULT"} {
+		t.Setenv(svc+"_API_HOST", srvURL.Host)
+		t.Setenv(svc+"_API_SCHEME", srvURL.Scheme)
+	}
+
+	_, err := CreateOrgWithVaultAndWallet("token", "org", nil)
+	if err == nil {
+		t.Fatal("expected wallet creation failure to be returned")
+	}
+
+	expected := strings.Join([]string{
+		"vaults/" + vaultID.String() + "/keys/" + keyID.String(),
+		"vaults/" + vaultID.String(),
+		"tokens/" + tokenID.String(),
+		"organizations/" + orgID.String(),
+	}, ",")
+	if strings.Join(deleted, ",") != expected {
+		t.Errorf("expected vault key, vault, token and organization to be rolled back; got %v", deleted)
+	}
+}
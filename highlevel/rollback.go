@@ -0,0 +1,43 @@
+package highlevel
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+// rollback is a stack of compensating actions undoing the completed steps of a flow
+type rollback struct {
+	steps []rollbackStep
+}
+
+type rollbackStep struct {
+	description string
+	undo        func() error
+}
+
+// push registers a compensating action for a completed step
+func (r *rollback) push(description string, undo func() error) {
+	r.steps = append(r.steps, rollbackStep{description, undo})
+}
+
+// run undoes the completed steps in reverse order, returning the given cause annotated
+// with any compensating actions which failed
+func (r *rollback) run(cause error) error {
+	failures := make([]string, 0)
+	for i := len(r.steps) - 1; i >= 0; i-- {
+		step := r.steps[i]
+		common.Log.Debugf("rolling back %s", step.description)
+		if err := step.undo(); err != nil {
+			common.Log.Warningf("failed to roll back %s; %s", step.description, err.Error())
+			failures = append(failures, fmt.Sprintf("%s: %s", step.description, err.Error()))
+		}
+	}
+	r.steps = nil
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%w; rollback incomplete: %s", cause, strings.Join(failures, "; "))
+	}
+	return cause
+}
@@ -0,0 +1,33 @@
+package highlevel
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRollbackRunsInReverseOrder(t *testing.T) {
+	order := make([]string, 0)
+	rb := &rollback{}
+	rb.push("first", func() error {
+		order = append(order, "first")
+		return nil
+	})
+	rb.push("second", func() error {
+		order = append(order, "second")
+		return errors.New("boom")
+	})
+
+	cause := errors.New("step failed")
+	err := rb.run(cause)
+
+	if strings.Join(order, ",") != "second,first" {
+		t.Errorf("expected rollback in reverse order; got %v", order)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected rollback error to wrap the cause")
+	}
+	if !strings.Contains(err.Error(), "second: boom") {
+		t.Errorf("expected rollback error to report failed compensation; got %s", err.Error())
+	}
+}
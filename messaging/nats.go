@@ -0,0 +1,211 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/provideplatform/provide-go/common"
+)
+
+const defaultNatsURL = "nats://localhost:4222"
+const defaultNatsConnectionName = "provide-go"
+const defaultNatsMaxReconnects = -1 // reconnect indefinitely
+const defaultNatsReconnectWait = time.Second * 2
+const defaultDurableConsumerAckWait = time.Second * 30
+const defaultDurableConsumerMaxDeliver = 10
+
+// SubjectBaselineInbound is the subject on which inbound baseline protocol messages are published
+const SubjectBaselineInbound = "baseline.inbound"
+
+// SubjectBaselineOutbound is the subject on which outbound baseline protocol messages are published
+const SubjectBaselineOutbound = "baseline.outbound"
+
+// TokenSource resolves the current bearer token used to authorize the NATS connection;
+// it is invoked each time the connection (re)authenticates, so rotated tokens are
+// picked up automatically (i.e., ident.TokenRefresher.AccessToken)
+type TokenSource func() (string, error)
+
+// Conn is a NATS connection authorized using a Provide-issued bearer token, with an
+// optional JetStream context
+type Conn struct {
+	*nats.Conn
+	JetStream nats.JetStreamContext
+}
+
+// Connect establishes a NATS connection authorized using the given static bearer token
+func Connect(token string, opts ...nats.Option) (*Conn, error) {
+	return ConnectWithTokenSource(func() (string, error) {
+		return token, nil
+	}, opts...)
+}
+
+// ConnectWithTokenSource establishes a NATS connection authorized using the bearer token
+// resolved from the given source; NATS_URL may contain a comma-separated list of servers
+func ConnectWithTokenSource(source TokenSource, opts ...nats.Option) (*Conn, error) {
+	if source == nil {
+		return nil, errors.New("failed to connect to NATS; token source is required")
+	}
+
+	natsURL := defaultNatsURL
+	if os.Getenv("NATS_URL") != "" {
+		natsURL = os.Getenv("NATS_URL")
+	}
+
+	options := []nats.Option{
+		nats.Name(defaultNatsConnectionName),
+		nats.MaxReconnects(defaultNatsMaxReconnects),
+		nats.ReconnectWait(defaultNatsReconnectWait),
+		nats.TokenHandler(func() string {
+			token, err := source()
+			if err != nil {
+				common.Log.Warningf("failed to resolve bearer token for NATS connection; %s", err.Error())
+				return ""
+			}
+			return token
+		}),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				common.Log.Debugf("NATS connection disconnected; %s", err.Error())
+			}
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			common.Log.Debugf("NATS connection reconnected to %s", nc.ConnectedUrl())
+		}),
+	}
+	options = append(options, opts...)
+
+	nc, err := nats.Connect(natsURL, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s; %s", natsURL, err.Error())
+	}
+
+	conn := &Conn{Conn: nc}
+	js, err := nc.JetStream()
+	if err != nil {
+		common.Log.Debugf("JetStream not available on NATS connection; %s", err.Error())
+	} else {
+		conn.JetStream = js
+	}
+
+	return conn, nil
+}
+
+// PublishJSON marshals the given value as JSON and publishes it to the given subject
+func (c *Conn) PublishJSON(subject string, v interface{}) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message for subject %s; %s", subject, err.Error())
+	}
+
+	return c.Publish(subject, payload)
+}
+
+// PublishJSONDurable marshals the given value as JSON and publishes it to the JetStream
+// stream bound to the given subject, waiting for the publish to be acknowledged
+func (c *Conn) PublishJSONDurable(subject string, v interface{}) (*nats.PubAck, error) {
+	if c.JetStream == nil {
+		return nil, errors.New("failed to publish durable message; JetStream not available")
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message for subject %s; %s", subject, err.Error())
+	}
+
+	return c.JetStream.Publish(subject, payload)
+}
+
+// SubscribeJSON subscribes to the given subject, unmarshaling each message into a new value
+// returned by factory prior to invoking the handler; when queue is non-empty, messages are
+// distributed across the members of the queue group
+func (c *Conn) SubscribeJSON(subject, queue string, factory func() interface{}, handler func(*nats.Msg, interface{})) (*nats.Subscription, error) {
+	cb := func(msg *nats.Msg) {
+		v := factory()
+		err := json.Unmarshal(msg.Data, v)
+		if err != nil {
+			common.Log.Warningf("failed to unmarshal message on subject %s; %s", msg.Subject, err.Error())
+			return
+		}
+		handler(msg, v)
+	}
+
+	if queue != "" {
+		return c.QueueSubscribe(subject, queue, cb)
+	}
+	return c.Subscribe(subject, cb)
+}
+
+// EnsureStream creates the given JetStream stream capturing the given subjects, if it does not exist
+func (c *Conn) EnsureStream(name string, subjects ...string) (*nats.StreamInfo, error) {
+	if c.JetStream == nil {
+		return nil, errors.New("failed to ensure stream; JetStream not available")
+	}
+
+	info, err := c.JetStream.StreamInfo(name)
+	if err == nil {
+		return info, nil
+	}
+	if !errors.Is(err, nats.ErrStreamNotFound) {
+		return nil, fmt.Errorf("failed to resolve stream %s; %s", name, err.Error())
+	}
+
+	return c.JetStream.AddStream(&nats.StreamConfig{
+		Name:     name,
+		Subjects: subjects,
+	})
+}
+
+// CreateDurableConsumer creates (or returns the existing) durable, explicitly-acknowledged push
+// consumer of the given stream, optionally filtered to the given subject; messages are delivered
+// to the deliver group named after the durable, so they are distributed across each process
+// subscribed using SubscribeDurable
+func (c *Conn) CreateDurableConsumer(stream, durable, filterSubject string, ackWait time.Duration) (*nats.ConsumerInfo, error) {
+	if c.JetStream == nil {
+		return nil, errors.New("failed to create durable consumer; JetStream not available")
+	}
+
+	info, err := c.JetStream.ConsumerInfo(stream, durable)
+	if err == nil {
+		if info.Config.DeliverSubject == "" {
+			return nil, fmt.Errorf("failed to create durable consumer; existing consumer %s of stream %s is a pull consumer", durable, stream)
+		}
+		return info, nil
+	}
+
+	if ackWait <= 0 {
+		ackWait = defaultDurableConsumerAckWait
+	}
+
+	return c.JetStream.AddConsumer(stream, &nats.ConsumerConfig{
+		Durable:        durable,
+		DeliverSubject: nats.NewInbox(),
+		DeliverGroup:   durable,
+		DeliverPolicy:  nats.DeliverAllPolicy,
+		AckPolicy:      nats.AckExplicitPolicy,
+		AckWait:        ackWait,
+		MaxDeliver:     defaultDurableConsumerMaxDeliver,
+		FilterSubject:  filterSubject,
+	})
+}
+
+// SubscribeDurable subscribes to the given subject using the given durable consumer, created
+// using CreateDurableConsumer; messages must be acknowledged by the handler (i.e., msg.Ack())
+// or they will be redelivered
+func (c *Conn) SubscribeDurable(subject, durable string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	if c.JetStream == nil {
+		return nil, errors.New("failed to subscribe durable consumer; JetStream not available")
+	}
+
+	return c.JetStream.QueueSubscribe(subject, durable, handler, nats.Durable(durable), nats.ManualAck())
+}
+
+// Subject joins the given tokens into a NATS subject (i.e., Subject("baseline", "inbound"))
+func Subject(tokens ...string) string {
+	return strings.Join(tokens, ".")
+}
@@ -0,0 +1,63 @@
+package messaging
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// TestDurableConsumer requires a JetStream-enabled NATS server, i.e., nats-server -js; set
+// NATS_JETSTREAM_TEST_URL to run it
+func TestDurableConsumer(t *testing.T) {
+	natsURL := os.Getenv("NATS_JETSTREAM_TEST_URL")
+	if natsURL == "" {
+		t.Skip("NATS_JETSTREAM_TEST_URL not set")
+	}
+	t.Setenv("NATS_URL", natsURL)
+
+	conn, err := Connect("")
+	if err != nil {
+		t.Fatalf("failed to connect; %s", err.Error())
+	}
+	defer conn.Close()
+
+	stream := "PROVIDE_GO_TEST"
+	subject := Subject("provide-go", "test", nats.NewInbox()[7:])
+	defer conn.JetStream.DeleteStream(stream)
+
+	_, err = conn.EnsureStream(stream, "provide-go.test.>")
+	if err != nil {
+		t.Fatalf("failed to ensure stream; %s", err.Error())
+	}
+
+	_, err = conn.CreateDurableConsumer(stream, "durable", subject, 0)
+	if err != nil {
+		t.Fatalf("failed to create durable consumer; %s", err.Error())
+	}
+
+	received := make(chan *nats.Msg, 1)
+	sub, err := conn.SubscribeDurable(subject, "durable", func(msg *nats.Msg) {
+		msg.Ack()
+		received <- msg
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe durable consumer; %s", err.Error())
+	}
+	defer sub.Unsubscribe()
+
+	_, err = conn.PublishJSONDurable(subject, map[string]interface{}{"hello": "world"})
+	if err != nil {
+		t.Fatalf("failed to publish durable message; %s", err.Error())
+	}
+
+	select {
+	case msg := <-received:
+		if string(msg.Data) != `{"hello":"world"}` {
+			t.Errorf("unexpected message; %s", msg.Data)
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("timed out waiting for durable message")
+	}
+}
@@ -0,0 +1,33 @@
+// Package version describes the provide-go build; the values are set at build time via ldflags,
+// i.e., -ldflags "-X github.com/provideplatform/provide-go/version.Version=v1.2.3"
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Product is the name reported in the User-Agent of requests sent by provide-go
+const Product = "provide-go"
+
+// Version is the provide-go version
+var Version = "dev"
+
+// Commit is the git commit from which provide-go was built
+var Commit = ""
+
+// BuildDate is the time at which provide-go was built
+var BuildDate = ""
+
+// String returns the version, including the commit when known
+func String() string {
+	if Commit == "" {
+		return Version
+	}
+	return fmt.Sprintf("%s+%s", Version, Commit)
+}
+
+// UserAgent returns the User-Agent reported by provide-go, i.e., provide-go/v1.2.3 (linux; amd64; go1.18)
+func UserAgent() string {
+	return fmt.Sprintf("%s/%s (%s; %s; %s)", Product, String(), runtime.GOOS, runtime.GOARCH, runtime.Version())
+}